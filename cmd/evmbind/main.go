@@ -0,0 +1,59 @@
+// Command evmbind generates the Go binding of a contract for the evm client of exchain-go-sdk.
+//
+// Usage with go:generate:
+//
+//	//go:generate go run github.com/okex/exchain-go-sdk/cmd/evmbind -abi token.abi -bin token.bin -type Token -pkg token -out token.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/okex/exchain-go-sdk/module/evm/bind"
+)
+
+func main() {
+	abiFile := flag.String("abi", "", "path to the abi json of the contract")
+	binFile := flag.String("bin", "", "path to the bytecode of the contract, and no deploy function is generated if empty")
+	typeName := flag.String("type", "", "name of the Go type of the binding")
+	pkgName := flag.String("pkg", "", "name of the Go package of the binding")
+	outFile := flag.String("out", "", "path to the output file, and stdout if empty")
+	flag.Parse()
+
+	if err := run(*abiFile, *binFile, *typeName, *pkgName, *outFile); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(abiFile, binFile, typeName, pkgName, outFile string) error {
+	if len(abiFile) == 0 || len(typeName) == 0 || len(pkgName) == 0 {
+		return fmt.Errorf("failed. the flags -abi, -type and -pkg are required")
+	}
+
+	abiJSON, err := ioutil.ReadFile(abiFile)
+	if err != nil {
+		return fmt.Errorf("failed. read abi file error: %s", err)
+	}
+
+	var bytecode []byte
+	if len(binFile) != 0 {
+		if bytecode, err = ioutil.ReadFile(binFile); err != nil {
+			return fmt.Errorf("failed. read bin file error: %s", err)
+		}
+	}
+
+	code, err := bind.Generate(typeName, pkgName, string(abiJSON), string(bytecode))
+	if err != nil {
+		return err
+	}
+
+	if len(outFile) == 0 {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	return ioutil.WriteFile(outFile, code, 0644)
+}
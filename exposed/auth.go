@@ -14,4 +14,6 @@ type Auth interface {
 // AuthQuery shows the expected query behavior for inner auth client
 type AuthQuery interface {
 	QueryAccount(accAddrStr string) (types.Account, error)
+	QueryAccountView(accAddrStr string) (types.AccountView, error)
+	QueryAccounts(accAddrsStr []string, concurrency int) []types.AccountResult
 }
@@ -1,6 +1,7 @@
 package exposed
 
 import (
+	"github.com/okex/exchain-go-sdk/module/distribution/types"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
@@ -10,10 +11,35 @@ import (
 type Distribution interface {
 	gosdktypes.Module
 	DistrTx
+	DistrQuery
+	DistrUtils
 }
 
 // DistrTx shows the expected tx behavior for inner distribution client
 type DistrTx interface {
 	SetWithdrawAddr(fromInfo keys.Info, passWd, withdrawAddrStr, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 	WithdrawRewards(fromInfo keys.Info, passWd, valAddrStr, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
+	WithdrawDelegatorReward(fromInfo keys.Info, passWd, valAddrStr, memo string, accNum, seqNum uint64) (sdk.TxResponse,
+		error)
+	WithdrawDelegatorAllRewards(fromInfo keys.Info, passWd, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
+}
+
+// DistrQuery shows the expected query behavior for inner distribution client
+type DistrQuery interface {
+	QueryValidatorOutstandingRewards(valAddrStr string) (sdk.SysCoins, error)
+	QueryValidatorCommission(valAddrStr string) (sdk.SysCoins, error)
+	QueryCommunityPool() (sdk.SysCoins, error)
+	QueryWithdrawAddress(delAddrStr string) (sdk.AccAddress, error)
+	QueryParams() (types.Params, error)
+	QueryDelegationRewards(delAddrStr, valAddrStr string) (sdk.SysCoins, error)
+	QueryDelegatorTotalRewards(delAddrStr string) (types.DelegatorTotalRewards, error)
+}
+
+// DistrUtils shows the expected utils behavior for inner distribution client
+type DistrUtils interface {
+	ParseWithdrawRewardsEvents(events sdk.StringEvents) ([]types.WithdrawRewardsEvent, error)
+	ParseWithdrawCommissionEvents(events sdk.StringEvents) ([]types.WithdrawRewardsEvent, error)
+	ParseSetWithdrawAddressEvents(events sdk.StringEvents) ([]types.SetWithdrawAddressEvent, error)
+	GetRewards(resp sdk.TxResponse) (sdk.SysCoins, error)
+	BuildWithdrawDelegatorRewardMsgs(delAddrStr string, valAddrsStr []string) ([]sdk.Msg, error)
 }
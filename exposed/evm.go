@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethcore "github.com/ethereum/go-ethereum/core/types"
@@ -11,6 +12,7 @@ import (
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	rpctypes "github.com/okx/okbchain/app/rpc/types"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
 )
 
 // Evm shows the expected behavior for inner farm client
@@ -20,6 +22,8 @@ type Evm interface {
 	EvmQuery
 	EvmUtils
 	web3Getter
+	erc20Getter
+	nftGetter
 }
 
 // EvmTx shows the expected tx behavior for inner evm client
@@ -32,10 +36,29 @@ type EvmTx interface {
 type EvmQuery interface {
 	QueryCode(contractAddrStr string) (types.QueryResCode, error)
 	QueryStorageAt(contractAddrStr, keyHexStr string) (types.QueryResStorage, error)
+	FilterLogs(filter types.LogFilter) (types.LogsPage, error)
+	ScanLogs(filter types.LogFilter, handler func(types.LogsPage) error) (nextBlock int64, err error)
+	QueryReceipt(txHash ethcmn.Hash) (types.Receipt, error)
+	GetReceipt(resTx *types.ResultTx) (types.Receipt, error)
 }
 
 type EvmUtils interface {
 	GetTxHash(signedTx *ethcore.Transaction) (ethcmn.Hash, error)
+	GetEthTxHash(tx tmtypes.Tx) (ethcmn.Hash, error)
+	ParseEthereumTxEvents(events sdk.StringEvents) ([]types.EthereumTxEvent, error)
+	DecodeResultData(data []byte) (types.ResultData, error)
+	GetResultData(resp sdk.TxResponse) (types.ResultData, error)
+	GetContractAddress(resp sdk.TxResponse) (ethcmn.Address, error)
+	DecodeLogs(abiJSON string, logs []*ethcore.Log) ([]types.DecodedLog, error)
+}
+
+type erc20Getter interface {
+	ERC20() ERC20
+}
+
+type nftGetter interface {
+	ERC721() ERC721
+	ERC1155() ERC1155
 }
 
 type web3Getter interface {
@@ -44,11 +67,113 @@ type web3Getter interface {
 
 // Web3Proxy shows the expected behavior as Web3 without rest server routing
 type Web3Proxy interface {
+	Web3ProxyTx
 	Web3ProxyQuery
 }
 
+// Web3ProxyTx shows the expected behavior as web3 tx request
+type Web3ProxyTx interface {
+	SendRawTransactionProxy(data hexutil.Bytes) (ethcmn.Hash, error)
+}
+
 // Web3Query shows the expected behavior as web3 query request
 type Web3ProxyQuery interface {
 	BlockNumberProxy() (hexutil.Uint64, error)
 	EstimateGasProxy(args rpctypes.CallArgs) (hexutil.Uint64, error)
+	CallProxy(args rpctypes.CallArgs, blockNum rpctypes.BlockNumber) (hexutil.Bytes, error)
+	GetBalanceProxy(addr ethcmn.Address, blockNum rpctypes.BlockNumber) (*hexutil.Big, error)
+	GetTransactionCountProxy(addr ethcmn.Address, blockNum rpctypes.BlockNumber) (*hexutil.Uint64, error)
+	GetCodeProxy(addr ethcmn.Address, blockNum rpctypes.BlockNumber) (hexutil.Bytes, error)
+	GetStorageAtProxy(addr ethcmn.Address, keyStr string, blockNum rpctypes.BlockNumber) (hexutil.Bytes, error)
+	GetBlockByNumberProxy(blockNum rpctypes.BlockNumber, fullTx bool) (*types.Block, error)
+	GetBlockByHashProxy(hash ethcmn.Hash, fullTx bool) (*types.Block, error)
+	GetTransactionByHashProxy(hash ethcmn.Hash) (*types.Transaction, error)
+	GetTransactionReceiptProxy(hash ethcmn.Hash) (*types.TransactionReceipt, error)
+	GetLogsProxy(query ethereum.FilterQuery) ([]*ethcore.Log, error)
+}
+
+// ERC20 shows the expected behavior of the helper for the erc20 contracts
+type ERC20 interface {
+	ERC20Tx
+	ERC20Query
+	ERC20Utils
+}
+
+// ERC20Tx shows the expected tx behavior of the erc20 helper, whose amount is the decimal string with the decimals of
+// the token, such as "1.5"
+type ERC20Tx interface {
+	Transfer(opts *types.TransactOpts, contractAddr, to ethcmn.Address, amountStr string) (sdk.TxResponse, error)
+	Approve(opts *types.TransactOpts, contractAddr, spender ethcmn.Address, amountStr string) (sdk.TxResponse, error)
+	TransferFrom(opts *types.TransactOpts, contractAddr, from, to ethcmn.Address, amountStr string) (sdk.TxResponse, error)
+}
+
+// ERC20Query shows the expected query behavior of the erc20 helper on the latest block
+type ERC20Query interface {
+	Name(contractAddr ethcmn.Address) (string, error)
+	Symbol(contractAddr ethcmn.Address) (string, error)
+	Decimals(contractAddr ethcmn.Address) (uint8, error)
+	TotalSupply(contractAddr ethcmn.Address) (types.TokenAmount, error)
+	BalanceOf(contractAddr, owner ethcmn.Address) (types.TokenAmount, error)
+	Allowance(contractAddr, owner, spender ethcmn.Address) (types.TokenAmount, error)
+}
+
+// ERC20Utils shows the expected utils behavior of the erc20 helper
+type ERC20Utils interface {
+	DecodeERC20Logs(logs []*ethcore.Log) ([]types.ERC20Event, error)
+	ParseERC20Events(resp sdk.TxResponse) ([]types.ERC20Event, error)
+}
+
+// ERC721 shows the expected behavior of the helper for the erc721 contracts
+type ERC721 interface {
+	ERC721Tx
+	ERC721Query
+	ERC721Utils
+}
+
+// ERC721Tx shows the expected tx behavior of the erc721 helper
+type ERC721Tx interface {
+	SafeTransferFrom(opts *types.TransactOpts, contractAddr, from, to ethcmn.Address, tokenID *big.Int, data []byte) (sdk.TxResponse, error)
+	Approve(opts *types.TransactOpts, contractAddr, to ethcmn.Address, tokenID *big.Int) (sdk.TxResponse, error)
+	SetApprovalForAll(opts *types.TransactOpts, contractAddr, operator ethcmn.Address, approved bool) (sdk.TxResponse, error)
+}
+
+// ERC721Query shows the expected query behavior of the erc721 helper on the latest block
+type ERC721Query interface {
+	OwnerOf(contractAddr ethcmn.Address, tokenID *big.Int) (ethcmn.Address, error)
+	TokenURI(contractAddr ethcmn.Address, tokenID *big.Int) (string, error)
+	BalanceOf(contractAddr, owner ethcmn.Address) (*big.Int, error)
+	SupportsInterface(contractAddr ethcmn.Address, interfaceID [4]byte) (bool, error)
+	IsERC721(contractAddr ethcmn.Address) (bool, error)
+}
+
+// ERC721Utils shows the expected utils behavior of the erc721 helper
+type ERC721Utils interface {
+	DecodeERC721Logs(logs []*ethcore.Log) ([]types.ERC721Transfer, error)
+	ParseERC721Events(resp sdk.TxResponse) ([]types.ERC721Transfer, error)
+}
+
+// ERC1155 shows the expected behavior of the helper for the erc1155 contracts
+type ERC1155 interface {
+	ERC1155Tx
+	ERC1155Query
+	ERC1155Utils
+}
+
+// ERC1155Tx shows the expected tx behavior of the erc1155 helper
+type ERC1155Tx interface {
+	SafeBatchTransferFrom(opts *types.TransactOpts, contractAddr, from, to ethcmn.Address, ids, amounts []*big.Int, data []byte) (sdk.TxResponse, error)
+}
+
+// ERC1155Query shows the expected query behavior of the erc1155 helper on the latest block
+type ERC1155Query interface {
+	BalanceOfBatch(contractAddr ethcmn.Address, owners []ethcmn.Address, ids []*big.Int) ([]*big.Int, error)
+	URI(contractAddr ethcmn.Address, id *big.Int) (string, error)
+	SupportsInterface(contractAddr ethcmn.Address, interfaceID [4]byte) (bool, error)
+	IsERC1155(contractAddr ethcmn.Address) (bool, error)
+}
+
+// ERC1155Utils shows the expected utils behavior of the erc1155 helper
+type ERC1155Utils interface {
+	DecodeERC1155Logs(logs []*ethcore.Log) ([]types.ERC1155Transfer, error)
+	ParseERC1155Events(resp sdk.TxResponse) ([]types.ERC1155Transfer, error)
 }
@@ -12,6 +12,7 @@ type Governance interface {
 	gosdktypes.Module
 	GovTx
 	GovQuery
+	GovUtils
 }
 
 // GovTx shows the expected tx behavior for inner governance client
@@ -19,6 +20,7 @@ type GovTx interface {
 	SubmitTextProposal(fromInfo keys.Info, passWd, proposalPath, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 	SubmitParamsChangeProposal(fromInfo keys.Info, passWd, proposalPath, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 	SubmitCommunityPoolSpendProposal(fromInfo keys.Info, passWd, proposalPath, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
+	SubmitProposal(fromInfo keys.Info, passWd string, proposal types.ProposalContent, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 	Deposit(fromInfo keys.Info, passWd, depositCoinsStr, memo string, proposalID, accNum, seqNum uint64) (sdk.TxResponse, error)
 	Vote(fromInfo keys.Info, passWd, voteOption, memo string, proposalID, accNum, seqNum uint64) (sdk.TxResponse, error)
 }
@@ -26,4 +28,19 @@ type GovTx interface {
 // GovQuery shows the expected query behavior for inner governance client
 type GovQuery interface {
 	QueryProposals(depositorAddrStr, voterAddrStr, status string, numLimit uint64) ([]types.Proposal, error)
+	QueryProposal(proposalID uint64) (types.Proposal, error)
+	QueryVotes(proposalID uint64) ([]types.Vote, error)
+	QueryVote(proposalID uint64, voterAddrStr string) (types.Vote, error)
+	QueryDeposits(proposalID uint64) ([]types.Deposit, error)
+	QueryDeposit(proposalID uint64, depositorAddrStr string) (types.Deposit, error)
+	QueryTally(proposalID uint64) (types.TallyResult, error)
+	QueryParams() (types.Params, error)
+}
+
+// GovUtils shows the expected utils behavior for inner governance client
+type GovUtils interface {
+	ParseSubmitProposalEvents(events sdk.StringEvents) ([]types.SubmitProposalEvent, error)
+	ParseProposalDepositEvents(events sdk.StringEvents) ([]types.ProposalDepositEvent, error)
+	ParseProposalVoteEvents(events sdk.StringEvents) ([]types.ProposalVoteEvent, error)
+	GetProposalID(resp sdk.TxResponse) (uint64, error)
 }
@@ -1,6 +1,7 @@
 package exposed
 
 import (
+	"github.com/okex/exchain-go-sdk/module/slashing/types"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
@@ -10,9 +11,18 @@ import (
 type Slashing interface {
 	gosdktypes.Module
 	SlashingTx
+	SlashingQuery
 }
 
 // SlashingTx shows the expected tx behavior for inner slashing client
 type SlashingTx interface {
 	Unjail(fromInfo keys.Info, passWd, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 }
+
+// SlashingQuery shows the expected query behavior for inner slashing client
+type SlashingQuery interface {
+	QueryParams() (types.Params, error)
+	QuerySigningInfo(consAddrStr string) (types.ValidatorSigningInfo, error)
+	QuerySigningInfos(page, limit int) ([]types.ValidatorSigningInfo, error)
+	QueryMissedBlocks(consAddrStr string) ([]types.MissedBlock, error)
+}
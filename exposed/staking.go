@@ -35,4 +35,10 @@ type StakingQuery interface {
 	QueryValidators() ([]types.Validator, error)
 	QueryValidator(valAddrStr string) (types.Validator, error)
 	QueryDelegator(delAddrStr string) (types.DelegatorResponse, error)
+	QueryValidatorsByStatus(status string, height int64, page, limit int) (types.ValidatorsResult, error)
+	QueryValidatorAllShares(valAddrStr string, height int64) (types.ValidatorSharesResult, error)
+	QueryProxyDelegators(proxyAddrStr string, height int64) (types.ProxyDelegatorsResult, error)
+	QueryParams(height int64) (types.Params, error)
+	QueryPool(height int64) (types.Pool, error)
+	QueryUnbonding(delAddrStr string, height int64) (types.UndelegationInfo, error)
 }
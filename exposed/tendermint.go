@@ -18,8 +18,11 @@ type TendermintQuery interface {
 	QueryBlockResults(height int64) (*types.ResultBlockResults, error)
 	QueryCommitResult(height int64) (*types.ResultCommit, error)
 	QueryValidatorsResult(height int64) (*types.ResultValidators, error)
+	QueryValidatorsResultByPage(height int64, page, limit int) (*types.ResultValidators, error)
 	QueryTxResult(hashHexStr string, prove bool) (*types.ResultTx, error)
 	// QueryTxsByEvents assumes the node to query a truth teller
 	QueryTxsByEvents(eventsStr string, page, limit int) (*ctypes.ResultTxSearch, error)
 	QueryStatus() (*ctypes.ResultStatus, error)
+	// QueryHistory assumes the node to query a truth teller
+	QueryHistory(addrStr string, fromHeight, toHeight int64, page, limit int) (types.TxHistory, error)
 }
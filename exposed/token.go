@@ -12,6 +12,7 @@ type Token interface {
 	gosdktypes.Module
 	TokenTx
 	TokenQuery
+	TokenUtils
 }
 
 // TokenTx shows the expected tx behavior for inner token client
@@ -30,4 +31,15 @@ type TokenTx interface {
 // TokenQuery shows the expected query behavior for inner token client
 type TokenQuery interface {
 	QueryTokenInfo(ownerAddr, symbol string) ([]types.TokenResp, error)
+	QueryTokens(height int64, page, limit int) (types.TokensResult, error)
+	QuerySupply(height int64, page, limit int) (types.SupplyResult, error)
+	QuerySupplyOf(denom string, height int64) (sdk.Dec, error)
+	QueryBalances(addrStr string, height int64, page, limit int) (types.BalancesResult, error)
+	QueryBalance(addrStr, denom string, height int64) (types.CoinInfo, error)
+}
+
+// TokenUtils shows the expected utils behavior for inner token client
+type TokenUtils interface {
+	ParseTransferEvents(events sdk.StringEvents) ([]types.TransferEvent, error)
+	GetTransfers(resp sdk.TxResponse) ([]types.TransferEvent, error)
 }
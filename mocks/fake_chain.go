@@ -0,0 +1,285 @@
+package mocks
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	authtypes "github.com/okex/exchain-go-sdk/module/auth/types"
+	staking "github.com/okex/exchain-go-sdk/module/staking/types"
+	tendermint "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	tmbytes "github.com/okx/okbchain/libs/tendermint/libs/bytes"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	stakingtypes "github.com/okx/okbchain/x/staking/types"
+)
+
+// FakeChain - structure of an in-memory chain for testing the routines built on the module clients, which keeps the
+// sequence of the sender, the latest block and the txs committed
+// Note: only the methods used by the routines are faked, and the others of the module clients panic. A test overrides
+// or adds the methods of its own modules on top of the fake module clients, and commits their txs by Commit
+type FakeChain struct {
+	AccountNumber uint64
+	Sequence      uint64
+	Height        int64
+	BlockTime     time.Time
+	CatchingUp    bool
+	Txs           []*ctypes.ResultTx
+	// BroadcastBlock makes the responses of the txs committed as the ones in the block broadcast mode
+	BroadcastBlock bool
+	// Pend makes the next tx accepted by the mempool but never committed
+	Pend bool
+	// Reject makes the next tx rejected by the mempool
+	Reject bool
+	// Crash makes the next tx committed without returning its response
+	Crash bool
+	// Fail makes the next tx committed with a non-zero code
+	Fail bool
+
+	Validators    []staking.Validator
+	Delegator     staking.DelegatorResponse
+	StakingParams staking.Params
+
+	FakeEvm
+}
+
+// NewFakeChain creates a new instance of FakeChain at the height with the sequence of the sender
+func NewFakeChain(height int64, seq uint64) *FakeChain {
+	return &FakeChain{
+		AccountNumber: 1,
+		Sequence:      seq,
+		Height:        height,
+		BlockTime:     time.Now(),
+	}
+}
+
+// Auth returns the fake auth client of the chain
+func (fc *FakeChain) Auth() exposed.Auth { return fakeAuth{chain: fc} }
+
+// Tendermint returns the fake tendermint client of the chain
+func (fc *FakeChain) Tendermint() exposed.Tendermint { return fakeTendermint{chain: fc} }
+
+// Staking returns the fake staking client of the chain
+func (fc *FakeChain) Staking() exposed.Staking { return fakeStaking{chain: fc} }
+
+// Commit commits the tx with the sequence and the events in a new block, and returns its response
+// Note: the tx hash is bound to the sequence, so the same tx is sent again with the same sequence
+func (fc *FakeChain) Commit(seqNum uint64, events ...abci.Event) (resp sdk.TxResponse, err error) {
+	if fc.Reject {
+		fc.Reject = false
+		return resp, errors.New("mempool is full")
+	}
+
+	if seqNum != fc.Sequence {
+		return resp, fmt.Errorf("invalid sequence %d, expected %d", seqNum, fc.Sequence)
+	}
+
+	tx := &ctypes.ResultTx{
+		Hash:     tmbytes.HexBytes(fmt.Sprintf("tx-%d", seqNum)),
+		TxResult: abci.ResponseDeliverTx{Events: events},
+	}
+	resp.TxHash = tx.Hash.String()
+	if fc.Pend {
+		fc.Pend = false
+		return
+	}
+
+	fc.Sequence++
+	fc.Height++
+	tx.Height = fc.Height
+	if fc.Fail {
+		fc.Fail = false
+		tx.TxResult = abci.ResponseDeliverTx{Code: 1, Log: "out of gas"}
+	}
+	fc.Txs = append(fc.Txs, tx)
+
+	if fc.Crash {
+		fc.Crash = false
+		return sdk.TxResponse{}, errors.New("connection reset")
+	}
+
+	if fc.BroadcastBlock {
+		resp.Height, resp.Code, resp.RawLog = tx.Height, tx.TxResult.Code, tx.TxResult.Log
+	}
+
+	return
+}
+
+// LastTx returns the tx committed last, or nil if there's none
+func (fc *FakeChain) LastTx() *ctypes.ResultTx {
+	if len(fc.Txs) == 0 {
+		return nil
+	}
+
+	return fc.Txs[len(fc.Txs)-1]
+}
+
+type fakeAuth struct {
+	exposed.Auth
+	chain *FakeChain
+}
+
+func (fa fakeAuth) QueryAccount(accAddrStr string) (authtypes.Account, error) {
+	accAddr, err := sdk.AccAddressFromBech32(accAddrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.BaseAccount{Address: accAddr, AccountNumber: fa.chain.AccountNumber, Sequence: fa.chain.Sequence},
+		nil
+}
+
+type fakeTendermint struct {
+	exposed.Tendermint
+	chain *FakeChain
+}
+
+func (ft fakeTendermint) QueryStatus() (*ctypes.ResultStatus, error) {
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{
+		LatestBlockHeight: ft.chain.Height,
+		LatestBlockTime:   ft.chain.BlockTime,
+		CatchingUp:        ft.chain.CatchingUp,
+	}}, nil
+}
+
+func (ft fakeTendermint) QueryTxResult(hashHexStr string, _ bool) (*tendermint.ResultTx, error) {
+	for _, tx := range ft.chain.Txs {
+		if tx.Hash.String() == hashHexStr {
+			return tx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("tx (%s) not found", hashHexStr)
+}
+
+// QueryHistory returns the txs sent by the sender, newest first
+func (ft fakeTendermint) QueryHistory(addrStr string, fromHeight, toHeight int64, page, limit int) (
+	history tendermint.TxHistory, err error) {
+	if page <= 0 || limit <= 0 {
+		return history, errors.New("failed. page and limit must be positive")
+	}
+
+	var entries []tendermint.HistoryEntry
+	for i := len(ft.chain.Txs) - 1; i >= 0; i-- {
+		tx := ft.chain.Txs[i]
+		if tx.Height < fromHeight || (toHeight > 0 && tx.Height > toHeight) {
+			continue
+		}
+
+		entries = append(entries, tendermint.HistoryEntry{
+			TxHash:    tx.Hash.String(),
+			Height:    tx.Height,
+			Code:      tx.TxResult.Code,
+			Kind:      tendermint.TxKindCosmos,
+			Direction: tendermint.DirectionSend,
+		})
+	}
+
+	history = tendermint.TxHistory{
+		Address:    addrStr,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		HasMore:    page*limit < len(entries),
+		Page:       page,
+		Limit:      limit,
+	}
+	if start := (page - 1) * limit; start < len(entries) {
+		end := start + limit
+		if end > len(entries) {
+			end = len(entries)
+		}
+		history.Entries = entries[start:end]
+	}
+
+	return
+}
+
+type fakeStaking struct {
+	exposed.Staking
+	chain *FakeChain
+}
+
+func (fs fakeStaking) QueryValidators() ([]staking.Validator, error) {
+	return fs.chain.Validators, nil
+}
+
+func (fs fakeStaking) QueryValidator(valAddrStr string) (staking.Validator, error) {
+	for _, val := range fs.chain.Validators {
+		if val.OperatorAddress.String() == valAddrStr {
+			return val, nil
+		}
+	}
+
+	return staking.Validator{}, fmt.Errorf("validator %s not found", valAddrStr)
+}
+
+func (fs fakeStaking) QueryValidatorsByStatus(status string, height int64, page, limit int) (
+	result staking.ValidatorsResult, err error) {
+	var vals []staking.Validator
+	for _, val := range fs.chain.Validators {
+		if status == staking.ValidatorStatusAll || strings.EqualFold(val.Status.String(), status) {
+			vals = append(vals, val)
+		}
+	}
+
+	result.PageInfo = gosdktypes.NewPageInfo(height, len(vals), page, limit)
+	result.Status = status
+	start, end := result.Bounds()
+	result.Validators = vals[start:end]
+	return
+}
+
+func (fs fakeStaking) QueryDelegator(delAddrStr string) (staking.DelegatorResponse, error) {
+	if delAddrStr != fs.chain.Delegator.DelegatorAddress.String() {
+		return staking.DelegatorResponse{}, fmt.Errorf("delegator %s not found", delAddrStr)
+	}
+
+	return fs.chain.Delegator, nil
+}
+
+func (fs fakeStaking) QueryParams(int64) (staking.Params, error) {
+	return fs.chain.StakingParams, nil
+}
+
+func (fs fakeStaking) Deposit(_ keys.Info, _, coinsStr, _ string, _, seqNum uint64) (sdk.TxResponse, error) {
+	return fs.chain.Commit(seqNum, abci.Event{Type: stakingtypes.EventTypeDelegate, Attributes: []kv.Pair{
+		{Key: []byte(sdk.AttributeKeyAmount), Value: []byte(coinsStr)},
+	}})
+}
+
+func (fs fakeStaking) Withdraw(_ keys.Info, _, coinsStr, _ string, _, seqNum uint64) (sdk.TxResponse, error) {
+	return fs.chain.Commit(seqNum, abci.Event{Type: stakingtypes.EventTypeUnbond, Attributes: []kv.Pair{
+		{Key: []byte(sdk.AttributeKeyAmount), Value: []byte(coinsStr)},
+	}})
+}
+
+// AddShares replaces the validators of the delegator once the tx is committed successfully
+func (fs fakeStaking) AddShares(_ keys.Info, _ string, valAddrsStr []string, _ string, _, seqNum uint64) (
+	resp sdk.TxResponse, err error) {
+	valAddrs := make([]sdk.ValAddress, len(valAddrsStr))
+	event := abci.Event{Type: stakingtypes.EventTypeAddShares}
+	for i, valAddrStr := range valAddrsStr {
+		if valAddrs[i], err = sdk.ValAddressFromBech32(valAddrStr); err != nil {
+			return
+		}
+		event.Attributes = append(event.Attributes, kv.Pair{
+			Key:   []byte(stakingtypes.AttributeKeyValidator),
+			Value: []byte(valAddrStr),
+		})
+	}
+
+	seq := fs.chain.Sequence
+	resp, err = fs.chain.Commit(seqNum, event)
+	if fs.chain.Sequence != seq && fs.chain.LastTx().TxResult.IsOK() {
+		fs.chain.Delegator.ValidatorAddresses = valAddrs
+	}
+
+	return
+}
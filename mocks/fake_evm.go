@@ -0,0 +1,150 @@
+package mocks
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/okex/exchain-go-sdk/exposed"
+	evmtypes "github.com/okex/exchain-go-sdk/module/evm/types"
+	rpctypes "github.com/okx/okbchain/app/rpc/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	tmbytes "github.com/okx/okbchain/libs/tendermint/libs/bytes"
+)
+
+// FakeEthTx - structure of an ethereum tx sent to the fake evm client, whose To is nil for a contract creation
+type FakeEthTx struct {
+	To       *ethcmn.Address
+	Nonce    uint64
+	Amount   *big.Int
+	GasLimit uint64
+	GasPrice *big.Int
+	Data     []byte
+}
+
+// FakeEvm - structure of the evm state of the fake chain, which records the ethereum txs sent and the arguments of
+// the last web3 proxy queries
+// Note: the nonce of any address is the sequence of the fake chain, and the height is its block number
+type FakeEvm struct {
+	Balances     map[ethcmn.Address]*big.Int
+	CallReturn   hexutil.Bytes
+	EstimatedGas uint64
+	Logs         []*ethcore.Log
+	EthTxs       []FakeEthTx
+	RawTxs       []hexutil.Bytes
+
+	LastCallArgs rpctypes.CallArgs
+	LastBlockNum rpctypes.BlockNumber
+	LastFilter   ethereum.FilterQuery
+}
+
+// Evm returns the fake evm client of the chain
+func (fc *FakeChain) Evm() exposed.Evm { return fakeEvm{chain: fc} }
+
+type fakeEvm struct {
+	exposed.Evm
+	chain *FakeChain
+}
+
+func (fe fakeEvm) Web3Proxy() exposed.Web3Proxy { return fakeWeb3Proxy{chain: fe.chain} }
+
+// SendTxEthereum records the tx, whose hash is bound to the nonce as the one of Commit to the sequence
+func (fe fakeEvm) SendTxEthereum(_ *ecdsa.PrivateKey, nonce uint64, to ethcmn.Address, amount *big.Int,
+	gasLimit uint64, gasPrice *big.Int, data []byte) (sdk.TxResponse, error) {
+	return fe.send(FakeEthTx{&to, nonce, amount, gasLimit, gasPrice, data})
+}
+
+// CreateContractEthereum records the tx, whose hash is bound to the nonce as the one of Commit to the sequence
+func (fe fakeEvm) CreateContractEthereum(_ *ecdsa.PrivateKey, nonce uint64, amount *big.Int, gasLimit uint64,
+	gasPrice *big.Int, data []byte) (sdk.TxResponse, error) {
+	return fe.send(FakeEthTx{nil, nonce, amount, gasLimit, gasPrice, data})
+}
+
+func (fe fakeEvm) send(tx FakeEthTx) (sdk.TxResponse, error) {
+	if fe.chain.Reject {
+		fe.chain.Reject = false
+		return sdk.TxResponse{}, errors.New("mempool is full")
+	}
+
+	fe.chain.EthTxs = append(fe.chain.EthTxs, tx)
+	return sdk.TxResponse{TxHash: tmbytes.HexBytes(fmt.Sprintf("tx-%d", tx.Nonce)).String()}, nil
+}
+
+type fakeWeb3Proxy struct {
+	exposed.Web3Proxy
+	chain *FakeChain
+}
+
+func (fp fakeWeb3Proxy) BlockNumberProxy() (hexutil.Uint64, error) {
+	return hexutil.Uint64(fp.chain.Height), nil
+}
+
+func (fp fakeWeb3Proxy) GetBalanceProxy(addr ethcmn.Address, blockNum rpctypes.BlockNumber) (*hexutil.Big, error) {
+	fp.chain.LastBlockNum = blockNum
+	balance, ok := fp.chain.Balances[addr]
+	if !ok {
+		balance = new(big.Int)
+	}
+
+	return (*hexutil.Big)(balance), nil
+}
+
+func (fp fakeWeb3Proxy) GetTransactionCountProxy(_ ethcmn.Address, blockNum rpctypes.BlockNumber) (*hexutil.Uint64,
+	error) {
+	fp.chain.LastBlockNum = blockNum
+	nonce := hexutil.Uint64(fp.chain.Sequence)
+	return &nonce, nil
+}
+
+// CallProxy returns CallReturn, and reverts the call without any contract address
+func (fp fakeWeb3Proxy) CallProxy(args rpctypes.CallArgs, blockNum rpctypes.BlockNumber) (hexutil.Bytes, error) {
+	fp.chain.LastCallArgs, fp.chain.LastBlockNum = args, blockNum
+	if args.To == nil {
+		return nil, errors.New("execution reverted")
+	}
+
+	return fp.chain.CallReturn, nil
+}
+
+func (fp fakeWeb3Proxy) EstimateGasProxy(args rpctypes.CallArgs) (hexutil.Uint64, error) {
+	fp.chain.LastCallArgs = args
+	return hexutil.Uint64(fp.chain.EstimatedGas), nil
+}
+
+func (fp fakeWeb3Proxy) GetLogsProxy(query ethereum.FilterQuery) ([]*ethcore.Log, error) {
+	fp.chain.LastFilter = query
+	return fp.chain.Logs, nil
+}
+
+func (fp fakeWeb3Proxy) SendRawTransactionProxy(data hexutil.Bytes) (ethcmn.Hash, error) {
+	fp.chain.RawTxs = append(fp.chain.RawTxs, data)
+	return ethcrypto.Keccak256Hash(data), nil
+}
+
+// GetTransactionReceiptProxy returns the successful receipt of a raw tx sent in the latest block, or nil if it's
+// never sent
+func (fp fakeWeb3Proxy) GetTransactionReceiptProxy(hash ethcmn.Hash) (*evmtypes.TransactionReceipt, error) {
+	for _, rawTx := range fp.chain.RawTxs {
+		if ethcrypto.Keccak256Hash(rawTx) != hash {
+			continue
+		}
+
+		return &evmtypes.TransactionReceipt{
+			Status:            hexutil.Uint64(ethcore.ReceiptStatusSuccessful),
+			CumulativeGasUsed: 21000,
+			Logs:              []*ethcore.Log{},
+			TransactionHash:   hash.String(),
+			GasUsed:           21000,
+			BlockHash:         ethcmn.BytesToHash([]byte(fmt.Sprintf("block-%d", fp.chain.Height))).String(),
+			BlockNumber:       hexutil.Uint64(fp.chain.Height),
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -1,21 +1,23 @@
 package auth
 
 import (
-	"errors"
 	"fmt"
+	"sync"
 
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/okex/exchain-go-sdk/module/auth/types"
 	"github.com/okex/exchain-go-sdk/utils"
-	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	apptypes "github.com/okx/okbchain/app/types"
 	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth"
 	authtypes "github.com/okx/okbchain/libs/cosmos-sdk/x/auth/types"
 )
 
 // QueryAccount gets the account info
+// Note: the address is accepted in both bech32 and hex format
 func (ac authClient) QueryAccount(accAddrStr string) (account types.Account, err error) {
-	accAddr, err := sdk.AccAddressFromBech32(accAddrStr)
+	accAddr, err := utils.ToCosmosAddress(accAddrStr)
 	if err != nil {
-		return account, errors.New("failed. accAddress converted from Bech32 error")
+		return account, fmt.Errorf("failed. parse Address [%s] error: %s", accAddrStr, err)
 	}
 
 	path := fmt.Sprintf("custom/%s/%s", auth.QuerierRoute, auth.QueryAccount)
@@ -35,3 +37,71 @@ func (ac authClient) QueryAccount(accAddrStr string) (account types.Account, err
 
 	return
 }
+
+// QueryAccountView gets the normalized account info with both the bech32 and hex address
+func (ac authClient) QueryAccountView(accAddrStr string) (accView types.AccountView, err error) {
+	account, err := ac.QueryAccount(accAddrStr)
+	if err != nil {
+		return
+	}
+
+	return newAccountView(account), nil
+}
+
+// QueryAccounts gets the normalized account info of a batch of addresses in parallel
+// Note: the results keep the order of the input addresses and each of them carries its own error. The concurrency
+// limits the number of the parallel queries, which defaults to types.DefaultQueryConcurrency by 0
+func (ac authClient) QueryAccounts(accAddrsStr []string, concurrency int) []types.AccountResult {
+	if concurrency <= 0 {
+		concurrency = types.DefaultQueryConcurrency
+	}
+
+	results := make([]types.AccountResult, len(accAddrsStr))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, accAddrStr := range accAddrsStr {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, accAddrStr string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			accView, err := ac.QueryAccountView(accAddrStr)
+			results[i] = types.AccountResult{
+				AddrStr: accAddrStr,
+				Account: accView,
+				Err:     err,
+			}
+		}(i, accAddrStr)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func newAccountView(account types.Account) types.AccountView {
+	accView := types.AccountView{
+		Address:       account.GetAddress(),
+		EthAddress:    ethcmn.BytesToAddress(account.GetAddress()),
+		AccountNumber: account.GetAccountNumber(),
+		Sequence:      account.GetSequence(),
+		Coins:         account.GetCoins(),
+	}
+
+	var ethAccount *apptypes.EthAccount
+	switch acc := account.(type) {
+	case apptypes.EthAccount:
+		ethAccount = &acc
+	case *apptypes.EthAccount:
+		ethAccount = acc
+	}
+
+	if ethAccount != nil && ethAccount.IsContract() {
+		accView.IsContract = true
+		accView.CodeHash = ethcmn.BytesToHash(ethAccount.CodeHash)
+	}
+
+	return accView
+}
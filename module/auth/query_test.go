@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/golang/mock/gomock"
 	"github.com/okex/exchain-go-sdk/mocks"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
@@ -72,3 +73,46 @@ func TestAuthClient_QueryAccount(t *testing.T) {
 	_, err = mockCli.Auth().QueryAccount(addr)
 	require.Error(t, err)
 }
+
+func TestAuthClient_QueryAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewAuthClient(mockCli.MockBaseClient))
+
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	ethAddr := ethcmn.BytesToAddress(accAddr)
+
+	expectedCdc := mockCli.GetCodec()
+	expectedRet := mockCli.BuildAccountBytes(addr, accPubkey, "default code hash", "1024okt", 1, 2)
+	expectedPath := fmt.Sprintf("custom/%s/%s", auth.QuerierRoute, auth.QueryAccount)
+	expectedParams, err := expectedCdc.MarshalJSON(auth.NewQueryAccountParams(accAddr))
+	require.NoError(t, err)
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil).Times(3)
+
+	// query by the hex address
+	accView, err := mockCli.Auth().QueryAccountView(ethAddr.Hex())
+	require.NoError(t, err)
+	require.Equal(t, addr, accView.Address.String())
+	require.Equal(t, ethAddr, accView.EthAddress)
+	require.Equal(t, uint64(1), accView.AccountNumber)
+	require.Equal(t, uint64(2), accView.Sequence)
+	require.Equal(t, "1024.000000000000000000okt", accView.Coins.String())
+	require.True(t, accView.IsContract)
+	require.Equal(t, ethcmn.BytesToHash([]byte("default code hash")), accView.CodeHash)
+
+	results := mockCli.Auth().QueryAccounts([]string{addr, addr[1:], ethAddr.Hex()}, 2)
+	require.Equal(t, 3, len(results))
+	require.NoError(t, results[0].Err)
+	require.Equal(t, addr, results[0].AddrStr)
+	require.Equal(t, addr, results[0].Account.Address.String())
+	require.Error(t, results[1].Err)
+	require.Equal(t, addr[1:], results[1].AddrStr)
+	require.NoError(t, results[2].Err)
+	require.Equal(t, ethAddr, results[2].Account.EthAddress)
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth"
 	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth/exported"
 )
@@ -8,8 +10,29 @@ import (
 // const
 const (
 	ModuleName = auth.ModuleName
+	// DefaultQueryConcurrency is the number of the parallel queries in a batch query by default
+	DefaultQueryConcurrency = 8
 )
 
 type (
 	Account = exported.Account
 )
+
+// AccountView - structure of the normalized account info with both the bech32 and hex address
+type AccountView struct {
+	Address       sdk.AccAddress `json:"address"`
+	EthAddress    ethcmn.Address `json:"eth_address"`
+	AccountNumber uint64         `json:"account_number"`
+	Sequence      uint64         `json:"sequence"`
+	Coins         sdk.SysCoins   `json:"coins"`
+	// CodeHash is set for the contract account only
+	CodeHash   ethcmn.Hash `json:"code_hash,omitempty"`
+	IsContract bool        `json:"is_contract"`
+}
+
+// AccountResult - structure of the result of one address in the batch account query
+type AccountResult struct {
+	AddrStr string
+	Account AccountView
+	Err     error
+}
@@ -40,8 +40,18 @@ func NewBaseClient(cdc *codec.Codec, pConfig *types.ClientConfig) *baseClient {
 
 // Query executes the basic query
 func (bc *baseClient) Query(path string, key tmbytes.HexBytes) (res []byte, height int64, err error) {
+	return bc.QueryWithHeight(path, key, 0)
+}
+
+// QueryWithHeight executes the basic query at a specific height. The latest height is used by 0
+func (bc *baseClient) QueryWithHeight(path string, key tmbytes.HexBytes, queryHeight int64) (res []byte, height int64,
+	err error) {
+	if queryHeight < 0 {
+		return res, height, errors.New("failed. negative height is not available")
+	}
+
 	opts := rpcclient.ABCIQueryOptions{
-		Height: 0,
+		Height: queryHeight,
 		Prove:  false,
 	}
 
@@ -0,0 +1,85 @@
+package distribution
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain-go-sdk/module/distribution/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+)
+
+// ParseWithdrawRewardsEvents decodes all the withdraw_rewards events of the delegator rewards
+func (distrClient) ParseWithdrawRewardsEvents(events sdk.StringEvents) ([]types.WithdrawRewardsEvent, error) {
+	return parseWithdrawEvents(events, distrtypes.EventTypeWithdrawRewards)
+}
+
+// ParseWithdrawCommissionEvents decodes all the withdraw_commission events of the validator commission
+func (distrClient) ParseWithdrawCommissionEvents(events sdk.StringEvents) ([]types.WithdrawRewardsEvent, error) {
+	return parseWithdrawEvents(events, distrtypes.EventTypeWithdrawCommission)
+}
+
+// ParseSetWithdrawAddressEvents decodes all the set_withdraw_address events
+func (distrClient) ParseSetWithdrawAddressEvents(events sdk.StringEvents) (sets []types.SetWithdrawAddressEvent,
+	err error) {
+	for _, attrs := range utils.ParseEventAttributes(events, distrtypes.EventTypeSetWithdrawAddress) {
+		var set types.SetWithdrawAddressEvent
+		withdrawAddrStr := attrs[distrtypes.AttributeKeyWithdrawAddress]
+		set.WithdrawAddress, err = sdk.AccAddressFromBech32(withdrawAddrStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed. parse withdraw address [%s] error: %s", withdrawAddrStr, err)
+		}
+
+		sets = append(sets, set)
+	}
+
+	return
+}
+
+// GetRewards gets the total rewards and commission withdrawn in the tx
+func (dc distrClient) GetRewards(resp sdk.TxResponse) (rewards sdk.SysCoins, err error) {
+	events := utils.GetEventsFromTxResponse(resp)
+	withdrawals, err := dc.ParseWithdrawRewardsEvents(events)
+	if err != nil {
+		return
+	}
+
+	commissions, err := dc.ParseWithdrawCommissionEvents(events)
+	if err != nil {
+		return
+	}
+
+	withdrawals = append(withdrawals, commissions...)
+	if len(withdrawals) == 0 {
+		return nil, utils.ErrEventAttributeNotFound(distrtypes.EventTypeWithdrawRewards, sdk.AttributeKeyAmount)
+	}
+
+	rewards = sdk.SysCoins{}
+	for _, withdrawal := range withdrawals {
+		rewards = rewards.Add(withdrawal.Amount...)
+	}
+
+	return
+}
+
+func parseWithdrawEvents(events sdk.StringEvents, eventType string) (withdrawals []types.WithdrawRewardsEvent, err error) {
+	for _, attrs := range utils.ParseEventAttributes(events, eventType) {
+		var withdrawal types.WithdrawRewardsEvent
+		// the validator is absent in the withdraw_commission event
+		if valAddrStr, ok := attrs[distrtypes.AttributeKeyValidator]; ok {
+			withdrawal.Validator, err = sdk.ValAddressFromBech32(valAddrStr)
+			if err != nil {
+				return nil, fmt.Errorf("failed. parse validator address [%s] error: %s", valAddrStr, err)
+			}
+		}
+
+		withdrawal.Amount, err = utils.ParseEventCoins(attrs[sdk.AttributeKeyAmount])
+		if err != nil {
+			return nil, err
+		}
+
+		withdrawals = append(withdrawals, withdrawal)
+	}
+
+	return
+}
@@ -0,0 +1,88 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistrClient_GetRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+
+	resp := mocks.DefaultMockSuccessTxResponse()
+	resp.Logs = sdk.ABCIMessageLogs{{
+		Events: sdk.StringEvents{
+			{
+				Type: "withdraw_commission",
+				Attributes: []sdk.Attribute{
+					{Key: "amount", Value: "1.024000000000000000okt"},
+				},
+			},
+			{
+				Type: "withdraw_rewards",
+				Attributes: []sdk.Attribute{
+					{Key: "amount", Value: "2.048000000000000000okt"},
+					{Key: "validator", Value: valAddr},
+				},
+			},
+		},
+	}}
+
+	events := resp.Logs[0].Events
+	withdrawals, err := mockCli.Distribution().ParseWithdrawRewardsEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(withdrawals))
+	require.Equal(t, valAddr, withdrawals[0].Validator.String())
+
+	commissions, err := mockCli.Distribution().ParseWithdrawCommissionEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(commissions))
+	require.True(t, commissions[0].Validator.Empty())
+
+	rewards, err := mockCli.Distribution().GetRewards(resp)
+	require.NoError(t, err)
+	require.Equal(t, "3.072000000000000000okt", rewards.String())
+
+	// bad validator address
+	resp.Logs[0].Events[1].Attributes[1].Value = valAddr[1:]
+	_, err = mockCli.Distribution().GetRewards(resp)
+	require.Error(t, err)
+
+	// no withdrawal event
+	_, err = mockCli.Distribution().GetRewards(mocks.DefaultMockSuccessTxResponse())
+	require.Error(t, err)
+}
+
+func TestDistrClient_ParseSetWithdrawAddressEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+
+	events := sdk.StringEvents{{
+		Type:       "set_withdraw_address",
+		Attributes: []sdk.Attribute{{Key: "withdraw_address", Value: recAddr}},
+	}}
+
+	sets, err := mockCli.Distribution().ParseSetWithdrawAddressEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(sets))
+	require.Equal(t, recAddr, sets[0].WithdrawAddress.String())
+
+	events[0].Attributes[0].Value = recAddr[1:]
+	_, err = mockCli.Distribution().ParseSetWithdrawAddressEvents(events)
+	require.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package distribution
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain-go-sdk/module/distribution/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+)
+
+// QueryValidatorOutstandingRewards gets the rewards allocated to a validator but not withdrawn yet, including the
+// commission and the rewards of all its delegators
+func (dc distrClient) QueryValidatorOutstandingRewards(valAddrStr string) (rewards sdk.SysCoins, err error) {
+	valAddr, err := sdk.ValAddressFromBech32(valAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := dc.GetCodec().MarshalJSON(distrtypes.NewQueryValidatorOutstandingRewardsParams(valAddr))
+	if err != nil {
+		return rewards, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = dc.query(distrtypes.QueryValidatorOutstandingRewards, jsonBytes, &rewards)
+	return
+}
+
+// QueryValidatorCommission gets the accumulated commission of a validator not withdrawn yet
+func (dc distrClient) QueryValidatorCommission(valAddrStr string) (commission sdk.SysCoins, err error) {
+	valAddr, err := sdk.ValAddressFromBech32(valAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := dc.GetCodec().MarshalJSON(distrtypes.NewQueryValidatorCommissionParams(valAddr))
+	if err != nil {
+		return commission, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = dc.query(distrtypes.QueryValidatorCommission, jsonBytes, &commission)
+	return
+}
+
+// QueryCommunityPool gets the coins in the community pool
+func (dc distrClient) QueryCommunityPool() (pool sdk.SysCoins, err error) {
+	err = dc.query(distrtypes.QueryCommunityPool, nil, &pool)
+	return
+}
+
+// QueryWithdrawAddress gets the address that the rewards of a delegator are withdrawn to
+// Note: the delegator address is accepted in both bech32 and hex format
+func (dc distrClient) QueryWithdrawAddress(delAddrStr string) (withdrawAddr sdk.AccAddress, err error) {
+	delAddr, err := utils.ToCosmosAddress(delAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := dc.GetCodec().MarshalJSON(distrtypes.NewQueryDelegatorWithdrawAddrParams(delAddr))
+	if err != nil {
+		return withdrawAddr, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = dc.query(distrtypes.QueryWithdrawAddr, jsonBytes, &withdrawAddr)
+	return
+}
+
+// QueryParams gets the current params of distribution module
+func (dc distrClient) QueryParams() (distrParams types.Params, err error) {
+	// the params are queried one by one from the sub-paths
+	paramPtrs := []struct {
+		key string
+		ptr interface{}
+	}{
+		{distrtypes.ParamCommunityTax, &distrParams.CommunityTax},
+		{distrtypes.ParamWithdrawAddrEnabled, &distrParams.WithdrawAddrEnabled},
+		{distrtypes.ParamDistributionType, &distrParams.DistributionType},
+		{distrtypes.ParamWithdrawRewardEnabled, &distrParams.WithdrawRewardEnabled},
+		{distrtypes.ParamRewardTruncatePrecision, &distrParams.RewardTruncatePrecision},
+	}
+
+	for _, param := range paramPtrs {
+		if err = dc.query(fmt.Sprintf("%s/%s", distrtypes.QueryParams, param.key), nil, param.ptr); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// QueryDelegationRewards gets the pending rewards of a delegator from a validator that it has added shares to
+// Note: the delegator address is accepted in both bech32 and hex format
+func (dc distrClient) QueryDelegationRewards(delAddrStr, valAddrStr string) (rewards sdk.SysCoins, err error) {
+	delAddr, err := utils.ToCosmosAddress(delAddrStr)
+	if err != nil {
+		return
+	}
+
+	valAddr, err := sdk.ValAddressFromBech32(valAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := dc.GetCodec().MarshalJSON(distrtypes.NewQueryDelegationRewardsParams(delAddr, valAddr))
+	if err != nil {
+		return rewards, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = dc.query(distrtypes.QueryDelegationRewards, jsonBytes, &rewards)
+	return
+}
+
+// QueryDelegatorTotalRewards gets the pending rewards of a delegator from each validator that it has added shares to
+// and the total
+// Note: the delegator address is accepted in both bech32 and hex format
+func (dc distrClient) QueryDelegatorTotalRewards(delAddrStr string) (totalRewards types.DelegatorTotalRewards,
+	err error) {
+	delAddr, err := utils.ToCosmosAddress(delAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := dc.GetCodec().MarshalJSON(distrtypes.NewQueryDelegatorParams(delAddr))
+	if err != nil {
+		return totalRewards, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = dc.query(distrtypes.QueryDelegatorTotalRewards, jsonBytes, &totalRewards)
+	return
+}
+
+func (dc distrClient) query(route string, jsonBytes []byte, ptr interface{}) error {
+	path := fmt.Sprintf("custom/%s/%s", distrtypes.QuerierRoute, route)
+	res, _, err := dc.Query(path, jsonBytes)
+	if err != nil {
+		return utils.ErrClientQuery(err.Error())
+	}
+
+	if err = dc.GetCodec().UnmarshalJSON(res, ptr); err != nil {
+		return utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return nil
+}
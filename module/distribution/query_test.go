@@ -0,0 +1,227 @@
+package distribution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	tmbytes "github.com/okx/okbchain/libs/tendermint/libs/bytes"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistrClient_QueryValidatorRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	valAddress, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+	rewards, err := sdk.ParseDecCoins("10.24okt,1btc-000")
+	require.NoError(t, err)
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(rewards)
+
+	// outstanding rewards
+	expectedPath := fmt.Sprintf("custom/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryValidatorOutstandingRewards)
+	expectedParams := mockCli.GetCodec().MustMarshalJSON(
+		distrtypes.NewQueryValidatorOutstandingRewardsParams(valAddress))
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+	outstanding, err := mockCli.Distribution().QueryValidatorOutstandingRewards(valAddr)
+	require.NoError(t, err)
+	require.Equal(t, rewards, outstanding)
+
+	_, err = mockCli.Distribution().QueryValidatorOutstandingRewards(valAddr[1:])
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(nil, int64(0),
+		errors.New("default error"))
+	_, err = mockCli.Distribution().QueryValidatorOutstandingRewards(valAddr)
+	require.Error(t, err)
+
+	// commission
+	expectedPath = fmt.Sprintf("custom/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryValidatorCommission)
+	expectedParams = mockCli.GetCodec().MustMarshalJSON(distrtypes.NewQueryValidatorCommissionParams(valAddress))
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+	commission, err := mockCli.Distribution().QueryValidatorCommission(valAddr)
+	require.NoError(t, err)
+	require.Equal(t, rewards, commission)
+
+	_, err = mockCli.Distribution().QueryValidatorCommission(valAddr[1:])
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Distribution().QueryValidatorCommission(valAddr)
+	require.Error(t, err)
+
+	// community pool
+	expectedPath = fmt.Sprintf("custom/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryCommunityPool)
+	mockCli.EXPECT().Query(expectedPath, nil).Return(expectedRet, int64(1024), nil)
+	pool, err := mockCli.Distribution().QueryCommunityPool()
+	require.NoError(t, err)
+	require.Equal(t, rewards, pool)
+
+	mockCli.EXPECT().Query(expectedPath, nil).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Distribution().QueryCommunityPool()
+	require.Error(t, err)
+}
+
+func TestDistrClient_QueryWithdrawAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	withdrawAddr, err := sdk.AccAddressFromBech32(recAddr)
+	require.NoError(t, err)
+
+	expectedPath := fmt.Sprintf("custom/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryWithdrawAddr)
+	expectedParams := mockCli.GetCodec().MustMarshalJSON(distrtypes.NewQueryDelegatorWithdrawAddrParams(delAddr))
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(withdrawAddr)
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+
+	// hex address is accepted
+	retAddr, err := mockCli.Distribution().QueryWithdrawAddress("0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991")
+	require.NoError(t, err)
+	require.Equal(t, withdrawAddr, retAddr)
+
+	_, err = mockCli.Distribution().QueryWithdrawAddress(addr[1:])
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Distribution().QueryWithdrawAddress(addr)
+	require.Error(t, err)
+}
+
+func TestDistrClient_QueryParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	expectedParams := distrtypes.Params{
+		CommunityTax:            sdk.NewDecWithPrec(2, 2),
+		WithdrawAddrEnabled:     true,
+		DistributionType:        distrtypes.DistributionTypeOnChain,
+		WithdrawRewardEnabled:   true,
+		RewardTruncatePrecision: 4,
+	}
+	paramsRet := map[string]interface{}{
+		distrtypes.ParamCommunityTax:            expectedParams.CommunityTax,
+		distrtypes.ParamWithdrawAddrEnabled:     expectedParams.WithdrawAddrEnabled,
+		distrtypes.ParamDistributionType:        expectedParams.DistributionType,
+		distrtypes.ParamWithdrawRewardEnabled:   expectedParams.WithdrawRewardEnabled,
+		distrtypes.ParamRewardTruncatePrecision: expectedParams.RewardTruncatePrecision,
+	}
+	for key, value := range paramsRet {
+		expectedPath := fmt.Sprintf("custom/%s/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryParams, key)
+		mockCli.EXPECT().Query(expectedPath, nil).Return(mockCli.GetCodec().MustMarshalJSON(value), int64(1024), nil)
+	}
+
+	distrParams, err := mockCli.Distribution().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, expectedParams, distrParams)
+
+	expectedPath := fmt.Sprintf("custom/%s/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryParams,
+		distrtypes.ParamCommunityTax)
+	mockCli.EXPECT().Query(expectedPath, nil).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Distribution().QueryParams()
+	require.Error(t, err)
+}
+
+func TestDistrClient_QueryDelegationRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	valAddress, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+	rewards, err := sdk.ParseDecCoins("10.24okt")
+	require.NoError(t, err)
+
+	expectedPath := fmt.Sprintf("custom/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryDelegationRewards)
+	expectedParams := mockCli.GetCodec().MustMarshalJSON(distrtypes.NewQueryDelegationRewardsParams(delAddr,
+		valAddress))
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(rewards)
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+	retRewards, err := mockCli.Distribution().QueryDelegationRewards(addr, valAddr)
+	require.NoError(t, err)
+	require.Equal(t, rewards, retRewards)
+
+	_, err = mockCli.Distribution().QueryDelegationRewards(addr[1:], valAddr)
+	require.Error(t, err)
+
+	_, err = mockCli.Distribution().QueryDelegationRewards(addr, valAddr[1:])
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(nil, int64(0),
+		errors.New("default error"))
+	_, err = mockCli.Distribution().QueryDelegationRewards(addr, valAddr)
+	require.Error(t, err)
+}
+
+func TestDistrClient_QueryDelegatorTotalRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	valAddress, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+	rewards, err := sdk.ParseDecCoins("10.24okt")
+	require.NoError(t, err)
+
+	// the response is marshaled by encoding/json on chain
+	expectedRet, err := json.Marshal(distrtypes.NewQueryDelegatorTotalRewardsResponse(
+		[]distrtypes.DelegationDelegatorReward{distrtypes.NewDelegationDelegatorReward(valAddress, rewards)}, rewards))
+	require.NoError(t, err)
+	expectedPath := fmt.Sprintf("custom/%s/%s", distrtypes.QuerierRoute, distrtypes.QueryDelegatorTotalRewards)
+	expectedParams := mockCli.GetCodec().MustMarshalJSON(distrtypes.NewQueryDelegatorParams(delAddr))
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+
+	totalRewards, err := mockCli.Distribution().QueryDelegatorTotalRewards(addr)
+	require.NoError(t, err)
+	require.Equal(t, rewards, totalRewards.Total)
+	require.Equal(t, 1, len(totalRewards.Rewards))
+	require.Equal(t, valAddress, totalRewards.Rewards[0].ValidatorAddress)
+	require.Equal(t, rewards, totalRewards.Rewards[0].Reward)
+
+	_, err = mockCli.Distribution().QueryDelegatorTotalRewards(addr[1:])
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Distribution().QueryDelegatorTotalRewards(addr)
+	require.Error(t, err)
+}
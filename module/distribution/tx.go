@@ -3,7 +3,9 @@ package distribution
 import (
 	"fmt"
 
+	"github.com/okex/exchain-go-sdk/module/staking"
 	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
 	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	distrtypes "github.com/okx/okbchain/x/distribution/types"
@@ -40,3 +42,79 @@ func (dc distrClient) WithdrawRewards(fromInfo keys.Info, passWd, valAddrStr, me
 	msg := distrtypes.NewMsgWithdrawValidatorCommission(valAddr)
 	return dc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
 }
+
+// WithdrawDelegatorReward withdraws the rewards of a delegator from a validator that it has added shares to
+func (dc distrClient) WithdrawDelegatorReward(fromInfo keys.Info, passWd, valAddrStr, memo string, accNum,
+	seqNum uint64) (resp sdk.TxResponse, err error) {
+	if err = params.CheckKeyParams(fromInfo, passWd); err != nil {
+		return
+	}
+
+	msgs, err := dc.BuildWithdrawDelegatorRewardMsgs(fromInfo.GetAddress().String(), []string{valAddrStr})
+	if err != nil {
+		return
+	}
+
+	return dc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, msgs, accNum, seqNum)
+}
+
+// WithdrawDelegatorAllRewards withdraws the rewards of a delegator from all the validators that it has added shares to
+func (dc distrClient) WithdrawDelegatorAllRewards(fromInfo keys.Info, passWd, memo string, accNum, seqNum uint64) (
+	resp sdk.TxResponse, err error) {
+	if err = params.CheckKeyParams(fromInfo, passWd); err != nil {
+		return
+	}
+
+	msgs, err := dc.BuildWithdrawDelegatorRewardMsgs(fromInfo.GetAddress().String(), nil)
+	if err != nil {
+		return
+	}
+
+	return dc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, msgs, accNum, seqNum)
+}
+
+// BuildWithdrawDelegatorRewardMsgs builds the msgs to withdraw the rewards of a delegator, which are validated against
+// the current state of the delegator and can be packed into a multi-msg tx along with others
+// Note: a MsgWithdrawDelegatorAllRewards is built if no validator address is given
+func (dc distrClient) BuildWithdrawDelegatorRewardMsgs(delAddrStr string, valAddrsStr []string) (msgs []sdk.Msg,
+	err error) {
+	delAddr, err := utils.ToCosmosAddress(delAddrStr)
+	if err != nil {
+		return
+	}
+
+	delegator, err := staking.NewStakingClient(dc.BaseClient).QueryDelegator(delAddr.String())
+	if err != nil {
+		return
+	}
+
+	if len(delegator.ValidatorAddresses) == 0 {
+		return msgs, fmt.Errorf("failed. delegator %s hasn't added shares to any validator", delAddr)
+	}
+
+	if len(valAddrsStr) == 0 {
+		return []sdk.Msg{distrtypes.NewMsgWithdrawDelegatorAllRewards(delAddr)}, nil
+	}
+
+	valAddrs, err := utils.ParseValAddresses(valAddrsStr)
+	if err != nil {
+		return msgs, fmt.Errorf("failed. validator address parsed error: %s", err.Error())
+	}
+
+	added := make(map[string]bool, len(delegator.ValidatorAddresses))
+	for _, valAddr := range delegator.ValidatorAddresses {
+		added[valAddr.String()] = true
+	}
+
+	for _, valAddr := range valAddrs {
+		if !added[valAddr.String()] {
+			return nil, fmt.Errorf("failed. delegator %s hasn't added shares to validator %s", delAddr, valAddr)
+		}
+
+		// mark it to reject the duplicated validator
+		added[valAddr.String()] = false
+		msgs = append(msgs, distrtypes.NewMsgWithdrawDelegatorReward(delAddr, valAddr))
+	}
+
+	return
+}
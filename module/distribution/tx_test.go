@@ -11,6 +11,9 @@ import (
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	"github.com/okex/exchain-go-sdk/utils"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	tmbytes "github.com/okx/okbchain/libs/tendermint/libs/bytes"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+	stakingtypes "github.com/okx/okbchain/x/staking/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,6 +28,8 @@ const (
 	valAddr   = "exvaloper1qwuag8gx408m9ej038vzx50ntt0x4yrq8qwdtq"
 )
 
+var unbondingPath = fmt.Sprintf("custom/%s/%s", stakingtypes.RouterKey, stakingtypes.QueryUnbondingDelegation)
+
 func TestDistrClient_SetWithdrawAddr(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -119,3 +124,186 @@ func TestDistrClient_WithdrawRewards(t *testing.T) {
 		accInfo.GetSequence())
 	require.Error(t, err)
 }
+
+func TestDistrClient_WithdrawDelegatorReward(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	validator, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okt", 1, 2)
+	delBytes := mockCli.BuildDelegatorBytes(delAddr, nil, []sdk.ValAddress{validator}, sdk.OneDec(), sdk.OneDec(),
+		sdk.ZeroDec(), false)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, int64(1024), nil)
+
+	accInfo, err := mockCli.Auth().QueryAccount(addr)
+	require.NoError(t, err)
+
+	// the delegator without undelegation
+	mockCli.EXPECT().Query(unbondingPath, gomock.Any()).Return(nil, int64(0), errors.New("default error")).
+		AnyTimes()
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(stakingtypes.GetDelegatorKey(delAddr)), stakingtypes.StoreKey, "key").
+		Return(delBytes, int64(1024), nil).Times(3)
+	mockCli.EXPECT().BuildAndBroadcast(
+		fromInfo.GetName(), passWd, memo, []sdk.Msg{distrtypes.NewMsgWithdrawDelegatorReward(delAddr, validator)},
+		accInfo.GetAccountNumber(), accInfo.GetSequence()).
+		Return(mocks.DefaultMockSuccessTxResponse(), nil)
+
+	res, err := mockCli.Distribution().WithdrawDelegatorReward(fromInfo, passWd, valAddr, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	// the delegator hasn't added shares to the validator
+	otherValAddr := sdk.ValAddress(delAddr).String()
+	_, err = mockCli.Distribution().WithdrawDelegatorReward(fromInfo, passWd, otherValAddr, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Distribution().WithdrawDelegatorReward(fromInfo, passWd, valAddr[1:], memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Distribution().WithdrawDelegatorReward(fromInfo, "", valAddr, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(stakingtypes.GetDelegatorKey(delAddr)), stakingtypes.StoreKey, "key").
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Distribution().WithdrawDelegatorReward(fromInfo, passWd, valAddr, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+}
+
+func TestDistrClient_WithdrawDelegatorAllRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	validator, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okt", 1, 2)
+	delBytes := mockCli.BuildDelegatorBytes(delAddr, nil, []sdk.ValAddress{validator}, sdk.OneDec(), sdk.OneDec(),
+		sdk.ZeroDec(), false)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, int64(1024), nil)
+
+	accInfo, err := mockCli.Auth().QueryAccount(addr)
+	require.NoError(t, err)
+
+	// the delegator without undelegation
+	mockCli.EXPECT().Query(unbondingPath, gomock.Any()).Return(nil, int64(0), errors.New("default error")).
+		AnyTimes()
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(stakingtypes.GetDelegatorKey(delAddr)), stakingtypes.StoreKey, "key").
+		Return(delBytes, int64(1024), nil).Times(2)
+	mockCli.EXPECT().BuildAndBroadcast(
+		fromInfo.GetName(), passWd, memo, []sdk.Msg{distrtypes.NewMsgWithdrawDelegatorAllRewards(delAddr)},
+		accInfo.GetAccountNumber(), accInfo.GetSequence()).
+		Return(mocks.DefaultMockSuccessTxResponse(), nil)
+
+	res, err := mockCli.Distribution().WithdrawDelegatorAllRewards(fromInfo, passWd, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	mockCli.EXPECT().BuildAndBroadcast(
+		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(),
+		accInfo.GetSequence()).
+		Return(sdk.TxResponse{}, errors.New("default error"))
+	_, err = mockCli.Distribution().WithdrawDelegatorAllRewards(fromInfo, passWd, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Distribution().WithdrawDelegatorAllRewards(fromInfo, "", memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	// the delegator doesn't exist
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(stakingtypes.GetDelegatorKey(delAddr)), stakingtypes.StoreKey, "key").
+		Return(nil, int64(1024), nil)
+	_, err = mockCli.Distribution().WithdrawDelegatorAllRewards(fromInfo, passWd, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+}
+
+func TestDistrClient_BuildWithdrawDelegatorRewardMsgs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDistrClient(mockCli.MockBaseClient))
+
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	validator1, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+	validator2 := sdk.ValAddress(delAddr)
+
+	delBytes := mockCli.BuildDelegatorBytes(delAddr, nil, []sdk.ValAddress{validator1, validator2}, sdk.OneDec(),
+		sdk.OneDec(), sdk.ZeroDec(), false)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	// the delegator without undelegation
+	mockCli.EXPECT().Query(unbondingPath, gomock.Any()).Return(nil, int64(0), errors.New("default error")).
+		AnyTimes()
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(stakingtypes.GetDelegatorKey(delAddr)), stakingtypes.StoreKey, "key").
+		Return(delBytes, int64(1024), nil).Times(4)
+
+	// hex address is accepted
+	msgs, err := mockCli.Distribution().BuildWithdrawDelegatorRewardMsgs("0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991",
+		[]string{validator2.String(), valAddr})
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Msg{
+		distrtypes.NewMsgWithdrawDelegatorReward(delAddr, validator2),
+		distrtypes.NewMsgWithdrawDelegatorReward(delAddr, validator1),
+	}, msgs)
+
+	msgs, err = mockCli.Distribution().BuildWithdrawDelegatorRewardMsgs(addr, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Msg{distrtypes.NewMsgWithdrawDelegatorAllRewards(delAddr)}, msgs)
+
+	_, err = mockCli.Distribution().BuildWithdrawDelegatorRewardMsgs(addr, []string{valAddr, valAddr})
+	require.Error(t, err)
+
+	_, err = mockCli.Distribution().BuildWithdrawDelegatorRewardMsgs(addr, []string{valAddr[1:]})
+	require.Error(t, err)
+
+	_, err = mockCli.Distribution().BuildWithdrawDelegatorRewardMsgs(addr[1:], nil)
+	require.Error(t, err)
+
+	// the delegator has added no shares
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(stakingtypes.GetDelegatorKey(delAddr)), stakingtypes.StoreKey, "key").
+		Return(mockCli.BuildDelegatorBytes(delAddr, nil, nil, sdk.ZeroDec(), sdk.OneDec(), sdk.ZeroDec(), false),
+			int64(1024), nil)
+	_, err = mockCli.Distribution().BuildWithdrawDelegatorRewardMsgs(addr, nil)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(stakingtypes.GetDelegatorKey(delAddr)), stakingtypes.StoreKey, "key").
+		Return(delBytes[1:], int64(1024), nil)
+	_, err = mockCli.Distribution().BuildWithdrawDelegatorRewardMsgs(addr, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,17 @@
+package types
+
+import (
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// WithdrawRewardsEvent - structure of the rewards withdrawn from a validator, decoded from the withdraw_rewards or
+// withdraw_commission event
+type WithdrawRewardsEvent struct {
+	Validator sdk.ValAddress
+	Amount    sdk.SysCoins
+}
+
+// SetWithdrawAddressEvent - structure of a new withdraw address decoded from the set_withdraw_address event
+type SetWithdrawAddressEvent struct {
+	WithdrawAddress sdk.AccAddress
+}
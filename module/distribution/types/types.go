@@ -8,3 +8,9 @@ import (
 const (
 	ModuleName = distrtypes.ModuleName
 )
+
+type (
+	Params                    = distrtypes.Params
+	DelegationDelegatorReward = distrtypes.DelegationDelegatorReward
+	DelegatorTotalRewards     = distrtypes.QueryDelegatorTotalRewardsResponse
+)
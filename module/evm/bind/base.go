@@ -0,0 +1,256 @@
+package bind
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	rpctypes "github.com/okx/okbchain/app/rpc/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// Client shows the expected behavior of the client that the bound contracts work with
+type Client interface {
+	Evm() exposed.Evm
+}
+
+// CallOpts - structure of the options to read a contract
+type CallOpts struct {
+	// From is the address that the call is made from, and the zero address by default
+	From ethcmn.Address
+	// BlockNumber is the block to call on, and the latest by default
+	BlockNumber rpctypes.BlockNumber
+}
+
+// TransactOpts - structure of the options to send a tx to a contract, which is shared with the token helpers of the
+// evm client
+type TransactOpts = types.TransactOpts
+
+// FilterOpts - structure of the options to filter the logs of a contract, where a nil block means the latest one
+type FilterOpts struct {
+	FromBlock *big.Int
+	ToBlock   *big.Int
+}
+
+// BoundContract - structure of a contract deployed on chain, which is the base of the generated bindings
+type BoundContract struct {
+	cli     Client
+	address ethcmn.Address
+	abi     abi.ABI
+}
+
+// NewBoundContract creates a new instance of BoundContract
+func NewBoundContract(cli Client, address ethcmn.Address, contractABI abi.ABI) *BoundContract {
+	return &BoundContract{
+		cli:     cli,
+		address: address,
+		abi:     contractABI,
+	}
+}
+
+// DeployContract deploys the contract with the bytecode and the constructor params through CreateContractEthereum
+func DeployContract(cli Client, opts *TransactOpts, contractABI abi.ABI, bytecode []byte, params ...interface{}) (
+	ethcmn.Address, sdk.TxResponse, *BoundContract, error) {
+	input, err := contractABI.Pack("", params...)
+	if err != nil {
+		return ethcmn.Address{}, sdk.TxResponse{}, nil, fmt.Errorf("failed. pack constructor params error: %s", err)
+	}
+
+	contract := NewBoundContract(cli, ethcmn.Address{}, contractABI)
+	resp, from, nonce, err := contract.transact(opts, nil, append(append([]byte{}, bytecode...), input...))
+	if err != nil {
+		return ethcmn.Address{}, resp, nil, err
+	}
+
+	contract.address = ethcrypto.CreateAddress(from, nonce)
+	return contract.address, resp, contract, nil
+}
+
+// Address returns the address of the contract
+func (c *BoundContract) Address() ethcmn.Address {
+	return c.address
+}
+
+// ABI returns the abi of the contract
+func (c *BoundContract) ABI() abi.ABI {
+	return c.abi
+}
+
+// Call reads the contract with the method and returns the unpacked outputs
+func (c *BoundContract) Call(opts *CallOpts, method string, params ...interface{}) ([]interface{}, error) {
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed. pack params of method %s error: %s", method, err)
+	}
+
+	from, data := opts.From, hexutil.Bytes(input)
+	ret, err := c.cli.Evm().Web3Proxy().CallProxy(rpctypes.CallArgs{
+		From: &from,
+		To:   &c.address,
+		Data: &data,
+	}, opts.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 && len(c.abi.Methods[method].Outputs) != 0 {
+		return nil, fmt.Errorf("failed. no return data of method %s, and contract %s may not be deployed", method,
+			c.address.Hex())
+	}
+
+	return c.abi.Unpack(method, ret)
+}
+
+// Transact sends a tx to the contract with the method through SendTxEthereum
+func (c *BoundContract) Transact(opts *TransactOpts, method string, params ...interface{}) (sdk.TxResponse, error) {
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed. pack params of method %s error: %s", method, err)
+	}
+
+	return c.RawTransact(opts, input)
+}
+
+// RawTransact sends a tx to the contract with the input packed already through SendTxEthereum
+func (c *BoundContract) RawTransact(opts *TransactOpts, input []byte) (sdk.TxResponse, error) {
+	resp, _, _, err := c.transact(opts, &c.address, input)
+	return resp, err
+}
+
+// UnpackLog unpacks the log of the event into its input values in order, where each indexed value of the dynamic types
+// is the hash in the topic
+func (c *BoundContract) UnpackLog(event string, log ethcore.Log) ([]interface{}, error) {
+	ev, ok := c.abi.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("failed. no event %s in the abi", event)
+	}
+
+	return types.UnpackLog(ev, log)
+}
+
+// FilterLogs returns the logs of the event emitted by the contract
+// Note: an anonymous event has no topic of its id to filter by, so the logs with another number of topics or with the
+// id of a non-anonymous event in the abi as the first topic are dropped
+func (c *BoundContract) FilterLogs(opts *FilterOpts, event string) ([]ethcore.Log, error) {
+	if opts == nil {
+		opts = new(FilterOpts)
+	}
+
+	ev, ok := c.abi.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("failed. no event %s in the abi", event)
+	}
+
+	query := ethereum.FilterQuery{
+		FromBlock: opts.FromBlock,
+		ToBlock:   opts.ToBlock,
+		Addresses: []ethcmn.Address{c.address},
+	}
+	if !ev.Anonymous {
+		query.Topics = [][]ethcmn.Hash{{ev.ID}}
+	}
+
+	pLogs, err := c.cli.Evm().Web3Proxy().GetLogsProxy(query)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]ethcore.Log, 0, len(pLogs))
+	for _, pLog := range pLogs {
+		if ev.Anonymous && !c.maybeAnonymous(ev, *pLog) {
+			continue
+		}
+		logs = append(logs, *pLog)
+	}
+
+	return logs, nil
+}
+
+// maybeAnonymous shows whether the log could be emitted as the anonymous event
+func (c *BoundContract) maybeAnonymous(ev abi.Event, log ethcore.Log) bool {
+	var indexed int
+	for _, input := range ev.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+	if len(log.Topics) != indexed {
+		return false
+	}
+
+	if len(log.Topics) != 0 {
+		for _, other := range c.abi.Events {
+			if !other.Anonymous && log.Topics[0] == other.ID {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// transact fills the options missing from the chain, and sends the tx or deploys the contract if to is nil
+func (c *BoundContract) transact(opts *TransactOpts, to *ethcmn.Address, input []byte) (resp sdk.TxResponse,
+	from ethcmn.Address, nonce uint64, err error) {
+	if opts == nil || opts.PrivKey == nil {
+		return resp, from, nonce, errors.New("failed. no private key in the transact options")
+	}
+
+	from = ethcrypto.PubkeyToAddress(opts.PrivKey.PublicKey)
+	proxy := c.cli.Evm().Web3Proxy()
+	if opts.Nonce != nil {
+		nonce = *opts.Nonce
+	} else {
+		latestNonce, err := proxy.GetTransactionCountProxy(from, rpctypes.LatestBlockNumber)
+		if err != nil {
+			return resp, from, nonce, fmt.Errorf("failed. query nonce of %s error: %s", from.Hex(), err)
+		}
+		nonce = uint64(*latestNonce)
+	}
+
+	value := new(big.Int)
+	if opts.Value != nil {
+		value = opts.Value
+	}
+
+	gasPrice := types.DefaultGasPrice
+	if opts.GasPrice != nil {
+		gasPrice = opts.GasPrice
+	}
+
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		data := hexutil.Bytes(input)
+		estimatedGas, err := proxy.EstimateGasProxy(rpctypes.CallArgs{
+			From:     &from,
+			To:       to,
+			GasPrice: (*hexutil.Big)(gasPrice),
+			Value:    (*hexutil.Big)(value),
+			Data:     &data,
+		})
+		if err != nil {
+			return resp, from, nonce, fmt.Errorf("failed. estimate gas error: %s", err)
+		}
+		gasLimit = uint64(estimatedGas)
+	}
+
+	if to == nil {
+		resp, err = c.cli.Evm().CreateContractEthereum(opts.PrivKey, nonce, value, gasLimit, gasPrice, input)
+	} else {
+		resp, err = c.cli.Evm().SendTxEthereum(opts.PrivKey, nonce, *to, value, gasLimit, gasPrice, input)
+	}
+
+	return
+}
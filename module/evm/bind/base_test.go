@@ -0,0 +1,196 @@
+package bind
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	rpctypes "github.com/okx/okbchain/app/rpc/types"
+	"github.com/stretchr/testify/require"
+)
+
+const bookABI = `[
+{"type":"constructor","inputs":[{"name":"owner","type":"address"}]},
+{"type":"function","name":"getOrder","stateMutability":"view","inputs":[{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"tuple","internalType":"struct Book.Order","components":[{"name":"maker","type":"address"},{"name":"amount","type":"uint256"},{"name":"fills","type":"tuple[]","internalType":"struct Book.Fill[]","components":[{"name":"taker","type":"address"},{"name":"size","type":"uint64"}]}]},{"name":"ok","type":"bool"}]},
+{"type":"function","name":"submit","stateMutability":"nonpayable","inputs":[{"name":"order","type":"tuple","internalType":"struct Book.Order","components":[{"name":"maker","type":"address"},{"name":"amount","type":"uint256"},{"name":"fills","type":"tuple[]","internalType":"struct Book.Fill[]","components":[{"name":"taker","type":"address"},{"name":"size","type":"uint64"}]}]},{"name":"type","type":"bytes32"},{"name":"","type":"string"}],"outputs":[]},
+{"type":"function","name":"address","stateMutability":"pure","inputs":[],"outputs":[]},
+{"type":"event","name":"Note","anonymous":false,"inputs":[{"name":"tag","type":"string","indexed":true},{"name":"id","type":"uint256","indexed":true},{"name":"raw","type":"bytes","indexed":false}]},
+{"type":"event","name":"Filled","anonymous":true,"inputs":[{"name":"taker","type":"address","indexed":true},{"name":"size","type":"uint64","indexed":false}]}
+]`
+
+var (
+	contractAddr = ethcmn.HexToAddress("0x9aD84c8630E0282F78e5479B46E64E17779e3Cfb")
+	makerAddr    = ethcmn.HexToAddress("0x2c4d395A628e68aD818a9938998a5C4723BBEa83")
+)
+
+// the structs that the generated binding declares for the tuples
+type bookFill struct {
+	Taker ethcmn.Address
+	Size  uint64
+}
+
+type bookOrder struct {
+	Maker  ethcmn.Address
+	Amount *big.Int
+	Fills  []bookFill
+}
+
+func newFakeClient() *mocks.FakeChain {
+	cli := mocks.NewFakeChain(100, 5)
+	cli.EstimatedGas = 30000
+	return cli
+}
+
+func TestBoundContract_Call(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(bookABI))
+	require.NoError(t, err)
+	cli := newFakeClient()
+	contract := NewBoundContract(cli, contractAddr, parsed)
+	require.Equal(t, contractAddr, contract.Address())
+
+	order := bookOrder{
+		Maker:  makerAddr,
+		Amount: big.NewInt(1024),
+		Fills:  []bookFill{{Taker: contractAddr, Size: 8}},
+	}
+	cli.CallReturn, err = parsed.Methods["getOrder"].Outputs.Pack(order, true)
+	require.NoError(t, err)
+
+	out, err := contract.Call(&CallOpts{BlockNumber: 10}, "getOrder", big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(out))
+	require.Equal(t, rpctypes.BlockNumber(10), cli.LastBlockNum)
+	require.Equal(t, contractAddr, *cli.LastCallArgs.To)
+	require.Equal(t, ethcmn.Address{}, *cli.LastCallArgs.From)
+
+	// converts the anonymous struct unpacked as the generated binding does
+	gotOrder := *abi.ConvertType(out[0], new(bookOrder)).(*bookOrder)
+	require.Equal(t, order, gotOrder)
+	require.True(t, *abi.ConvertType(out[1], new(bool)).(*bool))
+
+	// no return data
+	cli.CallReturn = nil
+	_, err = contract.Call(nil, "getOrder", big.NewInt(1))
+	require.Error(t, err)
+
+	// method without outputs
+	_, err = contract.Call(nil, "address")
+	require.NoError(t, err)
+
+	// bad params
+	_, err = contract.Call(nil, "getOrder", "1")
+	require.Error(t, err)
+}
+
+func TestBoundContract_Transact(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(bookABI))
+	require.NoError(t, err)
+	priv, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	from := ethcrypto.PubkeyToAddress(priv.PublicKey)
+	cli := newFakeClient()
+	contract := NewBoundContract(cli, contractAddr, parsed)
+
+	order := bookOrder{Maker: makerAddr, Amount: big.NewInt(1)}
+	resp, err := contract.Transact(&TransactOpts{PrivKey: priv}, "submit", order, [32]byte{1}, "memo")
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.TxHash)
+	require.Equal(t, 1, len(cli.EthTxs))
+	require.Equal(t, contractAddr, *cli.EthTxs[0].To)
+	require.Equal(t, uint64(5), cli.EthTxs[0].Nonce)
+	require.Equal(t, uint64(30000), cli.EthTxs[0].GasLimit)
+	require.Equal(t, types.DefaultGasPrice, cli.EthTxs[0].GasPrice)
+	require.Equal(t, from, *cli.LastCallArgs.From)
+	expectedData, err := parsed.Pack("submit", order, [32]byte{1}, "memo")
+	require.NoError(t, err)
+	require.Equal(t, expectedData, cli.EthTxs[0].Data)
+
+	// options set
+	nonce := uint64(9)
+	_, err = contract.Transact(&TransactOpts{PrivKey: priv, Nonce: &nonce, GasLimit: 100000, GasPrice: big.NewInt(1)},
+		"submit", order, [32]byte{}, "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), cli.EthTxs[1].Nonce)
+	require.Equal(t, uint64(100000), cli.EthTxs[1].GasLimit)
+	require.Equal(t, big.NewInt(1), cli.EthTxs[1].GasPrice)
+
+	// no key
+	_, err = contract.Transact(nil, "submit", order, [32]byte{}, "")
+	require.Error(t, err)
+
+	// deploy
+	address, resp, deployed, err := DeployContract(cli, &TransactOpts{PrivKey: priv}, parsed, []byte{0x60, 0x80},
+		makerAddr)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.TxHash)
+	require.Equal(t, ethcrypto.CreateAddress(from, 5), address)
+	require.Equal(t, address, deployed.Address())
+	require.Nil(t, cli.EthTxs[2].To)
+	require.Equal(t, []byte{0x60, 0x80}, cli.EthTxs[2].Data[:2])
+	require.Equal(t, ethcmn.LeftPadBytes(makerAddr.Bytes(), 32), cli.EthTxs[2].Data[2:])
+}
+
+func TestBoundContract_UnpackLog(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(bookABI))
+	require.NoError(t, err)
+	cli := newFakeClient()
+	contract := NewBoundContract(cli, contractAddr, parsed)
+
+	event := parsed.Events["Note"]
+	data, err := event.Inputs.NonIndexed().Pack([]byte("raw"))
+	require.NoError(t, err)
+	tagHash := ethcrypto.Keccak256Hash([]byte("tag"))
+	log := ethcore.Log{
+		Address: contractAddr,
+		Topics:  []ethcmn.Hash{event.ID, tagHash, ethcmn.BigToHash(big.NewInt(7))},
+		Data:    data,
+	}
+
+	values, err := contract.UnpackLog("Note", log)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{tagHash, big.NewInt(7), []byte("raw")}, values)
+
+	// wrong event
+	log.Topics[0] = ethcmn.Hash{}
+	_, err = contract.UnpackLog("Note", log)
+	require.Error(t, err)
+	_, err = contract.UnpackLog("Unknown", log)
+	require.Error(t, err)
+
+	// missing topic
+	log.Topics = []ethcmn.Hash{event.ID, tagHash}
+	_, err = contract.UnpackLog("Note", log)
+	require.Error(t, err)
+
+	// filter
+	cli.Logs = []*ethcore.Log{&log}
+	logs, err := contract.FilterLogs(&FilterOpts{FromBlock: big.NewInt(1)}, "Note")
+	require.NoError(t, err)
+	require.Equal(t, []ethcore.Log{log}, logs)
+	require.Equal(t, []ethcmn.Address{contractAddr}, cli.LastFilter.Addresses)
+	require.Equal(t, [][]ethcmn.Hash{{event.ID}}, cli.LastFilter.Topics)
+	require.Equal(t, big.NewInt(1), cli.LastFilter.FromBlock)
+	require.Nil(t, cli.LastFilter.ToBlock)
+
+	// anonymous event filtered without any topic
+	filled := parsed.Events["Filled"]
+	data, err = filled.Inputs.NonIndexed().Pack(uint64(8))
+	require.NoError(t, err)
+	filledLog := ethcore.Log{Address: contractAddr, Topics: []ethcmn.Hash{makerAddr.Hash()}, Data: data}
+	otherLog := ethcore.Log{Address: contractAddr, Topics: []ethcmn.Hash{event.ID}, Data: data}
+	cli.Logs = []*ethcore.Log{&log, &otherLog, &filledLog}
+	logs, err = contract.FilterLogs(nil, "Filled")
+	require.NoError(t, err)
+	require.Equal(t, []ethcore.Log{filledLog}, logs)
+	require.Nil(t, cli.LastFilter.Topics)
+
+	values, err = contract.UnpackLog("Filled", logs[0])
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{makerAddr, uint64(8)}, values)
+}
@@ -0,0 +1,332 @@
+package bind
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+)
+
+// reservedNames are the identifiers used in the generated function bodies, which the params mustn't shadow
+var reservedNames = map[string]bool{
+	"c": true, "cli": true, "opts": true, "out": true, "err": true, "parsed": true, "address": true, "resp": true,
+	"contract": true, "values": true, "log": true, "logs": true, "events": true, "event": true, "i": true,
+	"abi": true, "big": true, "bind": true, "ethcmn": true, "ethcore": true, "sdk": true, "strings": true,
+}
+
+type tmplField struct {
+	Name string
+	Type string
+}
+
+type tmplStruct struct {
+	Name   string
+	Fields []tmplField
+}
+
+type tmplParam struct {
+	Name string
+	Type string
+}
+
+type tmplMethod struct {
+	Name     string
+	Original string
+	Sig      string
+	Constant bool
+	Params   []tmplParam
+	Outputs  []string
+}
+
+type tmplEvent struct {
+	Name       string
+	StructName string
+	Original   string
+	Sig        string
+	Fields     []tmplField
+}
+
+type tmplData struct {
+	Package     string
+	Type        string
+	ABI         string
+	Bin         string
+	Constructor []tmplParam
+	Structs     []*tmplStruct
+	Methods     []tmplMethod
+	Events      []tmplEvent
+}
+
+// generator keeps the state while binding the types of an abi
+type generator struct {
+	typeName string
+	structs  map[string]*tmplStruct
+	ordered  []*tmplStruct
+	// declared are the top level identifiers declared in the generated file
+	declared map[string]bool
+}
+
+// Generate generates the Go binding of the contract with the abi, which reads through the web3 proxy of the evm client
+// and writes through Evm().SendTxEthereum. The deploy function is generated only if the bytecode is provided
+func Generate(typeName, pkgName, abiJSON, bytecode string) ([]byte, error) {
+	if !token.IsIdentifier(typeName) || token.IsKeyword(typeName) {
+		return nil, fmt.Errorf("failed. invalid type name %q", typeName)
+	}
+	if !token.IsIdentifier(pkgName) || token.IsKeyword(pkgName) {
+		return nil, fmt.Errorf("failed. invalid package name %q", pkgName)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed. parse abi error: %s", err)
+	}
+
+	var compactABI bytes.Buffer
+	if err = json.Compact(&compactABI, []byte(abiJSON)); err != nil {
+		return nil, fmt.Errorf("failed. compact abi error: %s", err)
+	}
+
+	bin := strings.TrimPrefix(strings.TrimSpace(bytecode), "0x")
+	if _, err = hex.DecodeString(bin); err != nil {
+		return nil, fmt.Errorf("failed. invalid bytecode: %s", err)
+	}
+
+	gen := &generator{
+		typeName: typeName,
+		structs:  make(map[string]*tmplStruct),
+		declared: map[string]bool{
+			typeName:            true,
+			"New" + typeName:    true,
+			"Deploy" + typeName: true,
+			typeName + "ABI":    true,
+			typeName + "Bin":    true,
+			"_":                 true,
+		},
+	}
+	data := tmplData{
+		Package: pkgName,
+		Type:    typeName,
+		ABI:     compactABI.String(),
+	}
+
+	if len(bin) != 0 {
+		data.Bin = "0x" + bin
+		if data.Constructor, err = gen.bindParams(parsed.Constructor.Inputs); err != nil {
+			return nil, err
+		}
+	}
+
+	// the method names of the generated type
+	usedMethods := map[string]bool{"Address": true}
+	for _, key := range sortedKeys(parsed.Methods) {
+		method := parsed.Methods[key]
+		m := tmplMethod{
+			Name:     abi.ResolveNameConflict(abi.ToCamelCase(key), func(s string) bool { return usedMethods[s] }),
+			Original: key,
+			Sig:      method.Sig,
+			Constant: method.IsConstant(),
+		}
+		usedMethods[m.Name] = true
+
+		if m.Params, err = gen.bindParams(method.Inputs); err != nil {
+			return nil, err
+		}
+		if m.Constant {
+			for _, output := range method.Outputs {
+				goType, err := gen.bindType(output.Type)
+				if err != nil {
+					return nil, err
+				}
+				m.Outputs = append(m.Outputs, goType)
+			}
+		}
+		data.Methods = append(data.Methods, m)
+	}
+
+	for _, key := range sortedKeys(parsed.Events) {
+		event := parsed.Events[key]
+		name := abi.ResolveNameConflict(abi.ToCamelCase(key),
+			func(s string) bool { return usedMethods["Parse"+s] || usedMethods["Filter"+s] })
+		usedMethods["Parse"+name], usedMethods["Filter"+name] = true, true
+
+		e := tmplEvent{
+			Name:       name,
+			StructName: gen.declare(typeName + name),
+			Original:   key,
+			Sig:        event.Sig,
+		}
+		usedFields := map[string]bool{"Raw": true}
+		for i, input := range event.Inputs {
+			fieldName := abi.ToCamelCase(input.Name)
+			if len(fieldName) == 0 {
+				fieldName = fmt.Sprintf("Arg%d", i)
+			}
+			fieldName = abi.ResolveNameConflict(fieldName, func(s string) bool { return usedFields[s] })
+			usedFields[fieldName] = true
+
+			goType := "ethcmn.Hash"
+			if !input.Indexed || !types.IsHashedTopic(input.Type) {
+				if goType, err = gen.bindType(input.Type); err != nil {
+					return nil, err
+				}
+			}
+			e.Fields = append(e.Fields, tmplField{Name: fieldName, Type: goType})
+		}
+		data.Events = append(data.Events, e)
+	}
+	data.Structs = gen.ordered
+
+	var buffer bytes.Buffer
+	if err = bindTemplate.Execute(&buffer, data); err != nil {
+		return nil, fmt.Errorf("failed. execute template error: %s", err)
+	}
+
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed. format the generated code error: %s\n%s", err, buffer.String())
+	}
+
+	return code, nil
+}
+
+// bindParams binds the abi arguments to the Go params with the valid and unique names
+func (gen *generator) bindParams(args abi.Arguments) ([]tmplParam, error) {
+	used := make(map[string]bool)
+	params := make([]tmplParam, len(args))
+	for i, arg := range args {
+		goType, err := gen.bindType(arg.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		name := abi.ToCamelCase(arg.Name)
+		if len(name) != 0 {
+			name = strings.ToLower(name[:1]) + name[1:]
+		}
+		if len(name) == 0 || token.IsKeyword(name) || reservedNames[name] {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		name = abi.ResolveNameConflict(name, func(s string) bool { return used[s] || reservedNames[s] })
+		used[name] = true
+
+		params[i] = tmplParam{Name: name, Type: goType}
+	}
+
+	return params, nil
+}
+
+// bindType converts the abi type to the Go type that the abi package packs from and unpacks to
+func (gen *generator) bindType(t abi.Type) (string, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		prefix := "int"
+		if t.T == abi.UintTy {
+			prefix = "uint"
+		}
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, t.Size), nil
+		}
+		return "*big.Int", nil
+	case abi.BoolTy:
+		return "bool", nil
+	case abi.StringTy:
+		return "string", nil
+	case abi.AddressTy:
+		return "ethcmn.Address", nil
+	case abi.BytesTy:
+		return "[]byte", nil
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", t.Size), nil
+	case abi.HashTy:
+		return "ethcmn.Hash", nil
+	case abi.FunctionTy:
+		return "[24]byte", nil
+	case abi.SliceTy:
+		elem, err := gen.bindType(*t.Elem)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case abi.ArrayTy:
+		elem, err := gen.bindType(*t.Elem)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%d]%s", t.Size, elem), nil
+	case abi.TupleTy:
+		return gen.bindStruct(t)
+	}
+
+	return "", fmt.Errorf("failed. unsupported abi type %s", t.String())
+}
+
+// bindStruct declares the Go struct of the tuple once, whose field names match the ones that the abi package unpacks to
+func (gen *generator) bindStruct(t abi.Type) (string, error) {
+	id := t.TupleRawName + t.String()
+	if s, ok := gen.structs[id]; ok {
+		return s.Name, nil
+	}
+
+	s := new(tmplStruct)
+	used := make(map[string]bool)
+	for i, elem := range t.TupleElems {
+		goType, err := gen.bindType(*elem)
+		if err != nil {
+			return "", err
+		}
+
+		name := abi.ResolveNameConflict(abi.ToCamelCase(t.TupleRawNames[i]), func(s string) bool { return used[s] })
+		used[name] = true
+		s.Fields = append(s.Fields, tmplField{Name: name, Type: goType})
+	}
+
+	rawName := t.TupleRawName
+	if idx := strings.Index(rawName, "["); idx >= 0 {
+		rawName = rawName[:idx]
+	}
+	if len(rawName) == 0 {
+		rawName = fmt.Sprintf("Tuple%d", len(gen.ordered))
+	}
+	// prefixes the struct with the type to avoid the collision among the bindings in the same package
+	rawName = abi.ToCamelCase(rawName)
+	if !strings.HasPrefix(rawName, gen.typeName) {
+		rawName = gen.typeName + rawName
+	}
+	s.Name = gen.declare(rawName)
+
+	gen.structs[id] = s
+	gen.ordered = append(gen.ordered, s)
+	return s.Name, nil
+}
+
+// declare makes the top level identifier unique in the generated file
+func (gen *generator) declare(name string) string {
+	name = abi.ResolveNameConflict(name, func(s string) bool { return gen.declared[s] })
+	gen.declared[name] = true
+	return name
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case map[string]abi.Method:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]abi.Event:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package bind
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	code, err := Generate("Book", "book", bookABI, "0x6080")
+	require.NoError(t, err)
+
+	// valid Go
+	_, err = parser.ParseFile(token.NewFileSet(), "book.go", code, parser.AllErrors)
+	require.NoError(t, err)
+
+	src := string(code)
+	for _, expected := range []string{
+		"package book",
+		"const BookABI = ",
+		`const BookBin = "0x6080"`,
+		"type BookOrder struct {",
+		"Fills  []BookFill",
+		"type BookFill struct {",
+		"func NewBook(cli bind.Client, address ethcmn.Address) (*Book, error) {",
+		"func DeployBook(cli bind.Client, opts *bind.TransactOpts, owner ethcmn.Address) (",
+		"func (c *Book) Address() ethcmn.Address {",
+		// the method named "address" is renamed
+		"func (c *Book) Address0(opts *bind.CallOpts) error {",
+		"func (c *Book) GetOrder(opts *bind.CallOpts, id *big.Int) (BookOrder, bool, error) {",
+		// the keyword and the empty names of params are replaced
+		"func (c *Book) Submit(opts *bind.TransactOpts, order BookOrder, arg1 [32]byte, arg2 string) (sdk.TxResponse, error) {",
+		`c.contract.Transact(opts, "submit", order, arg1, arg2)`,
+		"type BookNote struct {",
+		// the indexed string is the hash in the topic
+		"Tag  ethcmn.Hash",
+		"Raw0 []byte",
+		"Raw  ethcore.Log",
+		"func (c *Book) ParseNote(log ethcore.Log) (*BookNote, error) {",
+		"func (c *Book) FilterNote(opts *bind.FilterOpts) ([]*BookNote, error) {",
+		// the anonymous event
+		"type BookFilled struct {",
+		"func (c *Book) FilterFilled(opts *bind.FilterOpts) ([]*BookFilled, error) {",
+	} {
+		require.True(t, strings.Contains(src, expected), expected)
+	}
+
+	// type-checks the binding in a package of the module
+	if !testing.Short() {
+		dir, err := ioutil.TempDir(".", "book")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "book.go"), code, 0600))
+		out, err := exec.Command("go", "vet", "./"+dir).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	// no deploy without the bytecode
+	code, err = Generate("Book", "book", bookABI, "")
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(code), "DeployBook"))
+	require.False(t, strings.Contains(string(code), "BookBin"))
+
+	// bad inputs
+	_, err = Generate("func", "book", bookABI, "")
+	require.Error(t, err)
+	_, err = Generate("Book", "book-pkg", bookABI, "")
+	require.Error(t, err)
+	_, err = Generate("Book", "book", bookABI[1:], "")
+	require.Error(t, err)
+	_, err = Generate("Book", "book", bookABI, "0xzz")
+	require.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package bind
+
+import "text/template"
+
+// bindTemplate is the template of the Go binding generated from an abi
+var bindTemplate = template.Must(template.New("bind").Parse(`// Code generated by evmbind. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain-go-sdk/module/evm/bind"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// references the imports in case that some of them are unused
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.ConvertType
+	_ = ethcmn.Address{}
+	_ = ethcore.Log{}
+	_ = bind.NewBoundContract
+	_ = sdk.TxResponse{}
+)
+
+// {{.Type}}ABI is the abi of the contract {{.Type}}
+const {{.Type}}ABI = {{printf "%q" .ABI}}
+{{if .Bin}}
+// {{.Type}}Bin is the bytecode to deploy the contract {{.Type}}
+const {{.Type}}Bin = {{printf "%q" .Bin}}
+{{end}}
+{{range .Structs}}
+// {{.Name}} - structure of the tuple in the abi of the contract
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+{{end}}
+// {{.Type}} - structure of the binding of the contract {{.Type}}
+type {{.Type}} struct {
+	contract *bind.BoundContract
+}
+
+// New{{.Type}} creates a new instance of {{.Type}} bound to the contract on the address
+func New{{.Type}}(cli bind.Client, address ethcmn.Address) (*{{.Type}}, error) {
+	parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{.Type}}{contract: bind.NewBoundContract(cli, address, parsed)}, nil
+}
+{{if .Bin}}
+// Deploy{{.Type}} deploys the contract {{.Type}} and returns the binding of it
+func Deploy{{.Type}}(cli bind.Client, opts *bind.TransactOpts{{range .Constructor}}, {{.Name}} {{.Type}}{{end}}) (
+	ethcmn.Address, sdk.TxResponse, *{{.Type}}, error) {
+	parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	if err != nil {
+		return ethcmn.Address{}, sdk.TxResponse{}, nil, err
+	}
+
+	address, resp, contract, err := bind.DeployContract(cli, opts, parsed, ethcmn.FromHex({{.Type}}Bin)
+		{{- range .Constructor}}, {{.Name}}{{end}})
+	if err != nil {
+		return ethcmn.Address{}, resp, nil, err
+	}
+
+	return address, resp, &{{.Type}}{contract: contract}, nil
+}
+{{end}}
+// Address returns the address of the contract
+func (c *{{.Type}}) Address() ethcmn.Address {
+	return c.contract.Address()
+}
+{{$type := .Type}}
+{{- range .Methods}}
+{{- if .Constant}}
+// {{.Name}} calls the constant method {{.Sig}} of the contract
+func (c *{{$type}}) {{.Name}}(opts *bind.CallOpts{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (
+	{{- range .Outputs}}{{.}}, {{end}}error) {
+	{{if .Outputs}}out{{else}}_{{end}}, err := c.contract.Call(opts, {{printf "%q" .Original}}{{range .Params}}, {{.Name}}{{end}})
+	if err != nil {
+		return {{range .Outputs}}*new({{.}}), {{end}}err
+	}
+
+	return {{range $i, $out := .Outputs}}*abi.ConvertType(out[{{$i}}], new({{$out}})).(*{{$out}}), {{end}}nil
+}
+{{else}}
+// {{.Name}} sends a tx to the method {{.Sig}} of the contract
+func (c *{{$type}}) {{.Name}}(opts *bind.TransactOpts{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (sdk.TxResponse, error) {
+	return c.contract.Transact(opts, {{printf "%q" .Original}}{{range .Params}}, {{.Name}}{{end}})
+}
+{{end}}
+{{- end}}
+{{- range .Events}}
+// {{.StructName}} - structure of the event {{.Sig}} of the contract
+type {{.StructName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+	Raw ethcore.Log
+}
+
+// Parse{{.Name}} decodes the log into the event {{.Sig}}
+func (c *{{$type}}) Parse{{.Name}}(log ethcore.Log) (*{{.StructName}}, error) {
+	{{if .Fields}}values{{else}}_{{end}}, err := c.contract.UnpackLog({{printf "%q" .Original}}, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{.StructName}}{
+	{{- range $i, $field := .Fields}}
+		{{$field.Name}}: *abi.ConvertType(values[{{$i}}], new({{$field.Type}})).(*{{$field.Type}}),
+	{{- end}}
+		Raw: log,
+	}, nil
+}
+
+// Filter{{.Name}} returns the events {{.Sig}} emitted by the contract
+func (c *{{$type}}) Filter{{.Name}}(opts *bind.FilterOpts) ([]*{{.StructName}}, error) {
+	logs, err := c.contract.FilterLogs(opts, {{printf "%q" .Original}})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*{{.StructName}}, len(logs))
+	for i, log := range logs {
+		if events[i], err = c.Parse{{.Name}}(log); err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+{{end}}`))
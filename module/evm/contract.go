@@ -0,0 +1,121 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/evm/bind"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	rpctypes "github.com/okx/okbchain/app/rpc/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+var (
+	errNoReturnData      = errors.New("failed. no return data")
+	erc165PayloadBuilder = mustNewPayloadBuilder(types.ERC165ABI)
+)
+
+// mustNewPayloadBuilder creates the PayloadBuilder of the abi without bytecode, and panics if the abi is invalid
+func mustNewPayloadBuilder(abiJSON string) *utils.PayloadBuilder {
+	payloadBuilder, err := utils.NewPayloadBuilder("", abiJSON)
+	if err != nil {
+		panic(err)
+	}
+
+	return &payloadBuilder
+}
+
+// callContract calls the method of the contract on the latest block and unpacks the outputs
+func (ec evmClient) callContract(payloadBuilder *utils.PayloadBuilder, contractAddr ethcmn.Address, method string,
+	params ...interface{}) ([]interface{}, error) {
+	payload, err := payloadBuilder.Build(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed. build payload of method %s error: %s", method, err)
+	}
+
+	data := hexutil.Bytes(payload)
+	ret, err := ec.CallProxy(rpctypes.CallArgs{To: &contractAddr, Data: &data}, rpctypes.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("%w of method %s, and %s may not be a contract", errNoReturnData, method,
+			contractAddr.Hex())
+	}
+
+	outputs, err := payloadBuilder.Unpack(method, ret)
+	if err != nil {
+		return nil, fmt.Errorf("failed. unpack return data of method %s error: %s", method, err)
+	}
+
+	return outputs, nil
+}
+
+// evmBinder - structure of the client of the bound contracts on top of the evm client
+type evmBinder struct {
+	ec evmClient
+}
+
+// Evm returns the evm client
+func (b evmBinder) Evm() exposed.Evm {
+	return b.ec
+}
+
+// sendContractTx sends the tx with the payload to the contract through the bound contract, where the nonce, the gas
+// limit and the gas price missing from the options are filled as bind.TransactOpts describes
+// Note: the txs of the sender still in the mempool aren't counted into the default nonce, so set the nonce of the
+// options to send several txs in a row
+func (ec evmClient) sendContractTx(opts *types.TransactOpts, contractAddr ethcmn.Address, payload []byte) (
+	sdk.TxResponse, error) {
+	return bind.NewBoundContract(evmBinder{ec}, contractAddr, abi.ABI{}).RawTransact(opts, payload)
+}
+
+// supportsInterface calls the method supportsInterface of ERC-165 on the contract
+func (ec evmClient) supportsInterface(contractAddr ethcmn.Address, interfaceID [4]byte) (bool, error) {
+	outputs, err := ec.callContract(erc165PayloadBuilder, contractAddr, "supportsInterface", interfaceID)
+	if err != nil {
+		return false, err
+	}
+
+	supported, ok := outputs[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("failed. unexpected output %v of method supportsInterface", outputs[0])
+	}
+
+	return supported, nil
+}
+
+// detectInterface detects whether the contract implements the interface as ERC-165 specifies, where the contract
+// reverting or returning nothing doesn't
+func (ec evmClient) detectInterface(contractAddr ethcmn.Address, interfaceID [4]byte) (bool, error) {
+	for _, probe := range []struct {
+		interfaceID [4]byte
+		expected    bool
+	}{
+		{types.ERC165InterfaceID, true},
+		{types.InvalidInterfaceID, false},
+		{interfaceID, true},
+	} {
+		supported, err := ec.supportsInterface(contractAddr, probe.interfaceID)
+		if err != nil {
+			if errors.Is(err, errNoReturnData) || strings.Contains(err.Error(), vm.ErrExecutionReverted.Error()) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		if supported != probe.expected {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
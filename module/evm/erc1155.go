@@ -0,0 +1,158 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+var erc1155PayloadBuilder = mustNewPayloadBuilder(types.ERC1155ABI)
+
+type erc1155Client struct {
+	ec evmClient
+}
+
+// ERC1155 returns the client with exposed.ERC1155's behaviour
+func (ec evmClient) ERC1155() exposed.ERC1155 {
+	return erc1155Client{ec}
+}
+
+// BalanceOfBatch returns the balances of the erc1155 tokens with the ids owned by the owners in pairs
+func (c erc1155Client) BalanceOfBatch(contractAddr ethcmn.Address, owners []ethcmn.Address, ids []*big.Int) (
+	[]*big.Int, error) {
+	if len(owners) != len(ids) {
+		return nil, fmt.Errorf("failed. %d owners mismatch %d ids", len(owners), len(ids))
+	}
+
+	outputs, err := c.ec.callContract(erc1155PayloadBuilder, contractAddr, "balanceOfBatch", owners, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, ok := outputs[0].([]*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("failed. unexpected output %v of method balanceOfBatch", outputs[0])
+	}
+
+	return balances, nil
+}
+
+// URI returns the metadata uri of the erc1155 token
+// Note: the "{id}" in the uri is replaced with the id in lowercase hex of 64 characters as ERC-1155 specifies
+func (c erc1155Client) URI(contractAddr ethcmn.Address, id *big.Int) (string, error) {
+	outputs, err := c.ec.callContract(erc1155PayloadBuilder, contractAddr, "uri", id)
+	if err != nil {
+		return "", err
+	}
+
+	uri, ok := outputs[0].(string)
+	if !ok {
+		return "", fmt.Errorf("failed. unexpected output %v of method uri", outputs[0])
+	}
+
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", id)), nil
+}
+
+// SupportsInterface returns whether the contract supports the interface by ERC-165
+func (c erc1155Client) SupportsInterface(contractAddr ethcmn.Address, interfaceID [4]byte) (bool, error) {
+	return c.ec.supportsInterface(contractAddr, interfaceID)
+}
+
+// IsERC1155 detects whether the contract is an erc1155 one by ERC-165
+func (c erc1155Client) IsERC1155(contractAddr ethcmn.Address) (bool, error) {
+	return c.ec.detectInterface(contractAddr, types.ERC1155InterfaceID)
+}
+
+// SafeBatchTransferFrom transfers the amounts of the erc1155 tokens with the ids in pairs from the owner to the
+// receiver, which must accept them if a contract
+func (c erc1155Client) SafeBatchTransferFrom(opts *types.TransactOpts, contractAddr, from, to ethcmn.Address, ids,
+	amounts []*big.Int, data []byte) (resp sdk.TxResponse, err error) {
+	if len(ids) != len(amounts) {
+		return resp, fmt.Errorf("failed. %d ids mismatch %d amounts", len(ids), len(amounts))
+	}
+
+	payload, err := erc1155PayloadBuilder.Build("safeBatchTransferFrom", from, to, ids, amounts, data)
+	if err != nil {
+		return resp, fmt.Errorf("failed. build payload of method safeBatchTransferFrom error: %s", err)
+	}
+
+	return c.ec.sendContractTx(opts, contractAddr, payload)
+}
+
+// DecodeERC1155Logs decodes the erc1155 events TransferSingle and TransferBatch from the logs, and skips the other logs
+func (erc1155Client) DecodeERC1155Logs(logs []*ethcore.Log) (transfers []types.ERC1155Transfer, err error) {
+	for _, log := range logs {
+		if log == nil || len(log.Topics) != 4 {
+			continue
+		}
+
+		transfer := types.ERC1155Transfer{
+			Contract: log.Address,
+			Operator: ethcmn.BytesToAddress(log.Topics[1].Bytes()),
+			From:     ethcmn.BytesToAddress(log.Topics[2].Bytes()),
+			To:       ethcmn.BytesToAddress(log.Topics[3].Bytes()),
+			Log:      log,
+		}
+
+		switch log.Topics[0] {
+		case types.ERC1155TransferSingleTopic:
+			transfer.Name = types.ERC1155EventTransferSingle
+		case types.ERC1155TransferBatchTopic:
+			transfer.Name = types.ERC1155EventTransferBatch
+		default:
+			continue
+		}
+
+		if transfer.IDs, transfer.Values, err = unpackERC1155Transfer(transfer.Name, log.Data); err != nil {
+			return nil, fmt.Errorf("failed. decode erc1155 event %s in tx %s error: %s", transfer.Name,
+				log.TxHash.Hex(), err)
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	return
+}
+
+// ParseERC1155Events decodes the erc1155 events TransferSingle and TransferBatch from the logs in the result data of
+// the evm tx
+func (c erc1155Client) ParseERC1155Events(resp sdk.TxResponse) ([]types.ERC1155Transfer, error) {
+	resultData, err := c.ec.GetResultData(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DecodeERC1155Logs(resultData.Logs)
+}
+
+// unpackERC1155Transfer unpacks the ids and the values from the data of the event TransferSingle or TransferBatch
+func unpackERC1155Transfer(event string, data []byte) (ids, values []*big.Int, err error) {
+	outputs, err := erc1155PayloadBuilder.Unpack(event, data)
+	if err != nil {
+		return
+	}
+
+	if event == types.ERC1155EventTransferSingle {
+		id, ok1 := outputs[0].(*big.Int)
+		value, ok2 := outputs[1].(*big.Int)
+		if !ok1 || !ok2 {
+			return nil, nil, errors.New("unexpected id or value")
+		}
+		return []*big.Int{id}, []*big.Int{value}, nil
+	}
+
+	ids, ok1 := outputs[0].([]*big.Int)
+	values, ok2 := outputs[1].([]*big.Int)
+	if !ok1 || !ok2 || len(ids) != len(values) {
+		return nil, nil, errors.New("unexpected ids or values")
+	}
+
+	return
+}
@@ -0,0 +1,182 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/auth"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErc1155Client_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	owners := []ethcmn.Address{ethcmn.HexToAddress(recAddrEth), contractAddr}
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	mockSimulate(t, &mockCli, buildRets(t, types.ERC1155ABI, []struct {
+		method  string
+		params  []interface{}
+		outputs []interface{}
+	}{
+		{"balanceOfBatch", []interface{}{owners, ids}, []interface{}{[]*big.Int{big.NewInt(10), big.NewInt(5)}}},
+		{"uri", []interface{}{big.NewInt(1)}, []interface{}{"https://token/{id}.json"}},
+		{"supportsInterface", []interface{}{types.ERC165InterfaceID}, []interface{}{true}},
+		{"supportsInterface", []interface{}{types.InvalidInterfaceID}, []interface{}{false}},
+		{"supportsInterface", []interface{}{types.ERC1155InterfaceID}, []interface{}{true}},
+		{"supportsInterface", []interface{}{types.ERC721InterfaceID}, []interface{}{false}},
+	}))
+	erc1155 := mockCli.Evm().ERC1155()
+
+	balances, err := erc1155.BalanceOfBatch(contractAddr, owners, ids)
+	require.NoError(t, err)
+	require.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(5)}, balances)
+
+	uri, err := erc1155.URI(contractAddr, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, "https://token/0000000000000000000000000000000000000000000000000000000000000001.json", uri)
+
+	isERC1155, err := erc1155.IsERC1155(contractAddr)
+	require.NoError(t, err)
+	require.True(t, isERC1155)
+
+	isERC721, err := mockCli.Evm().ERC721().IsERC721(contractAddr)
+	require.NoError(t, err)
+	require.False(t, isERC721)
+
+	supported, err := erc1155.SupportsInterface(contractAddr, types.ERC721InterfaceID)
+	require.NoError(t, err)
+	require.False(t, supported)
+
+	// mismatched lengths
+	_, err = erc1155.BalanceOfBatch(contractAddr, owners, ids[:1])
+	require.Error(t, err)
+}
+
+func TestErc1155Client_SafeBatchTransferFrom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+	mockSimulate(t, &mockCli, nil)
+
+	priv, err := ethcrypto.HexToECDSA(privKeyHex)
+	require.NoError(t, err)
+	opts := &types.TransactOpts{PrivKey: priv}
+	from := ethcrypto.PubkeyToAddress(priv.PublicKey)
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	to := ethcmn.HexToAddress(recAddrEth)
+	ids, amounts := []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}
+
+	var broadcasted []byte
+	mockCli.EXPECT().GetConfig().Return(config).AnyTimes()
+	mockCli.EXPECT().Broadcast(gomock.Any(), gosdktypes.BroadcastBlock).DoAndReturn(
+		func(txBytes []byte, _ string) (sdk.TxResponse, error) {
+			broadcasted = txBytes
+			return sdk.TxResponse{Height: 10}, nil
+		})
+
+	_, err = mockCli.Evm().ERC1155().SafeBatchTransferFrom(opts, contractAddr, from, to, ids, amounts, nil)
+	require.NoError(t, err)
+
+	payloadBuilder, err := utils.NewPayloadBuilder("", types.ERC1155ABI)
+	require.NoError(t, err)
+	expectedPayload, err := payloadBuilder.Build("safeBatchTransferFrom", from, to, ids, amounts, []byte{})
+	require.NoError(t, err)
+	var ethMsg evmtypes.MsgEthereumTx
+	require.NoError(t, rlp.DecodeBytes(broadcasted, &ethMsg))
+	require.Equal(t, contractAddr, *ethMsg.Data.Recipient)
+	require.True(t, bytes.Equal(expectedPayload, ethMsg.Data.Payload))
+
+	// mismatched lengths
+	_, err = mockCli.Evm().ERC1155().SafeBatchTransferFrom(opts, contractAddr, from, to, ids, amounts[:1], nil)
+	require.Error(t, err)
+}
+
+func TestErc1155Client_DecodeERC1155Logs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	erc1155ABI, err := abi.JSON(strings.NewReader(types.ERC1155ABI))
+	require.NoError(t, err)
+	singleData, err := erc1155ABI.Events["TransferSingle"].Inputs.NonIndexed().Pack(big.NewInt(1), big.NewInt(10))
+	require.NoError(t, err)
+	batchData, err := erc1155ABI.Events["TransferBatch"].Inputs.NonIndexed().Pack(
+		[]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+	require.NoError(t, err)
+
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	operator, from, to := contractAddr, ethcmn.HexToAddress(recAddrEth), ethcmn.HexToAddress("0x01")
+	logs := []*ethcore.Log{
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC1155TransferSingleTopic, operator.Hash(), from.Hash(), to.Hash()},
+			Data:    singleData,
+		},
+		// erc721 Transfer
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC721TransferTopic, from.Hash(), to.Hash(), ethcmn.BigToHash(big.NewInt(7))},
+		},
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC1155TransferBatchTopic, operator.Hash(), from.Hash(), to.Hash()},
+			Data:    batchData,
+		},
+	}
+
+	transfers, err := mockCli.Evm().ERC1155().DecodeERC1155Logs(logs)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transfers))
+	require.Equal(t, types.ERC1155EventTransferSingle, transfers[0].Name)
+	require.Equal(t, operator, transfers[0].Operator)
+	require.Equal(t, from, transfers[0].From)
+	require.Equal(t, to, transfers[0].To)
+	require.Equal(t, []*big.Int{big.NewInt(1)}, transfers[0].IDs)
+	require.Equal(t, []*big.Int{big.NewInt(10)}, transfers[0].Values)
+	require.Equal(t, types.ERC1155EventTransferBatch, transfers[1].Name)
+	require.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, transfers[1].IDs)
+	require.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(20)}, transfers[1].Values)
+	require.Equal(t, logs[2], transfers[1].Log)
+
+	// from the result data of the tx
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Logs: logs})
+	require.NoError(t, err)
+	transfers, err = mockCli.Evm().ERC1155().ParseERC1155Events(sdk.TxResponse{Data: hex.EncodeToString(data)})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transfers))
+
+	// bad data
+	logs[2].Data = batchData[:64]
+	_, err = mockCli.Evm().ERC1155().DecodeERC1155Logs(logs)
+	require.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package evm
+
+import (
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+var erc20PayloadBuilder = mustNewPayloadBuilder(types.ERC20ABI)
+
+type erc20Client struct {
+	ec evmClient
+}
+
+// ERC20 returns the client with exposed.ERC20's behaviour
+func (ec evmClient) ERC20() exposed.ERC20 {
+	return erc20Client{ec}
+}
+
+// Name returns the name of the erc20 token
+func (c erc20Client) Name(contractAddr ethcmn.Address) (string, error) {
+	outputs, err := c.ec.callContract(erc20PayloadBuilder, contractAddr, "name")
+	if err != nil {
+		return "", err
+	}
+
+	name, ok := outputs[0].(string)
+	if !ok {
+		return "", fmt.Errorf("failed. unexpected output %v of method name", outputs[0])
+	}
+
+	return name, nil
+}
+
+// Symbol returns the symbol of the erc20 token
+func (c erc20Client) Symbol(contractAddr ethcmn.Address) (string, error) {
+	outputs, err := c.ec.callContract(erc20PayloadBuilder, contractAddr, "symbol")
+	if err != nil {
+		return "", err
+	}
+
+	symbol, ok := outputs[0].(string)
+	if !ok {
+		return "", fmt.Errorf("failed. unexpected output %v of method symbol", outputs[0])
+	}
+
+	return symbol, nil
+}
+
+// Decimals returns the decimals of the erc20 token
+func (c erc20Client) Decimals(contractAddr ethcmn.Address) (uint8, error) {
+	outputs, err := c.ec.callContract(erc20PayloadBuilder, contractAddr, "decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, ok := outputs[0].(uint8)
+	if !ok {
+		return 0, fmt.Errorf("failed. unexpected output %v of method decimals", outputs[0])
+	}
+
+	return decimals, nil
+}
+
+// TotalSupply returns the total supply of the erc20 token
+func (c erc20Client) TotalSupply(contractAddr ethcmn.Address) (types.TokenAmount, error) {
+	return c.queryAmount(contractAddr, "totalSupply")
+}
+
+// BalanceOf returns the balance of the owner in the erc20 token
+func (c erc20Client) BalanceOf(contractAddr, owner ethcmn.Address) (types.TokenAmount, error) {
+	return c.queryAmount(contractAddr, "balanceOf", owner)
+}
+
+// Allowance returns the amount of the erc20 token that the spender is allowed to transfer from the owner
+func (c erc20Client) Allowance(contractAddr, owner, spender ethcmn.Address) (types.TokenAmount, error) {
+	return c.queryAmount(contractAddr, "allowance", owner, spender)
+}
+
+// Transfer transfers the amount of the erc20 token to the receiver
+func (c erc20Client) Transfer(opts *types.TransactOpts, contractAddr, to ethcmn.Address, amountStr string) (
+	sdk.TxResponse, error) {
+	return c.sendAmount(opts, contractAddr, amountStr, "transfer", to)
+}
+
+// Approve allows the spender to transfer the amount of the erc20 token from the sender
+func (c erc20Client) Approve(opts *types.TransactOpts, contractAddr, spender ethcmn.Address, amountStr string) (
+	sdk.TxResponse, error) {
+	return c.sendAmount(opts, contractAddr, amountStr, "approve", spender)
+}
+
+// TransferFrom transfers the amount of the erc20 token from the owner to the receiver with the allowance of the sender
+func (c erc20Client) TransferFrom(opts *types.TransactOpts, contractAddr, from, to ethcmn.Address, amountStr string) (
+	sdk.TxResponse, error) {
+	return c.sendAmount(opts, contractAddr, amountStr, "transferFrom", from, to)
+}
+
+// DecodeERC20Logs decodes the erc20 events Transfer and Approval from the logs, and skips the other logs
+// Note: the event Transfer of erc721 with the indexed token id is skipped as well
+func (erc20Client) DecodeERC20Logs(logs []*ethcore.Log) (events []types.ERC20Event, err error) {
+	for _, log := range logs {
+		if log == nil || len(log.Topics) != 3 {
+			continue
+		}
+
+		var name string
+		switch log.Topics[0] {
+		case types.ERC20TransferTopic:
+			name = types.ERC20EventTransfer
+		case types.ERC20ApprovalTopic:
+			name = types.ERC20EventApproval
+		default:
+			continue
+		}
+
+		if len(log.Data) != ethcmn.HashLength {
+			return nil, fmt.Errorf("failed. invalid data length %d of erc20 event %s in tx %s", len(log.Data), name,
+				log.TxHash.Hex())
+		}
+
+		events = append(events, types.ERC20Event{
+			Name:     name,
+			Contract: log.Address,
+			From:     ethcmn.BytesToAddress(log.Topics[1].Bytes()),
+			To:       ethcmn.BytesToAddress(log.Topics[2].Bytes()),
+			Value:    new(big.Int).SetBytes(log.Data),
+			Log:      log,
+		})
+	}
+
+	return
+}
+
+// ParseERC20Events decodes the erc20 events Transfer and Approval from the logs in the result data of the evm tx
+func (c erc20Client) ParseERC20Events(resp sdk.TxResponse) ([]types.ERC20Event, error) {
+	resultData, err := c.ec.GetResultData(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DecodeERC20Logs(resultData.Logs)
+}
+
+func (c erc20Client) queryAmount(contractAddr ethcmn.Address, method string, params ...interface{}) (
+	ta types.TokenAmount, err error) {
+	decimals, err := c.Decimals(contractAddr)
+	if err != nil {
+		return
+	}
+
+	outputs, err := c.ec.callContract(erc20PayloadBuilder, contractAddr, method, params...)
+	if err != nil {
+		return
+	}
+
+	amount, ok := outputs[0].(*big.Int)
+	if !ok {
+		return ta, fmt.Errorf("failed. unexpected output %v of method %s", outputs[0], method)
+	}
+
+	return types.NewTokenAmount(amount, decimals), nil
+}
+
+func (c erc20Client) sendAmount(opts *types.TransactOpts, contractAddr ethcmn.Address, amountStr, method string,
+	params ...interface{}) (resp sdk.TxResponse, err error) {
+	decimals, err := c.Decimals(contractAddr)
+	if err != nil {
+		return
+	}
+
+	amount, err := types.ParseTokenAmount(amountStr, decimals)
+	if err != nil {
+		return
+	}
+
+	payload, err := erc20PayloadBuilder.Build(method, append(params, amount.Amount)...)
+	if err != nil {
+		return resp, fmt.Errorf("failed. build payload of method %s error: %s", method, err)
+	}
+
+	return c.ec.sendContractTx(opts, contractAddr, payload)
+}
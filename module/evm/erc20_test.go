@@ -0,0 +1,290 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/auth"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const erc20ContractAddr = "0x9aD84c8630E0282F78e5479B46E64E17779e3Cfb"
+
+// mockSimulate answers the calls through "app/simulate" with the return data of the whole payload or its method
+// selector in hex, and treats the accounts as nonexistent
+func mockSimulate(t *testing.T, mockCli *mocks.MockClient, rets map[string][]byte) {
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(cdc).AnyTimes()
+	mockCli.EXPECT().QueryWithHeight(gomock.Any(), gomock.Any(), int64(0)).DoAndReturn(
+		func(path string, txBytes []byte, _ int64) ([]byte, int64, error) {
+			if !strings.HasPrefix(path, "app/simulate/") {
+				return nil, 0, errors.New("account does not exist")
+			}
+			return buildSimulationResponse(t, cdc, txBytes, rets), 0, nil
+		}).AnyTimes()
+}
+
+func buildSimulationResponse(t *testing.T, cdc *codec.Codec, txBytes []byte, rets map[string][]byte) []byte {
+	var msg evmtypes.MsgEthereumTx
+	require.NoError(t, rlp.DecodeBytes(txBytes, &msg))
+
+	ret, ok := rets[hex.EncodeToString(msg.Data.Payload)]
+	if !ok {
+		ret = rets[hex.EncodeToString(msg.Data.Payload[:4])]
+	}
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Ret: ret})
+	require.NoError(t, err)
+
+	return cdc.MustMarshalBinaryBare(sdk.SimulationResponse{
+		GasInfo: sdk.GasInfo{GasUsed: 30000},
+		Result:  &sdk.Result{Data: data},
+	})
+}
+
+func buildERC20Rets(t *testing.T) map[string][]byte {
+	payloadBuilder, err := utils.NewPayloadBuilder("", types.ERC20ABI)
+	require.NoError(t, err)
+	erc20ABI, err := abi.JSON(strings.NewReader(types.ERC20ABI))
+	require.NoError(t, err)
+
+	rets := make(map[string][]byte)
+	for method, outputs := range map[string][]interface{}{
+		"name":        {"Test Token"},
+		"symbol":      {"TT"},
+		"decimals":    {uint8(6)},
+		"totalSupply": {big.NewInt(1000000000)},
+		"balanceOf":   {big.NewInt(1500000)},
+		"allowance":   {big.NewInt(1)},
+	} {
+		selector, err := payloadBuilder.Build(method, zeroParams(method)...)
+		require.NoError(t, err)
+		ret, err := erc20ABI.Methods[method].Outputs.Pack(outputs...)
+		require.NoError(t, err)
+		rets[hex.EncodeToString(selector[:4])] = ret
+	}
+
+	return rets
+}
+
+func zeroParams(method string) []interface{} {
+	switch method {
+	case "balanceOf":
+		return []interface{}{ethcmn.Address{}}
+	case "allowance":
+		return []interface{}{ethcmn.Address{}, ethcmn.Address{}}
+	}
+
+	return nil
+}
+
+func TestErc20Client_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+	mockSimulate(t, &mockCli, buildERC20Rets(t))
+
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	owner := ethcmn.HexToAddress(recAddrEth)
+	erc20 := mockCli.Evm().ERC20()
+
+	name, err := erc20.Name(contractAddr)
+	require.NoError(t, err)
+	require.Equal(t, "Test Token", name)
+
+	symbol, err := erc20.Symbol(contractAddr)
+	require.NoError(t, err)
+	require.Equal(t, "TT", symbol)
+
+	decimals, err := erc20.Decimals(contractAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint8(6), decimals)
+
+	totalSupply, err := erc20.TotalSupply(contractAddr)
+	require.NoError(t, err)
+	require.Equal(t, "1000", totalSupply.String())
+
+	balance, err := erc20.BalanceOf(contractAddr, owner)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1500000), balance.Amount)
+	require.Equal(t, "1.5", balance.String())
+
+	allowance, err := erc20.Allowance(contractAddr, owner, owner)
+	require.NoError(t, err)
+	require.Equal(t, "0.000001", allowance.String())
+}
+
+func TestErc20Client_Transfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+	mockSimulate(t, &mockCli, buildERC20Rets(t))
+
+	priv, err := ethcrypto.HexToECDSA(privKeyHex)
+	require.NoError(t, err)
+	opts := &types.TransactOpts{PrivKey: priv}
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	to := ethcmn.HexToAddress(recAddrEth)
+
+	var broadcasted []byte
+	mockCli.EXPECT().GetConfig().Return(config).AnyTimes()
+	mockCli.EXPECT().Broadcast(gomock.Any(), gosdktypes.BroadcastBlock).DoAndReturn(
+		func(txBytes []byte, _ string) (sdk.TxResponse, error) {
+			broadcasted = txBytes
+			return sdk.TxResponse{Height: 10}, nil
+		}).Times(4)
+
+	checkTx := func(expectedPayload []byte) {
+		var ethMsg evmtypes.MsgEthereumTx
+		require.NoError(t, rlp.DecodeBytes(broadcasted, &ethMsg))
+		require.Equal(t, contractAddr, *ethMsg.Data.Recipient)
+		require.Zero(t, ethMsg.Data.AccountNonce)
+		require.Equal(t, uint64(31000), ethMsg.Data.GasLimit)
+		require.Zero(t, ethMsg.Data.Amount.Sign())
+		require.True(t, bytes.Equal(expectedPayload, ethMsg.Data.Payload))
+	}
+
+	payloadBuilder, err := utils.NewPayloadBuilder("", types.ERC20ABI)
+	require.NoError(t, err)
+
+	_, err = mockCli.Evm().ERC20().Transfer(opts, contractAddr, to, "1.5")
+	require.NoError(t, err)
+	expectedPayload, err := payloadBuilder.Build("transfer", to, big.NewInt(1500000))
+	require.NoError(t, err)
+	checkTx(expectedPayload)
+
+	_, err = mockCli.Evm().ERC20().Approve(opts, contractAddr, to, "2")
+	require.NoError(t, err)
+	expectedPayload, err = payloadBuilder.Build("approve", to, big.NewInt(2000000))
+	require.NoError(t, err)
+	checkTx(expectedPayload)
+
+	_, err = mockCli.Evm().ERC20().TransferFrom(opts, contractAddr, to, contractAddr, ".000001")
+	require.NoError(t, err)
+	expectedPayload, err = payloadBuilder.Build("transferFrom", to, contractAddr, big.NewInt(1))
+	require.NoError(t, err)
+	checkTx(expectedPayload)
+
+	// nonce, gas limit and gas price overridden
+	nonce := uint64(5)
+	_, err = mockCli.Evm().ERC20().Transfer(&types.TransactOpts{PrivKey: priv, Nonce: &nonce, GasLimit: 50000,
+		GasPrice: big.NewInt(2)}, contractAddr, to, "1")
+	require.NoError(t, err)
+	var ethMsg evmtypes.MsgEthereumTx
+	require.NoError(t, rlp.DecodeBytes(broadcasted, &ethMsg))
+	require.Equal(t, nonce, ethMsg.Data.AccountNonce)
+	require.Equal(t, uint64(50000), ethMsg.Data.GasLimit)
+	require.Equal(t, int64(2), ethMsg.Data.Price.Int64())
+
+	// more digits than the decimals
+	_, err = mockCli.Evm().ERC20().Transfer(opts, contractAddr, to, "0.0000001")
+	require.Error(t, err)
+
+	_, err = mockCli.Evm().ERC20().Transfer(opts, contractAddr, to, "1,5")
+	require.Error(t, err)
+}
+
+func TestErc20Client_DecodeERC20Logs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	from, to := ethcmn.HexToAddress(recAddrEth), ethcmn.HexToAddress("0x01")
+	logs := []*ethcore.Log{
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC20TransferTopic, from.Hash(), to.Hash()},
+			Data:    ethcmn.BigToHash(big.NewInt(1024)).Bytes(),
+		},
+		// erc721 Transfer
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC20TransferTopic, from.Hash(), to.Hash(), ethcmn.BigToHash(big.NewInt(1))},
+		},
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC20ApprovalTopic, from.Hash(), to.Hash()},
+			Data:    ethcmn.BigToHash(big.NewInt(1)).Bytes(),
+		},
+	}
+
+	events, err := mockCli.Evm().ERC20().DecodeERC20Logs(logs)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(events))
+	require.Equal(t, types.ERC20EventTransfer, events[0].Name)
+	require.Equal(t, contractAddr, events[0].Contract)
+	require.Equal(t, from, events[0].From)
+	require.Equal(t, to, events[0].To)
+	require.Equal(t, big.NewInt(1024), events[0].Value)
+	require.Equal(t, types.ERC20EventApproval, events[1].Name)
+	require.Equal(t, logs[2], events[1].Log)
+
+	// from the result data of the tx
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Logs: logs})
+	require.NoError(t, err)
+	events, err = mockCli.Evm().ERC20().ParseERC20Events(sdk.TxResponse{Data: hex.EncodeToString(data)})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(events))
+
+	// bad data
+	logs[0].Data = []byte{0x01}
+	_, err = mockCli.Evm().ERC20().DecodeERC20Logs(logs)
+	require.Error(t, err)
+}
+
+func TestTokenAmount(t *testing.T) {
+	for _, tc := range []struct {
+		amountStr string
+		decimals  uint8
+		expected  *big.Int
+		str       string
+	}{
+		{"1.5", 18, new(big.Int).Mul(big.NewInt(15), big.NewInt(1e17)), "1.5"},
+		{"100", 0, big.NewInt(100), "100"},
+		{"0.01", 2, big.NewInt(1), "0.01"},
+		{".5", 1, big.NewInt(5), "0.5"},
+		{"3.", 2, big.NewInt(300), "3"},
+		{"1.50", 1, big.NewInt(15), "1.5"},
+	} {
+		ta, err := types.ParseTokenAmount(tc.amountStr, tc.decimals)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, ta.Amount)
+		require.Equal(t, tc.str, ta.String())
+	}
+
+	require.Equal(t, "-0.25", types.NewTokenAmount(big.NewInt(-25), 2).String())
+
+	for _, amountStr := range []string{"", ".", "1.23", "-1", "1e3", "0x10"} {
+		_, err := types.ParseTokenAmount(amountStr, 1)
+		require.Error(t, err, amountStr)
+	}
+}
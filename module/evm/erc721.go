@@ -0,0 +1,136 @@
+package evm
+
+import (
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+var erc721PayloadBuilder = mustNewPayloadBuilder(types.ERC721ABI)
+
+type erc721Client struct {
+	ec evmClient
+}
+
+// ERC721 returns the client with exposed.ERC721's behaviour
+func (ec evmClient) ERC721() exposed.ERC721 {
+	return erc721Client{ec}
+}
+
+// OwnerOf returns the owner of the erc721 token
+func (c erc721Client) OwnerOf(contractAddr ethcmn.Address, tokenID *big.Int) (ethcmn.Address, error) {
+	outputs, err := c.ec.callContract(erc721PayloadBuilder, contractAddr, "ownerOf", tokenID)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+
+	owner, ok := outputs[0].(ethcmn.Address)
+	if !ok {
+		return ethcmn.Address{}, fmt.Errorf("failed. unexpected output %v of method ownerOf", outputs[0])
+	}
+
+	return owner, nil
+}
+
+// TokenURI returns the metadata uri of the erc721 token
+func (c erc721Client) TokenURI(contractAddr ethcmn.Address, tokenID *big.Int) (string, error) {
+	outputs, err := c.ec.callContract(erc721PayloadBuilder, contractAddr, "tokenURI", tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	uri, ok := outputs[0].(string)
+	if !ok {
+		return "", fmt.Errorf("failed. unexpected output %v of method tokenURI", outputs[0])
+	}
+
+	return uri, nil
+}
+
+// BalanceOf returns the number of the erc721 tokens owned by the owner
+func (c erc721Client) BalanceOf(contractAddr, owner ethcmn.Address) (*big.Int, error) {
+	outputs, err := c.ec.callContract(erc721PayloadBuilder, contractAddr, "balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, ok := outputs[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("failed. unexpected output %v of method balanceOf", outputs[0])
+	}
+
+	return balance, nil
+}
+
+// SupportsInterface returns whether the contract supports the interface by ERC-165
+func (c erc721Client) SupportsInterface(contractAddr ethcmn.Address, interfaceID [4]byte) (bool, error) {
+	return c.ec.supportsInterface(contractAddr, interfaceID)
+}
+
+// IsERC721 detects whether the contract is an erc721 one by ERC-165
+func (c erc721Client) IsERC721(contractAddr ethcmn.Address) (bool, error) {
+	return c.ec.detectInterface(contractAddr, types.ERC721InterfaceID)
+}
+
+// SafeTransferFrom transfers the erc721 token from the owner to the receiver, which must accept it if a contract
+func (c erc721Client) SafeTransferFrom(opts *types.TransactOpts, contractAddr, from, to ethcmn.Address,
+	tokenID *big.Int, data []byte) (sdk.TxResponse, error) {
+	return c.send(opts, contractAddr, "safeTransferFrom", from, to, tokenID, data)
+}
+
+// Approve allows the receiver to transfer the erc721 token from the owner
+func (c erc721Client) Approve(opts *types.TransactOpts, contractAddr, to ethcmn.Address, tokenID *big.Int) (
+	sdk.TxResponse, error) {
+	return c.send(opts, contractAddr, "approve", to, tokenID)
+}
+
+// SetApprovalForAll allows or disallows the operator to transfer all the erc721 tokens of the sender
+func (c erc721Client) SetApprovalForAll(opts *types.TransactOpts, contractAddr, operator ethcmn.Address,
+	approved bool) (sdk.TxResponse, error) {
+	return c.send(opts, contractAddr, "setApprovalForAll", operator, approved)
+}
+
+// DecodeERC721Logs decodes the erc721 events Transfer from the logs, and skips the other logs
+// Note: the event Transfer of erc20 without the indexed value is skipped as well
+func (erc721Client) DecodeERC721Logs(logs []*ethcore.Log) (transfers []types.ERC721Transfer, err error) {
+	for _, log := range logs {
+		if log == nil || len(log.Topics) != 4 || log.Topics[0] != types.ERC721TransferTopic {
+			continue
+		}
+
+		transfers = append(transfers, types.ERC721Transfer{
+			Contract: log.Address,
+			From:     ethcmn.BytesToAddress(log.Topics[1].Bytes()),
+			To:       ethcmn.BytesToAddress(log.Topics[2].Bytes()),
+			TokenID:  log.Topics[3].Big(),
+			Log:      log,
+		})
+	}
+
+	return
+}
+
+// ParseERC721Events decodes the erc721 events Transfer from the logs in the result data of the evm tx
+func (c erc721Client) ParseERC721Events(resp sdk.TxResponse) ([]types.ERC721Transfer, error) {
+	resultData, err := c.ec.GetResultData(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DecodeERC721Logs(resultData.Logs)
+}
+
+func (c erc721Client) send(opts *types.TransactOpts, contractAddr ethcmn.Address, method string,
+	params ...interface{}) (resp sdk.TxResponse, err error) {
+	payload, err := erc721PayloadBuilder.Build(method, params...)
+	if err != nil {
+		return resp, fmt.Errorf("failed. build payload of method %s error: %s", method, err)
+	}
+
+	return c.ec.sendContractTx(opts, contractAddr, payload)
+}
@@ -0,0 +1,237 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/auth"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+// buildRets builds the return data of the calls keyed by the whole payload in hex
+func buildRets(t *testing.T, abiJSON string, calls []struct {
+	method  string
+	params  []interface{}
+	outputs []interface{}
+}) map[string][]byte {
+	payloadBuilder, err := utils.NewPayloadBuilder("", abiJSON)
+	require.NoError(t, err)
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+
+	rets := make(map[string][]byte)
+	for _, call := range calls {
+		payload, err := payloadBuilder.Build(call.method, call.params...)
+		require.NoError(t, err)
+		ret, err := contractABI.Methods[call.method].Outputs.Pack(call.outputs...)
+		require.NoError(t, err)
+		rets[hex.EncodeToString(payload)] = ret
+	}
+
+	return rets
+}
+
+func TestErc721Client_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	owner := ethcmn.HexToAddress(recAddrEth)
+	tokenID := big.NewInt(7)
+	mockSimulate(t, &mockCli, buildRets(t, types.ERC721ABI, []struct {
+		method  string
+		params  []interface{}
+		outputs []interface{}
+	}{
+		{"ownerOf", []interface{}{tokenID}, []interface{}{owner}},
+		{"tokenURI", []interface{}{tokenID}, []interface{}{"ipfs://token/7"}},
+		{"balanceOf", []interface{}{owner}, []interface{}{big.NewInt(2)}},
+		{"supportsInterface", []interface{}{types.ERC165InterfaceID}, []interface{}{true}},
+		{"supportsInterface", []interface{}{types.InvalidInterfaceID}, []interface{}{false}},
+		{"supportsInterface", []interface{}{types.ERC721InterfaceID}, []interface{}{true}},
+		{"supportsInterface", []interface{}{types.ERC1155InterfaceID}, []interface{}{false}},
+	}))
+	erc721 := mockCli.Evm().ERC721()
+
+	gotOwner, err := erc721.OwnerOf(contractAddr, tokenID)
+	require.NoError(t, err)
+	require.Equal(t, owner, gotOwner)
+
+	uri, err := erc721.TokenURI(contractAddr, tokenID)
+	require.NoError(t, err)
+	require.Equal(t, "ipfs://token/7", uri)
+
+	balance, err := erc721.BalanceOf(contractAddr, owner)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), balance)
+
+	supported, err := erc721.SupportsInterface(contractAddr, types.ERC721InterfaceID)
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	isERC721, err := erc721.IsERC721(contractAddr)
+	require.NoError(t, err)
+	require.True(t, isERC721)
+
+	isERC1155, err := mockCli.Evm().ERC1155().IsERC1155(contractAddr)
+	require.NoError(t, err)
+	require.False(t, isERC1155)
+
+	// no return data of the unknown token
+	_, err = erc721.OwnerOf(contractAddr, big.NewInt(8))
+	require.Error(t, err)
+}
+
+func TestErc721Client_DetectInterface(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	// a contract answering true for any interface isn't ERC-165
+	mockSimulate(t, &mockCli, buildRets(t, types.ERC721ABI, []struct {
+		method  string
+		params  []interface{}
+		outputs []interface{}
+	}{
+		{"supportsInterface", []interface{}{types.ERC165InterfaceID}, []interface{}{true}},
+		{"supportsInterface", []interface{}{types.InvalidInterfaceID}, []interface{}{true}},
+		{"supportsInterface", []interface{}{types.ERC721InterfaceID}, []interface{}{true}},
+	}))
+
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	isERC721, err := mockCli.Evm().ERC721().IsERC721(contractAddr)
+	require.NoError(t, err)
+	require.False(t, isERC721)
+
+	supported, err := mockCli.Evm().ERC721().SupportsInterface(contractAddr, types.ERC721InterfaceID)
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	// no return data
+	_, err = mockCli.Evm().ERC721().SupportsInterface(contractAddr, types.ERC1155InterfaceID)
+	require.Error(t, err)
+}
+
+func TestErc721Client_Tx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+	mockSimulate(t, &mockCli, nil)
+
+	priv, err := ethcrypto.HexToECDSA(privKeyHex)
+	require.NoError(t, err)
+	opts := &types.TransactOpts{PrivKey: priv}
+	from := ethcrypto.PubkeyToAddress(priv.PublicKey)
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	to := ethcmn.HexToAddress(recAddrEth)
+
+	var broadcasted []byte
+	mockCli.EXPECT().GetConfig().Return(config).AnyTimes()
+	mockCli.EXPECT().Broadcast(gomock.Any(), gosdktypes.BroadcastBlock).DoAndReturn(
+		func(txBytes []byte, _ string) (sdk.TxResponse, error) {
+			broadcasted = txBytes
+			return sdk.TxResponse{Height: 10}, nil
+		}).Times(3)
+
+	checkPayload := func(expectedPayload []byte) {
+		var ethMsg evmtypes.MsgEthereumTx
+		require.NoError(t, rlp.DecodeBytes(broadcasted, &ethMsg))
+		require.Equal(t, contractAddr, *ethMsg.Data.Recipient)
+		require.True(t, bytes.Equal(expectedPayload, ethMsg.Data.Payload))
+	}
+
+	payloadBuilder, err := utils.NewPayloadBuilder("", types.ERC721ABI)
+	require.NoError(t, err)
+
+	_, err = mockCli.Evm().ERC721().SafeTransferFrom(opts, contractAddr, from, to, big.NewInt(7), []byte("memo"))
+	require.NoError(t, err)
+	expectedPayload, err := payloadBuilder.Build("safeTransferFrom", from, to, big.NewInt(7), []byte("memo"))
+	require.NoError(t, err)
+	// safeTransferFrom(address,address,uint256,bytes)
+	require.Equal(t, "b88d4fde", hex.EncodeToString(expectedPayload[:4]))
+	checkPayload(expectedPayload)
+
+	_, err = mockCli.Evm().ERC721().Approve(opts, contractAddr, to, big.NewInt(7))
+	require.NoError(t, err)
+	expectedPayload, err = payloadBuilder.Build("approve", to, big.NewInt(7))
+	require.NoError(t, err)
+	checkPayload(expectedPayload)
+
+	_, err = mockCli.Evm().ERC721().SetApprovalForAll(opts, contractAddr, to, true)
+	require.NoError(t, err)
+	expectedPayload, err = payloadBuilder.Build("setApprovalForAll", to, true)
+	require.NoError(t, err)
+	checkPayload(expectedPayload)
+}
+
+func TestErc721Client_DecodeERC721Logs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	contractAddr := ethcmn.HexToAddress(erc20ContractAddr)
+	from, to := ethcmn.HexToAddress(recAddrEth), ethcmn.HexToAddress("0x01")
+	logs := []*ethcore.Log{
+		// erc20 Transfer
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC721TransferTopic, from.Hash(), to.Hash()},
+			Data:    ethcmn.BigToHash(big.NewInt(1024)).Bytes(),
+		},
+		{
+			Address: contractAddr,
+			Topics:  []ethcmn.Hash{types.ERC721TransferTopic, from.Hash(), to.Hash(), ethcmn.BigToHash(big.NewInt(7))},
+		},
+	}
+
+	transfers, err := mockCli.Evm().ERC721().DecodeERC721Logs(logs)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(transfers))
+	require.Equal(t, contractAddr, transfers[0].Contract)
+	require.Equal(t, from, transfers[0].From)
+	require.Equal(t, to, transfers[0].To)
+	require.Equal(t, big.NewInt(7), transfers[0].TokenID)
+	require.Equal(t, logs[1], transfers[0].Log)
+
+	// from the result data of the tx
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Logs: logs})
+	require.NoError(t, err)
+	transfers, err = mockCli.Evm().ERC721().ParseERC721Events(sdk.TxResponse{Data: hex.EncodeToString(data)})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(transfers))
+
+	_, err = mockCli.Evm().ERC721().ParseERC721Events(sdk.TxResponse{})
+	require.Error(t, err)
+}
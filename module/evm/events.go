@@ -0,0 +1,78 @@
+package evm
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+)
+
+// ParseEthereumTxEvents decodes all the ethereum events
+func (evmClient) ParseEthereumTxEvents(events sdk.StringEvents) (ethTxs []types.EthereumTxEvent, err error) {
+	for _, attrs := range utils.ParseEventAttributes(events, evmtypes.EventTypeEthereumTx) {
+		var ethTx types.EthereumTxEvent
+		amountStr := attrs[sdk.AttributeKeyAmount]
+		amount, ok := new(big.Int).SetString(amountStr, 10)
+		if !ok {
+			return nil, fmt.Errorf("failed. parse amount [%s] of ethereum event", amountStr)
+		}
+		ethTx.Amount = amount
+
+		if recipientStr, ok := attrs[evmtypes.AttributeKeyRecipient]; ok {
+			if !ethcmn.IsHexAddress(recipientStr) {
+				return nil, fmt.Errorf("failed. invalid recipient [%s] of ethereum event", recipientStr)
+			}
+			recipient := ethcmn.HexToAddress(recipientStr)
+			ethTx.Recipient = &recipient
+		}
+
+		ethTxs = append(ethTxs, ethTx)
+	}
+
+	return
+}
+
+// DecodeResultData decodes the result data of an evm tx, which is the Data of sdk.TxResponse in bytes or the
+// TxResult.Data of ResultTx
+func (evmClient) DecodeResultData(data []byte) (resultData types.ResultData, err error) {
+	if len(data) == 0 {
+		return resultData, errors.New("failed. empty result data of the evm tx")
+	}
+
+	resultData, err = evmtypes.DecodeResultData(data)
+	if err != nil {
+		return resultData, fmt.Errorf("failed. decode result data of the evm tx error: %s", err)
+	}
+
+	return
+}
+
+// GetResultData gets the result data of the evm tx executed in block, including logs, bloom and return data
+func (ec evmClient) GetResultData(resp sdk.TxResponse) (resultData types.ResultData, err error) {
+	data, err := hex.DecodeString(resp.Data)
+	if err != nil {
+		return resultData, fmt.Errorf("failed. decode hex data of tx response error: %s", err)
+	}
+
+	return ec.DecodeResultData(data)
+}
+
+// GetContractAddress gets the address of the contract deployed by the evm tx from CreateContractEthereum
+func (ec evmClient) GetContractAddress(resp sdk.TxResponse) (contractAddr ethcmn.Address, err error) {
+	resultData, err := ec.GetResultData(resp)
+	if err != nil {
+		return
+	}
+
+	if resultData.ContractAddress == (ethcmn.Address{}) {
+		return contractAddr, errors.New("failed. no contract deployed by the evm tx")
+	}
+
+	return resultData.ContractAddress, nil
+}
@@ -0,0 +1,88 @@
+package evm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvmClient_GetContractAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{
+		ContractAddress: ethcmn.HexToAddress(contractAddr),
+		Ret:             []byte("default ret"),
+	})
+	require.NoError(t, err)
+
+	resp := mocks.DefaultMockSuccessTxResponse()
+	resp.Data = hex.EncodeToString(data)
+	resAddr, err := mockCli.Evm().GetContractAddress(resp)
+	require.NoError(t, err)
+	require.Equal(t, contractAddr, resAddr.Hex())
+
+	resultData, err := mockCli.Evm().DecodeResultData(data)
+	require.NoError(t, err)
+	require.Equal(t, []byte("default ret"), resultData.Ret)
+
+	// no contract deployed
+	data, err = evmtypes.EncodeResultData(&evmtypes.ResultData{})
+	require.NoError(t, err)
+	resp.Data = hex.EncodeToString(data)
+	_, err = mockCli.Evm().GetContractAddress(resp)
+	require.Error(t, err)
+
+	// bad data
+	resp.Data = "default data"
+	_, err = mockCli.Evm().GetContractAddress(resp)
+	require.Error(t, err)
+
+	_, err = mockCli.Evm().DecodeResultData(nil)
+	require.Error(t, err)
+}
+
+func TestEvmClient_ParseEthereumTxEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	events := sdk.StringEvents{{
+		Type: "ethereum",
+		Attributes: []sdk.Attribute{
+			{Key: "amount", Value: "1024"},
+			{Key: "recipient", Value: recAddrEth},
+		},
+	}}
+
+	ethTxs, err := mockCli.Evm().ParseEthereumTxEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ethTxs))
+	require.Equal(t, big.NewInt(1024), ethTxs[0].Amount)
+	require.Equal(t, recAddrEth, ethTxs[0].Recipient.Hex())
+
+	events[0].Attributes[1].Value = "bad recipient"
+	_, err = mockCli.Evm().ParseEthereumTxEvents(events)
+	require.Error(t, err)
+
+	events[0].Attributes[0].Value = "bad amount"
+	_, err = mockCli.Evm().ParseEthereumTxEvents(events)
+	require.Error(t, err)
+}
@@ -0,0 +1,242 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	rpctypes "github.com/okx/okbchain/app/rpc/types"
+)
+
+// ethAPI serves the methods in the namespace "eth"
+type ethAPI struct {
+	cli Client
+}
+
+func newEthAPI(cli Client) *ethAPI {
+	return &ethAPI{cli: cli}
+}
+
+func (api *ethAPI) proxy() exposed.Web3Proxy {
+	return api.cli.Evm().Web3Proxy()
+}
+
+// ChainId returns the chain id for EIP-155 replay protection
+func (api *ethAPI) ChainId() (*hexutil.Big, error) {
+	chainID := api.cli.GetConfig().ChainIDBigInt
+	if chainID == nil {
+		return nil, fmt.Errorf("failed. no chain id for EIP-155 parsed from %s", api.cli.GetConfig().ChainID)
+	}
+
+	return (*hexutil.Big)(chainID), nil
+}
+
+// BlockNumber returns the number of the latest block executed
+func (api *ethAPI) BlockNumber() (hexutil.Uint64, error) {
+	return api.proxy().BlockNumberProxy()
+}
+
+// GasPrice returns the default gas price of the chain
+func (api *ethAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).Set(types.DefaultGasPrice))
+}
+
+// Accounts returns no account since the server never holds any key
+func (api *ethAPI) Accounts() []ethcmn.Address {
+	return []ethcmn.Address{}
+}
+
+// Syncing always returns false since the server follows the node
+func (api *ethAPI) Syncing() bool {
+	return false
+}
+
+// GetBalance returns the balance of the address on the block number
+func (api *ethAPI) GetBalance(addr ethcmn.Address, blockNum *rpctypes.BlockNumber) (*hexutil.Big, error) {
+	return api.proxy().GetBalanceProxy(addr, blockNumber(blockNum))
+}
+
+// GetTransactionCount returns the nonce of the address on the block number
+func (api *ethAPI) GetTransactionCount(addr ethcmn.Address, blockNum *rpctypes.BlockNumber) (*hexutil.Uint64, error) {
+	return api.proxy().GetTransactionCountProxy(addr, blockNumber(blockNum))
+}
+
+// GetCode returns the contract code of the address on the block number
+func (api *ethAPI) GetCode(addr ethcmn.Address, blockNum *rpctypes.BlockNumber) (hexutil.Bytes, error) {
+	return api.proxy().GetCodeProxy(addr, blockNumber(blockNum))
+}
+
+// GetStorageAt returns the storage value with the key of the address on the block number
+func (api *ethAPI) GetStorageAt(addr ethcmn.Address, key string, blockNum *rpctypes.BlockNumber) (hexutil.Bytes,
+	error) {
+	return api.proxy().GetStorageAtProxy(addr, key, blockNumber(blockNum))
+}
+
+// Call executes the message call on the block number without creating a tx
+func (api *ethAPI) Call(args rpctypes.CallArgs, blockNum *rpctypes.BlockNumber) (hexutil.Bytes, error) {
+	return api.proxy().CallProxy(args, blockNumber(blockNum))
+}
+
+// EstimateGas returns the gas estimated for the message call on the latest block
+// Note: the block number is accepted for compatibility but ignored
+func (api *ethAPI) EstimateGas(args rpctypes.CallArgs, _ *rpctypes.BlockNumber) (hexutil.Uint64, error) {
+	if args.From == nil {
+		args.From = &ethcmn.Address{}
+	}
+
+	return api.proxy().EstimateGasProxy(args)
+}
+
+// GetBlockByNumber returns the block of the block number
+func (api *ethAPI) GetBlockByNumber(blockNum rpctypes.BlockNumber, fullTx bool) (*types.Block, error) {
+	return api.proxy().GetBlockByNumberProxy(blockNum, fullTx)
+}
+
+// GetBlockByHash returns the block of the hash
+func (api *ethAPI) GetBlockByHash(hash ethcmn.Hash, fullTx bool) (*types.Block, error) {
+	return api.proxy().GetBlockByHashProxy(hash, fullTx)
+}
+
+// GetTransactionByHash returns the tx of the hash
+func (api *ethAPI) GetTransactionByHash(hash ethcmn.Hash) (*types.Transaction, error) {
+	return api.proxy().GetTransactionByHashProxy(hash)
+}
+
+// GetTransactionReceipt returns the receipt of the tx
+func (api *ethAPI) GetTransactionReceipt(hash ethcmn.Hash) (*types.TransactionReceipt, error) {
+	return api.proxy().GetTransactionReceiptProxy(hash)
+}
+
+// GetLogs returns the logs matching the filter criteria
+func (api *ethAPI) GetLogs(crit filterCriteria) ([]*ethcore.Log, error) {
+	return api.proxy().GetLogsProxy(ethereum.FilterQuery(crit))
+}
+
+// SendRawTransaction broadcasts the signed tx encoded in RLP and returns its hash
+func (api *ethAPI) SendRawTransaction(data hexutil.Bytes) (ethcmn.Hash, error) {
+	return api.proxy().SendRawTransactionProxy(data)
+}
+
+// netAPI serves the methods in the namespace "net"
+type netAPI struct {
+	cli Client
+}
+
+func newNetAPI(cli Client) *netAPI {
+	return &netAPI{cli: cli}
+}
+
+// Version returns the network id, which is the chain id for EIP-155 in decimal
+func (api *netAPI) Version() (string, error) {
+	chainID := api.cli.GetConfig().ChainIDBigInt
+	if chainID == nil {
+		return "", fmt.Errorf("failed. no chain id for EIP-155 parsed from %s", api.cli.GetConfig().ChainID)
+	}
+
+	return chainID.String(), nil
+}
+
+// Listening always returns true since the server is serving
+func (api *netAPI) Listening() bool {
+	return true
+}
+
+// web3API serves the methods in the namespace "web3"
+type web3API struct {
+	clientVersion string
+}
+
+func newWeb3API(clientVersion string) *web3API {
+	return &web3API{clientVersion: clientVersion}
+}
+
+// ClientVersion returns the version of the server
+func (api *web3API) ClientVersion() string {
+	return api.clientVersion
+}
+
+// Sha3 returns the keccak-256 hash of the input
+func (api *web3API) Sha3(input hexutil.Bytes) hexutil.Bytes {
+	return ethcrypto.Keccak256(input)
+}
+
+// blockNumber returns the latest if the optional block number is omitted
+func blockNumber(blockNum *rpctypes.BlockNumber) rpctypes.BlockNumber {
+	if blockNum == nil {
+		return rpctypes.LatestBlockNumber
+	}
+
+	return *blockNum
+}
+
+// filterCriteria is the ethereum.FilterQuery decoded from the JSON-RPC params
+type filterCriteria ethereum.FilterQuery
+
+// UnmarshalJSON decodes the filter object, where the address is a single one or an array, and each topic is null, a
+// single one or an array
+// Note: the block number "latest" and "pending" are decoded to nil as the latest block
+func (crit *filterCriteria) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *ethcmn.Hash          `json:"blockHash"`
+		FromBlock *rpctypes.BlockNumber `json:"fromBlock"`
+		ToBlock   *rpctypes.BlockNumber `json:"toBlock"`
+		Addresses json.RawMessage       `json:"address"`
+		Topics    []json.RawMessage     `json:"topics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	crit.BlockHash = raw.BlockHash
+	crit.FromBlock, crit.ToBlock = toBigInt(raw.FromBlock), toBigInt(raw.ToBlock)
+
+	for _, rawAddr := range oneOrMany(raw.Addresses) {
+		var addr ethcmn.Address
+		if err := json.Unmarshal(rawAddr, &addr); err != nil {
+			return fmt.Errorf("failed. invalid address in filter: %s", err)
+		}
+		crit.Addresses = append(crit.Addresses, addr)
+	}
+
+	crit.Topics = make([][]ethcmn.Hash, len(raw.Topics))
+	for i, rawTopic := range raw.Topics {
+		for _, rawHash := range oneOrMany(rawTopic) {
+			var topic ethcmn.Hash
+			if err := json.Unmarshal(rawHash, &topic); err != nil {
+				return fmt.Errorf("failed. invalid topic %d in filter: %s", i, err)
+			}
+			crit.Topics[i] = append(crit.Topics[i], topic)
+		}
+	}
+
+	return nil
+}
+
+func toBigInt(blockNum *rpctypes.BlockNumber) *big.Int {
+	if blockNum == nil || *blockNum <= rpctypes.LatestBlockNumber {
+		return nil
+	}
+
+	return big.NewInt(blockNum.Int64())
+}
+
+// oneOrMany splits null, a single value or an array of values into the raw values
+func oneOrMany(data json.RawMessage) []json.RawMessage {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return []json.RawMessage{data}
+	}
+
+	return raws
+}
@@ -0,0 +1,119 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/okex/exchain-go-sdk/exposed"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+)
+
+// const
+const (
+	// DefaultClientVersion is the default version returned by method "web3_clientVersion"
+	DefaultClientVersion = "exchain-go-sdk"
+	// DefaultShutdownTimeout is the default duration to wait for the requests in flight when the server stops
+	DefaultShutdownTimeout = 5 * time.Second
+)
+
+// Client shows the expected behavior of the client that the server works with
+type Client interface {
+	Evm() exposed.Evm
+	GetConfig() gosdktypes.ClientConfig
+}
+
+// Options - structure of the options of the server
+type Options struct {
+	// WsOrigins are the origins allowed to connect by websocket, and any origin is allowed by default
+	WsOrigins       []string
+	ClientVersion   string
+	ShutdownTimeout time.Duration
+}
+
+// Server - structure of the embeddable ethereum JSON-RPC server, which answers the eth_, net_ and web3_ methods through
+// the ABCI queries and the Tendermint RPC calls of the client. It serves both http and websocket on the same handler
+type Server struct {
+	rpcServer *rpc.Server
+	wsHandler http.Handler
+	opts      Options
+}
+
+// NewServer creates a new instance of Server
+func NewServer(cli Client, opts Options) (*Server, error) {
+	if len(opts.WsOrigins) == 0 {
+		opts.WsOrigins = []string{"*"}
+	}
+	if len(opts.ClientVersion) == 0 {
+		opts.ClientVersion = DefaultClientVersion
+	}
+	if opts.ShutdownTimeout <= 0 {
+		opts.ShutdownTimeout = DefaultShutdownTimeout
+	}
+
+	rpcServer := rpc.NewServer()
+	for namespace, api := range map[string]interface{}{
+		"eth":  newEthAPI(cli),
+		"net":  newNetAPI(cli),
+		"web3": newWeb3API(opts.ClientVersion),
+	} {
+		if err := rpcServer.RegisterName(namespace, api); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Server{
+		rpcServer: rpcServer,
+		wsHandler: rpcServer.WebsocketHandler(opts.WsOrigins),
+		opts:      opts,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler, and upgrades the request to websocket if asked
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if isWebsocket(r) {
+		s.wsHandler.ServeHTTP(w, r)
+		return
+	}
+
+	s.rpcServer.ServeHTTP(w, r)
+}
+
+// ListenAndServe listens on the address and serves until the context is done
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{Addr: addr, Handler: s}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		s.rpcServer.Stop()
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.opts.ShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.rpcServer.Stop()
+		return err
+	}
+
+	s.rpcServer.Stop()
+	return ctx.Err()
+}
+
+// Stop stops serving the requests and closes all the websocket connections
+func (s *Server) Stop() {
+	s.rpcServer.Stop()
+}
+
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
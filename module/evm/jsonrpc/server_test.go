@@ -0,0 +1,198 @@
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	rpctypes "github.com/okx/okbchain/app/rpc/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	contractAddr = ethcmn.HexToAddress("0x9aD84c8630E0282F78e5479B46E64E17779e3Cfb")
+	holderAddr   = ethcmn.HexToAddress("0x2c4d395A628e68aD818a9938998a5C4723BBEa83")
+	topic        = ethcmn.HexToHash("0x0a")
+)
+
+// fakeChain simulates the web3 proxy of the evm module on top of the shared fake chain
+type fakeChain struct {
+	*mocks.FakeChain
+	config gosdktypes.ClientConfig
+}
+
+func (fc *fakeChain) GetConfig() gosdktypes.ClientConfig { return fc.config }
+
+func newTestServer(t *testing.T) (*fakeChain, *httptest.Server) {
+	config, err := gosdktypes.NewClientConfig("testURL", "exchain-65", gosdktypes.BroadcastSync, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+
+	chain := &fakeChain{FakeChain: mocks.NewFakeChain(100, 8), config: config}
+	chain.Balances = map[ethcmn.Address]*big.Int{holderAddr: big.NewInt(1024)}
+	chain.CallReturn, chain.EstimatedGas = ethcmn.LeftPadBytes([]byte{1}, 32), 22000
+	chain.Logs = []*ethcore.Log{{
+		Address:     contractAddr,
+		Topics:      []ethcmn.Hash{topic},
+		Data:        []byte{},
+		BlockNumber: uint64(chain.Height),
+		TxHash:      ethcmn.HexToHash("0x02"),
+		BlockHash:   ethcmn.HexToHash("0x01"),
+	}}
+	server, err := NewServer(chain, Options{})
+	require.NoError(t, err)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	return chain, httpServer
+}
+
+func TestServer_EthClient(t *testing.T) {
+	chain, httpServer := newTestServer(t)
+	ctx := context.Background()
+	cli, err := ethclient.Dial(httpServer.URL)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	chainID, err := cli.ChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(65), chainID.Int64())
+
+	networkID, err := cli.NetworkID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(65), networkID.Int64())
+
+	blockNumber, err := cli.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), blockNumber)
+
+	gasPrice, err := cli.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultGasPrice, gasPrice)
+
+	balance, err := cli.BalanceAt(ctx, holderAddr, big.NewInt(90))
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), balance.Int64())
+	require.Equal(t, rpctypes.BlockNumber(90), chain.LastBlockNum)
+
+	nonce, err := cli.PendingNonceAt(ctx, holderAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), nonce)
+	require.Equal(t, rpctypes.PendingBlockNumber, chain.LastBlockNum)
+
+	// eth_call from the zero address
+	ret, err := cli.CallContract(ctx, ethereum.CallMsg{To: &contractAddr, Data: []byte{0x01}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), new(big.Int).SetBytes(ret))
+	require.Equal(t, rpctypes.LatestBlockNumber, chain.LastBlockNum)
+	require.Equal(t, hexutil.Bytes{0x01}, *chain.LastCallArgs.Data)
+
+	_, err = cli.CallContract(ctx, ethereum.CallMsg{}, nil)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "execution reverted"))
+
+	gas, err := cli.EstimateGas(ctx, ethereum.CallMsg{To: &contractAddr})
+	require.NoError(t, err)
+	require.Equal(t, uint64(22000), gas)
+	require.NotNil(t, chain.LastCallArgs.From)
+
+	// eth_sendRawTransaction and the receipt
+	priv, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	signedTx, err := ethcore.SignTx(ethcore.NewTransaction(0, holderAddr, big.NewInt(1), 21000, gasPrice, nil),
+		ethcore.NewEIP155Signer(chainID), priv)
+	require.NoError(t, err)
+	require.NoError(t, cli.SendTransaction(ctx, signedTx))
+	require.Equal(t, 1, len(chain.RawTxs))
+
+	receipt, err := cli.TransactionReceipt(ctx, signedTx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, ethcore.ReceiptStatusSuccessful, receipt.Status)
+	require.Equal(t, signedTx.Hash(), receipt.TxHash)
+
+	_, err = cli.TransactionReceipt(ctx, ethcmn.HexToHash("0x03"))
+	require.Equal(t, ethereum.NotFound, err)
+
+	// eth_getLogs
+	logs, err := cli.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: big.NewInt(90),
+		Addresses: []ethcmn.Address{contractAddr},
+		Topics:    [][]ethcmn.Hash{{topic}, nil},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, int64(90), chain.LastFilter.FromBlock.Int64())
+	require.Nil(t, chain.LastFilter.ToBlock)
+	require.Equal(t, []ethcmn.Address{contractAddr}, chain.LastFilter.Addresses)
+	require.Equal(t, [][]ethcmn.Hash{{topic}, nil}, chain.LastFilter.Topics)
+}
+
+func TestServer_Websocket(t *testing.T) {
+	chain, httpServer := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cli, err := rpc.DialContext(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http"))
+	require.NoError(t, err)
+	defer cli.Close()
+
+	var version string
+	require.NoError(t, cli.CallContext(ctx, &version, "web3_clientVersion"))
+	require.Equal(t, DefaultClientVersion, version)
+
+	var hash hexutil.Bytes
+	require.NoError(t, cli.CallContext(ctx, &hash, "web3_sha3", hexutil.Bytes("abc")))
+	require.Equal(t, hexutil.Bytes(ethcrypto.Keccak256([]byte("abc"))), hash)
+
+	var listening bool
+	require.NoError(t, cli.CallContext(ctx, &listening, "net_listening"))
+	require.True(t, listening)
+
+	// a single address and a single topic
+	var logs []*ethcore.Log
+	require.NoError(t, cli.CallContext(ctx, &logs, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "latest",
+		"address":   contractAddr,
+		"topics":    []interface{}{nil, topic},
+	}))
+	require.Equal(t, 1, len(logs))
+	require.Nil(t, chain.LastFilter.FromBlock)
+	require.Equal(t, []ethcmn.Address{contractAddr}, chain.LastFilter.Addresses)
+	require.Equal(t, [][]ethcmn.Hash{nil, {topic}}, chain.LastFilter.Topics)
+}
+
+func TestServer_ListenAndServe(t *testing.T) {
+	config, err := gosdktypes.NewClientConfig("testURL", "exchain-65", gosdktypes.BroadcastSync, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	server, err := NewServer(&fakeChain{FakeChain: mocks.NewFakeChain(100, 8), config: config},
+		Options{ShutdownTimeout: time.Second})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe(ctx, "127.0.0.1:0")
+	}()
+
+	cancel()
+	require.Equal(t, context.Canceled, <-errCh)
+
+	// an invalid address
+	require.Error(t, server.ListenAndServe(context.Background(), "bad address"))
+}
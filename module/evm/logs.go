@@ -0,0 +1,160 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+)
+
+// FilterLogs scans a page of the evm logs matching the filter from FromBlock, within MaxBlocks blocks and MaxLogs logs
+// Note: the page scanned before an error is returned with the error as well, so that the scan can be resumed from its
+// NextBlock
+func (ec evmClient) FilterLogs(filter types.LogFilter) (page types.LogsPage, err error) {
+	if err = checkLogFilter(filter); err != nil {
+		return
+	}
+
+	toHeight := filter.ToBlock
+	if toHeight == 0 {
+		latest, err := ec.BlockNumberProxy()
+		if err != nil {
+			return page, err
+		}
+		toHeight = int64(latest)
+	}
+
+	page = types.LogsPage{
+		FromBlock: filter.FromBlock,
+		ToBlock:   filter.FromBlock - 1,
+		NextBlock: filter.FromBlock,
+		Logs:      make([]*ethcore.Log, 0),
+	}
+
+	maxBlocks := filter.MaxBlocks
+	if maxBlocks == 0 {
+		maxBlocks = types.MaxLogsBlockRange
+	}
+	endHeight := filter.FromBlock + maxBlocks - 1
+	if endHeight > toHeight {
+		endHeight = toHeight
+	}
+
+	for height := filter.FromBlock; height <= endHeight; height++ {
+		logs, err := ec.matchedLogs(height, filter.Addresses, filter.Topics)
+		if err != nil {
+			return page, fmt.Errorf("failed. scan logs of block %d error: %s", height, err)
+		}
+
+		page.Logs = append(page.Logs, logs...)
+		page.ToBlock, page.NextBlock = height, height+1
+		if filter.MaxLogs > 0 && len(page.Logs) >= filter.MaxLogs {
+			break
+		}
+	}
+
+	page.Done = page.NextBlock > toHeight
+	return
+}
+
+// ScanLogs scans the evm logs matching the filter page by page, and hands each page to the handler in order
+// Note: the latest height is resolved once before the scan if ToBlock is 0. The height to resume from is returned,
+// which is the FromBlock of the page failed in the scan or by the handler
+func (ec evmClient) ScanLogs(filter types.LogFilter, handler func(types.LogsPage) error) (nextBlock int64,
+	err error) {
+	if err = checkLogFilter(filter); err != nil {
+		return filter.FromBlock, err
+	}
+
+	if filter.ToBlock == 0 {
+		latest, err := ec.BlockNumberProxy()
+		if err != nil {
+			return filter.FromBlock, err
+		}
+		filter.ToBlock = int64(latest)
+	}
+
+	for {
+		page, err := ec.FilterLogs(filter)
+		if err != nil {
+			return filter.FromBlock, err
+		}
+
+		if err = handler(page); err != nil {
+			return filter.FromBlock, fmt.Errorf("failed. handle logs of blocks [%d, %d] error: %s", page.FromBlock,
+				page.ToBlock, err)
+		}
+
+		if page.Done {
+			return page.NextBlock, nil
+		}
+		filter.FromBlock = page.NextBlock
+	}
+}
+
+// DecodeLogs decodes the evm logs into the events with the named args by the abi of the contract, and skips the logs
+// of the events out of the abi
+// Note: the anonymous events can't be recognized from the logs, so they are skipped as well
+func (evmClient) DecodeLogs(abiJSON string, logs []*ethcore.Log) (decodedLogs []types.DecodedLog, err error) {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed. parse abi error: %s", err)
+	}
+
+	for _, log := range logs {
+		if log == nil || len(log.Topics) == 0 {
+			continue
+		}
+
+		event, err := contractABI.EventByID(log.Topics[0])
+		if err != nil || event.Anonymous {
+			continue
+		}
+
+		values, err := types.UnpackLog(*event, *log)
+		if err != nil {
+			return nil, fmt.Errorf("failed. decode log %d in tx %s error: %s", log.Index, log.TxHash.Hex(), err)
+		}
+
+		args := make(map[string]interface{}, len(values))
+		for i, input := range event.Inputs {
+			name := input.Name
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			args[name] = values[i]
+		}
+
+		decodedLogs = append(decodedLogs, types.DecodedLog{
+			Event: event.Name,
+			Args:  args,
+			Log:   log,
+		})
+	}
+
+	return
+}
+
+func checkLogFilter(filter types.LogFilter) error {
+	if filter.FromBlock <= 0 {
+		return errors.New("failed. from block must be positive")
+	}
+
+	if filter.ToBlock < 0 {
+		return errors.New("failed. to block must be non-negative")
+	}
+
+	if filter.MaxBlocks < 0 || filter.MaxBlocks > types.MaxLogsBlockRange {
+		return fmt.Errorf("failed. max blocks %d is out of range [0, %d]", filter.MaxBlocks,
+			types.MaxLogsBlockRange)
+	}
+
+	if filter.MaxLogs < 0 {
+		return errors.New("failed. max logs must be non-negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const depositABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"tag","type":"string"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"","type":"bytes32"}],"name":"Deposit","type":"event"}]`
+
+// mockBlockLogs mocks the block of the height with an evm tx emitting the logs
+func mockBlockLogs(t *testing.T, mockCli *mocks.MockClient, config gosdktypes.ClientConfig, height int64,
+	logs []*ethcore.Log) {
+	for _, log := range logs {
+		log.BlockNumber = uint64(height)
+	}
+
+	tx := newSignedEthTx(t, config.ChainIDBigInt, uint64(height), 30000)
+	bloom := ethcore.BytesToBloom(ethcore.LogsBloom(logs))
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Bloom: bloom, Logs: logs})
+	require.NoError(t, err)
+
+	block := &tmtypes.Block{
+		Header:     tmtypes.Header{ChainID: config.ChainID, Height: height, ValidatorsHash: []byte("validators")},
+		LastCommit: &tmtypes.Commit{},
+		Data:       tmtypes.Data{Txs: tmtypes.Txs{tx}},
+	}
+	bloomPath := fmt.Sprintf("custom/%s/%s/%d", evmtypes.RouterKey, evmtypes.QueryBloom, height)
+
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().QueryWithHeight(bloomPath, nil, height).
+		Return(cdc.MustMarshalJSON(evmtypes.QueryBloomFilter{Bloom: bloom}), height, nil).AnyTimes()
+	mockCli.EXPECT().Block(&height).Return(&ctypes.ResultBlock{Block: block}, nil).AnyTimes()
+	mockCli.EXPECT().Tx(tx.Hash(), false).
+		Return(&ctypes.ResultTx{Hash: tx.Hash(), Height: height, Tx: tx, TxResult: abci.ResponseDeliverTx{Data: data}},
+			nil).AnyTimes()
+}
+
+func TestEvmClient_FilterLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	contract := ethcmn.HexToAddress(contractAddr)
+	topicA, topicB := ethcmn.HexToHash("0x0a"), ethcmn.HexToHash("0x0b")
+	logs10 := []*ethcore.Log{
+		{Address: contract, Topics: []ethcmn.Hash{topicA}},
+		{Address: contract, Topics: []ethcmn.Hash{topicA, topicB}},
+	}
+	logs12 := []*ethcore.Log{
+		{Address: contract, Topics: []ethcmn.Hash{topicB}},
+		{Address: contract, Topics: []ethcmn.Hash{topicA}},
+	}
+
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(cdc).AnyTimes()
+	mockCli.EXPECT().BlockchainInfo(int64(0), int64(0)).
+		Return(&ctypes.ResultBlockchainInfo{LastHeight: 13}, nil).AnyTimes()
+	mockBlockLogs(t, &mockCli, config, 10, logs10)
+	mockBlockLogs(t, &mockCli, config, 11, nil)
+	mockBlockLogs(t, &mockCli, config, 12, logs12)
+
+	filter := types.LogFilter{
+		Addresses: []ethcmn.Address{contract},
+		Topics:    [][]ethcmn.Hash{{topicA}},
+		FromBlock: 10,
+		MaxBlocks: 2,
+	}
+	page, err := mockCli.Evm().FilterLogs(filter)
+	require.NoError(t, err)
+	require.Equal(t, types.LogsPage{FromBlock: 10, ToBlock: 11, NextBlock: 12, Logs: logs10}, page)
+
+	// resume to the latest
+	filter.FromBlock = page.NextBlock
+	page, err = mockCli.Evm().FilterLogs(filter)
+	require.NoError(t, err)
+	require.Equal(t, types.LogsPage{FromBlock: 12, ToBlock: 12, NextBlock: 13, Done: true, Logs: logs12[1:]}, page)
+
+	// caught up with the latest
+	filter.FromBlock = page.NextBlock
+	page, err = mockCli.Evm().FilterLogs(filter)
+	require.NoError(t, err)
+	require.Equal(t, types.LogsPage{FromBlock: 13, ToBlock: 12, NextBlock: 13, Done: true, Logs: []*ethcore.Log{}},
+		page)
+
+	// stop at the end of the block reaching the max logs
+	page, err = mockCli.Evm().FilterLogs(types.LogFilter{FromBlock: 10, ToBlock: 12, MaxLogs: 1})
+	require.NoError(t, err)
+	require.Equal(t, types.LogsPage{FromBlock: 10, ToBlock: 10, NextBlock: 11, Logs: logs10}, page)
+
+	// bad filters
+	_, err = mockCli.Evm().FilterLogs(types.LogFilter{})
+	require.Error(t, err)
+	_, err = mockCli.Evm().FilterLogs(types.LogFilter{FromBlock: 1, ToBlock: -1})
+	require.Error(t, err)
+	_, err = mockCli.Evm().FilterLogs(types.LogFilter{FromBlock: 1, MaxBlocks: types.MaxLogsBlockRange + 1})
+	require.Error(t, err)
+	_, err = mockCli.Evm().FilterLogs(types.LogFilter{FromBlock: 1, MaxLogs: -1})
+	require.Error(t, err)
+}
+
+func TestEvmClient_ScanLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	contract := ethcmn.HexToAddress(contractAddr)
+	logs10 := []*ethcore.Log{{Address: contract, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x0a")}}}
+	logs12 := []*ethcore.Log{{Address: contract, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x0b")}}}
+
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(cdc).AnyTimes()
+	mockCli.EXPECT().BlockchainInfo(int64(0), int64(0)).
+		Return(&ctypes.ResultBlockchainInfo{LastHeight: 13}, nil).AnyTimes()
+	mockBlockLogs(t, &mockCli, config, 10, logs10)
+	mockBlockLogs(t, &mockCli, config, 11, nil)
+	mockBlockLogs(t, &mockCli, config, 12, logs12)
+
+	var scanned []*ethcore.Log
+	var pages int
+	filter := types.LogFilter{Addresses: []ethcmn.Address{contract}, FromBlock: 10, MaxBlocks: 1}
+	nextBlock, err := mockCli.Evm().ScanLogs(filter, func(page types.LogsPage) error {
+		pages++
+		scanned = append(scanned, page.Logs...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(13), nextBlock)
+	require.Equal(t, 3, pages)
+	require.Equal(t, append(logs10, logs12...), scanned)
+
+	// resume from the page failed by the handler
+	nextBlock, err = mockCli.Evm().ScanLogs(filter, func(page types.LogsPage) error {
+		if page.FromBlock == 11 {
+			return errors.New("default error")
+		}
+		return nil
+	})
+	require.Error(t, err)
+	require.Equal(t, int64(11), nextBlock)
+
+	_, err = mockCli.Evm().ScanLogs(types.LogFilter{}, func(types.LogsPage) error { return nil })
+	require.Error(t, err)
+}
+
+func TestEvmClient_DecodeLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	contractABI, err := abi.JSON(strings.NewReader(depositABI))
+	require.NoError(t, err)
+	event := contractABI.Events["Deposit"]
+	memo := ethcmn.HexToHash("0x01")
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(1024), memo)
+	require.NoError(t, err)
+
+	from := ethcmn.HexToAddress(recAddrEth)
+	tagHash := ethcrypto.Keccak256Hash([]byte("tag"))
+	logs := []*ethcore.Log{
+		{Topics: []ethcmn.Hash{event.ID, from.Hash(), tagHash}, Data: data},
+		// out of the abi
+		{Topics: []ethcmn.Hash{ethcmn.HexToHash("0x0a")}},
+		{},
+	}
+
+	decodedLogs, err := mockCli.Evm().DecodeLogs(depositABI, logs)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(decodedLogs))
+	require.Equal(t, "Deposit", decodedLogs[0].Event)
+	require.Equal(t, map[string]interface{}{
+		"from":  from,
+		"tag":   tagHash,
+		"value": big.NewInt(1024),
+		"arg3":  [32]byte(memo),
+	}, decodedLogs[0].Args)
+	require.Equal(t, logs[0], decodedLogs[0].Log)
+
+	// bad data
+	logs[0].Data = data[:32]
+	_, err = mockCli.Evm().DecodeLogs(depositABI, logs)
+	require.Error(t, err)
+
+	// missing topic
+	logs[0].Data, logs[0].Topics = data, logs[0].Topics[:2]
+	_, err = mockCli.Evm().DecodeLogs(depositABI, logs)
+	require.Error(t, err)
+
+	_, err = mockCli.Evm().DecodeLogs("bad abi", logs)
+	require.Error(t, err)
+}
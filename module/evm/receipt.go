@@ -0,0 +1,136 @@
+package evm
+
+import (
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	sdkerrors "github.com/okx/okbchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+)
+
+// QueryReceipt gets the receipt of the evm tx by its hash, which is the same in tendermint and ethereum
+func (ec evmClient) QueryReceipt(txHash ethcmn.Hash) (receipt types.Receipt, err error) {
+	resTx, err := ec.Tx(txHash.Bytes(), false)
+	if err != nil {
+		return receipt, fmt.Errorf("failed. query tx %s error: %s", txHash.Hex(), err)
+	}
+
+	return ec.GetReceipt(resTx)
+}
+
+// GetReceipt converts the tendermint tx result of the evm tx into the receipt shaped as the one of go-ethereum
+// Note: the block and its results are queried to fill the block hash, the cumulative gas used and the indices of the
+// logs in the block. The logs and the bloom are empty if the tx failed
+func (ec evmClient) GetReceipt(resTx *types.ResultTx) (receipt types.Receipt, err error) {
+	ethReceipt, ethTx, err := ec.ethReceipt(resTx)
+	if err != nil {
+		return
+	}
+
+	return types.Receipt{
+		Receipt:           ethReceipt,
+		TmTxHash:          ethcmn.BytesToHash(resTx.Tx.Hash()),
+		From:              ethTx.EthereumAddress(),
+		To:                ethTx.To(),
+		EffectiveGasPrice: ethTx.Data.Price,
+	}, nil
+}
+
+// ethReceipt converts the tendermint tx result of the evm tx into the receipt of go-ethereum, along with the evm tx
+// decoded with its sender verified
+// Note: the block and its results are queried to fill the block hash, the cumulative gas used and the indices of the
+// logs in the block. The logs and the bloom are empty if the tx failed
+func (ec evmClient) ethReceipt(resTx *ctypes.ResultTx) (*ethcore.Receipt, *evmtypes.MsgEthereumTx, error) {
+	tmHash := ethcmn.BytesToHash(resTx.Tx.Hash())
+	ethTx, err := ec.decodeEthTx(resTx.Tx, resTx.Height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = ethTx.VerifySig(ethTx.ChainID(), resTx.Height); err != nil {
+		return nil, nil, fmt.Errorf("failed. verify signature of tx %s error: %s", tmHash.Hex(), err)
+	}
+
+	ethHash, err := ethTxHash(ec.GetCodec(), ethTx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed. calculate ethereum hash of tx %s error: %s", tmHash.Hex(), err)
+	}
+
+	resBlock, err := ec.Block(&resTx.Height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed. query block %d error: %s", resTx.Height, err)
+	}
+
+	resBlockResults, err := ec.BlockResults(&resTx.Height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed. query block results %d error: %s", resTx.Height, err)
+	}
+
+	txsResults := resBlockResults.TxsResults
+	if int(resTx.Index) >= len(txsResults) {
+		return nil, nil, fmt.Errorf("failed. tx index %d is out of the %d txs in block %d", resTx.Index,
+			len(txsResults), resTx.Height)
+	}
+
+	// the gas used and the logs emitted by the txs before in the block
+	var cumulativeGasUsed uint64
+	var logIndex uint
+	for _, txResult := range txsResults[:resTx.Index] {
+		cumulativeGasUsed += txGasUsed(*txResult)
+		if !txResult.IsOK() {
+			continue
+		}
+
+		if resultData, err := evmtypes.DecodeResultData(txResult.Data); err == nil {
+			logIndex += uint(len(resultData.Logs))
+		}
+	}
+
+	gasUsed := txGasUsed(resTx.TxResult)
+	blockHash := ethcmn.BytesToHash(resBlock.Block.Hash())
+	receipt := &ethcore.Receipt{
+		Type:              ethcore.LegacyTxType,
+		CumulativeGasUsed: cumulativeGasUsed + gasUsed,
+		Logs:              []*ethcore.Log{},
+		TxHash:            ethHash,
+		GasUsed:           gasUsed,
+		BlockHash:         blockHash,
+		BlockNumber:       big.NewInt(resTx.Height),
+		TransactionIndex:  uint(resTx.Index),
+	}
+
+	// the evm tx failed if the result data can't be decoded
+	if resTx.TxResult.IsOK() {
+		if resultData, err := evmtypes.DecodeResultData(resTx.TxResult.Data); err == nil {
+			receipt.Status = ethcore.ReceiptStatusSuccessful
+			receipt.Bloom = resultData.Bloom
+			receipt.ContractAddress = resultData.ContractAddress
+			for _, log := range resultData.Logs {
+				log.TxHash = ethHash
+				log.TxIndex = uint(resTx.Index)
+				log.BlockHash = blockHash
+				log.BlockNumber = uint64(resTx.Height)
+				log.Index = logIndex
+				logIndex++
+			}
+			receipt.Logs = append(receipt.Logs, resultData.Logs...)
+		}
+	}
+
+	return receipt, ethTx, nil
+}
+
+// txGasUsed returns the gas used by the tx, where no gas is used when the ante handler rejects the tx with an invalid
+// sequence
+func txGasUsed(txResult abci.ResponseDeliverTx) uint64 {
+	if txResult.Code == sdkerrors.ErrInvalidSequence.ABCICode() {
+		return 0
+	}
+
+	return uint64(txResult.GasUsed)
+}
@@ -0,0 +1,131 @@
+package evm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvmClient_GetReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	priv, err := ethcrypto.HexToECDSA(privKeyHex)
+	require.NoError(t, err)
+	to := ethcmn.HexToAddress(recAddrEth)
+	ethMsg := evmtypes.NewMsgEthereumTx(1, &to, big.NewInt(1024), 50000, big.NewInt(2), nil)
+	require.NoError(t, ethMsg.Sign(config.ChainIDBigInt, priv))
+	txBytes, err := rlp.EncodeToBytes(&ethMsg)
+	require.NoError(t, err)
+	tx := tmtypes.Tx(txBytes)
+
+	// a cosmos tx and an evm tx emitting a log before the tx in the block
+	height := int64(10)
+	cosmosTx, evmTx := tmtypes.Tx("cosmos tx"), newSignedEthTx(t, config.ChainIDBigInt, 0, 30000)
+	contract := ethcmn.HexToAddress(contractAddr)
+	evmData, err := evmtypes.EncodeResultData(&evmtypes.ResultData{
+		Logs: []*ethcore.Log{{Address: contract, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x0a")}}},
+	})
+	require.NoError(t, err)
+
+	logs := []*ethcore.Log{
+		{Address: contract, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x0b")}},
+		{Address: contract, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x0c")}, Data: []byte{1}},
+	}
+	bloom := ethcore.BytesToBloom(ethcore.LogsBloom(logs))
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Bloom: bloom, Logs: logs, ContractAddress: contract})
+	require.NoError(t, err)
+
+	block := &tmtypes.Block{
+		Header:     tmtypes.Header{ChainID: config.ChainID, Height: height, ValidatorsHash: []byte("validators")},
+		LastCommit: &tmtypes.Commit{},
+		Data:       tmtypes.Data{Txs: tmtypes.Txs{cosmosTx, evmTx, tx}},
+	}
+	txResult := abci.ResponseDeliverTx{GasUsed: 25000, Data: data}
+	blockResults := &ctypes.ResultBlockResults{
+		Height: height,
+		TxsResults: []*abci.ResponseDeliverTx{
+			{GasUsed: 20000},
+			{GasUsed: 30000, Data: evmData},
+			&txResult,
+		},
+	}
+	resTx := &ctypes.ResultTx{Hash: tx.Hash(), Height: height, Index: 2, Tx: tx, TxResult: txResult}
+
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(cdc).AnyTimes()
+	mockCli.EXPECT().Tx(tx.Hash(), false).Return(resTx, nil)
+	mockCli.EXPECT().Block(&height).Return(&ctypes.ResultBlock{Block: block}, nil).Times(3)
+	mockCli.EXPECT().BlockResults(&height).Return(blockResults, nil).Times(3)
+
+	receipt, err := mockCli.Evm().QueryReceipt(ethcmn.BytesToHash(tx.Hash()))
+	require.NoError(t, err)
+
+	blockHash, txHash := ethcmn.BytesToHash(block.Hash()), ethcmn.BytesToHash(tx.Hash())
+	require.Equal(t, txHash, receipt.TmTxHash)
+	require.Equal(t, ethcrypto.PubkeyToAddress(priv.PublicKey), receipt.From)
+	require.Equal(t, &to, receipt.To)
+	require.Equal(t, big.NewInt(2), receipt.EffectiveGasPrice)
+
+	ethReceipt := receipt.Receipt
+	require.Equal(t, ethcore.ReceiptStatusSuccessful, ethReceipt.Status)
+	require.Equal(t, uint64(75000), ethReceipt.CumulativeGasUsed)
+	require.Equal(t, uint64(25000), ethReceipt.GasUsed)
+	require.Equal(t, bloom, ethReceipt.Bloom)
+	require.Equal(t, txHash, ethReceipt.TxHash)
+	require.Equal(t, contract, ethReceipt.ContractAddress)
+	require.Equal(t, blockHash, ethReceipt.BlockHash)
+	require.Equal(t, big.NewInt(height), ethReceipt.BlockNumber)
+	require.Equal(t, uint(2), ethReceipt.TransactionIndex)
+	require.Equal(t, 2, len(ethReceipt.Logs))
+	for i, log := range ethReceipt.Logs {
+		require.Equal(t, logs[i].Topics, log.Topics)
+		require.Equal(t, txHash, log.TxHash)
+		require.Equal(t, uint(2), log.TxIndex)
+		require.Equal(t, blockHash, log.BlockHash)
+		require.Equal(t, uint64(height), log.BlockNumber)
+		// after the log of the evm tx before
+		require.Equal(t, uint(i+1), log.Index)
+	}
+
+	// failed tx without logs
+	resTx.TxResult.Code = 7
+	receipt, err = mockCli.Evm().GetReceipt(resTx)
+	require.NoError(t, err)
+	require.Equal(t, ethcore.ReceiptStatusFailed, receipt.Receipt.Status)
+	require.Equal(t, uint64(75000), receipt.Receipt.CumulativeGasUsed)
+	require.Equal(t, ethcore.Bloom{}, receipt.Receipt.Bloom)
+	require.Empty(t, receipt.Receipt.Logs)
+	require.Equal(t, ethcmn.Address{}, receipt.Receipt.ContractAddress)
+
+	// out of the block results
+	resTx.Index = 3
+	_, err = mockCli.Evm().GetReceipt(resTx)
+	require.Error(t, err)
+
+	// not an evm tx
+	_, err = mockCli.Evm().GetReceipt(&ctypes.ResultTx{Height: height, Tx: cosmosTx})
+	require.Error(t, err)
+
+	mockCli.EXPECT().Tx(ethcmn.Hash{}.Bytes(), false).Return(nil, errors.New("default error"))
+	_, err = mockCli.Evm().QueryReceipt(ethcmn.Hash{})
+	require.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/okex/exchain-go-sdk/utils"
+)
+
+// const
+const (
+	ERC20EventTransfer = "Transfer"
+	ERC20EventApproval = "Approval"
+
+	// ERC20ABI is the abi of the standard erc20 methods and events
+	ERC20ABI = `[
+{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+{"type":"event","name":"Approval","anonymous":false,"inputs":[{"name":"owner","type":"address","indexed":true},{"name":"spender","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+)
+
+var (
+	// ERC20TransferTopic is the topic of the event Transfer(address,address,uint256)
+	ERC20TransferTopic = ethcrypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// ERC20ApprovalTopic is the topic of the event Approval(address,address,uint256)
+	ERC20ApprovalTopic = ethcrypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+)
+
+// TokenAmount - structure of the amount of an erc20 token in the smallest unit with the decimals of the token
+type TokenAmount struct {
+	Amount   *big.Int
+	Decimals uint8
+}
+
+// NewTokenAmount creates a new instance of TokenAmount
+func NewTokenAmount(amount *big.Int, decimals uint8) TokenAmount {
+	return TokenAmount{
+		Amount:   amount,
+		Decimals: decimals,
+	}
+}
+
+// ParseTokenAmount parses the decimal amount string, such as "1.5", into TokenAmount with the decimals of the token
+// Note: the trailing zeros beyond the decimals are accepted, such as "1.50" with 1 decimal, as utils.ParseDecimal does
+func ParseTokenAmount(amountStr string, decimals uint8) (ta TokenAmount, err error) {
+	amount, err := utils.ParseDecimal(amountStr, int64(decimals))
+	if err != nil {
+		return
+	}
+
+	return NewTokenAmount(amount, decimals), nil
+}
+
+// String returns the decimal amount with the trailing zeros trimmed
+func (ta TokenAmount) String() string {
+	return utils.FormatDecimal(ta.Amount, int64(ta.Decimals))
+}
+
+// ERC20Event - structure of the event Transfer or Approval emitted by an erc20 contract
+type ERC20Event struct {
+	Name     string
+	Contract ethcmn.Address
+	// From is the owner for the event Approval
+	From ethcmn.Address
+	// To is the spender for the event Approval
+	To    ethcmn.Address
+	Value *big.Int
+	Log   *ethcore.Log
+}
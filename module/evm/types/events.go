@@ -0,0 +1,14 @@
+package types
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// EthereumTxEvent - structure of an evm tx decoded from the ethereum event
+// Note: the recipient is nil for the contract creation
+type EthereumTxEvent struct {
+	Amount    *big.Int
+	Recipient *ethcmn.Address
+}
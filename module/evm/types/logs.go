@@ -0,0 +1,97 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+)
+
+// LogFilter - structure of the filter to scan the evm logs between two heights
+// Note: an empty rule of the topics matches anything in the position, ToBlock 0 stands for the latest height and
+// MaxBlocks 0 stands for MaxLogsBlockRange
+type LogFilter struct {
+	Addresses []ethcmn.Address
+	Topics    [][]ethcmn.Hash
+	FromBlock int64
+	ToBlock   int64
+	// MaxBlocks is the max number of blocks to scan in a page
+	MaxBlocks int64
+	// MaxLogs stops the page at the end of the block where the number of the logs reaches it, and 0 stands for no limit
+	MaxLogs int
+}
+
+// LogsPage - structure of the logs scanned in the block range [FromBlock, ToBlock]
+// Note: the scan is resumed by the filter with FromBlock set to NextBlock until Done
+type LogsPage struct {
+	FromBlock int64          `json:"from_block"`
+	ToBlock   int64          `json:"to_block"`
+	NextBlock int64          `json:"next_block"`
+	Done      bool           `json:"done"`
+	Logs      []*ethcore.Log `json:"logs"`
+}
+
+// DecodedLog - structure of an evm log decoded by the abi of the contract
+// Note: the indexed args of the dynamic types are kept as the hashes in the topics, and the unnamed args are named as
+// arg0, arg1 and so on by their positions
+type DecodedLog struct {
+	Event string                 `json:"event"`
+	Args  map[string]interface{} `json:"args"`
+	Log   *ethcore.Log           `json:"log"`
+}
+
+// UnpackLog unpacks the log of the event into its input values in order, where each indexed value of the dynamic types
+// is the hash in the topic
+func UnpackLog(event abi.Event, log ethcore.Log) ([]interface{}, error) {
+	topics := log.Topics
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.ID {
+			return nil, fmt.Errorf("failed. log isn't the event %s", event.Sig)
+		}
+		topics = topics[1:]
+	}
+
+	nonIndexed, err := event.Inputs.Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed. unpack data of event %s error: %s", event.Sig, err)
+	}
+
+	values := make([]interface{}, len(event.Inputs))
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			values[i], nonIndexed = nonIndexed[0], nonIndexed[1:]
+			continue
+		}
+
+		if len(topics) == 0 {
+			return nil, fmt.Errorf("failed. missing topic of indexed input %s of event %s", input.Name, event.Sig)
+		}
+
+		topic := topics[0]
+		topics = topics[1:]
+		if IsHashedTopic(input.Type) {
+			values[i] = topic
+			continue
+		}
+
+		// the static value is encoded in the topic as a word of the data
+		value, err := abi.Arguments{{Type: input.Type}}.Unpack(topic.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed. unpack indexed input %s of event %s error: %s", input.Name, event.Sig, err)
+		}
+		values[i] = value[0]
+	}
+
+	return values, nil
+}
+
+// IsHashedTopic checks whether the indexed value of the type is stored as its hash in the topic
+func IsHashedTopic(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	}
+
+	return false
+}
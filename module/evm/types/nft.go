@@ -0,0 +1,86 @@
+package types
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// const
+const (
+	ERC721EventTransfer         = "Transfer"
+	ERC1155EventTransferSingle  = "TransferSingle"
+	ERC1155EventTransferBatch   = "TransferBatch"
+	erc165SupportsInterfaceJSON = `{"type":"function","name":"supportsInterface","stateMutability":"view","inputs":[{"name":"interfaceId","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]}`
+
+	// ERC165ABI is the abi of the method supportsInterface of ERC-165
+	ERC165ABI = `[` + erc165SupportsInterfaceJSON + `]`
+
+	// ERC721ABI is the abi of the standard erc721 methods and events used by the helper
+	ERC721ABI = `[
+{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"ownerOf","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"address"}]},
+{"type":"function","name":"tokenURI","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"safeTransferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]},
+{"type":"function","name":"setApprovalForAll","stateMutability":"nonpayable","inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"outputs":[]},
+{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"tokenId","type":"uint256","indexed":true}]},
+` + erc165SupportsInterfaceJSON + `
+]`
+
+	// ERC1155ABI is the abi of the standard erc1155 methods and events used by the helper
+	ERC1155ABI = `[
+{"type":"function","name":"balanceOfBatch","stateMutability":"view","inputs":[{"name":"accounts","type":"address[]"},{"name":"ids","type":"uint256[]"}],"outputs":[{"name":"","type":"uint256[]"}]},
+{"type":"function","name":"uri","stateMutability":"view","inputs":[{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"safeBatchTransferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"ids","type":"uint256[]"},{"name":"amounts","type":"uint256[]"},{"name":"data","type":"bytes"}],"outputs":[]},
+{"type":"event","name":"TransferSingle","anonymous":false,"inputs":[{"name":"operator","type":"address","indexed":true},{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"id","type":"uint256","indexed":false},{"name":"value","type":"uint256","indexed":false}]},
+{"type":"event","name":"TransferBatch","anonymous":false,"inputs":[{"name":"operator","type":"address","indexed":true},{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"ids","type":"uint256[]","indexed":false},{"name":"values","type":"uint256[]","indexed":false}]},
+` + erc165SupportsInterfaceJSON + `
+]`
+)
+
+var (
+	// ERC165InterfaceID is the interface id of ERC-165 itself
+	ERC165InterfaceID = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	// InvalidInterfaceID is the interface id that no contract of ERC-165 supports
+	InvalidInterfaceID = [4]byte{0xff, 0xff, 0xff, 0xff}
+	// ERC721InterfaceID is the interface id of ERC-721
+	ERC721InterfaceID = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	// ERC721MetadataInterfaceID is the interface id of the metadata extension of ERC-721
+	ERC721MetadataInterfaceID = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	// ERC1155InterfaceID is the interface id of ERC-1155
+	ERC1155InterfaceID = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	// ERC1155MetadataURIInterfaceID is the interface id of the metadata uri extension of ERC-1155
+	ERC1155MetadataURIInterfaceID = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+
+	// ERC721TransferTopic is the topic of the event Transfer(address,address,uint256), which is the same as erc20's
+	ERC721TransferTopic = ethcrypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// ERC1155TransferSingleTopic is the topic of the event TransferSingle(address,address,address,uint256,uint256)
+	ERC1155TransferSingleTopic = ethcrypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	// ERC1155TransferBatchTopic is the topic of the event TransferBatch(address,address,address,uint256[],uint256[])
+	ERC1155TransferBatchTopic = ethcrypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+// ERC721Transfer - structure of the event Transfer emitted by an erc721 contract
+type ERC721Transfer struct {
+	Contract ethcmn.Address
+	From     ethcmn.Address
+	To       ethcmn.Address
+	TokenID  *big.Int
+	Log      *ethcore.Log
+}
+
+// ERC1155Transfer - structure of the event TransferSingle or TransferBatch emitted by an erc1155 contract, where the
+// TransferSingle has only one id and value
+type ERC1155Transfer struct {
+	Name     string
+	Contract ethcmn.Address
+	Operator ethcmn.Address
+	From     ethcmn.Address
+	To       ethcmn.Address
+	IDs      []*big.Int
+	Values   []*big.Int
+	Log      *ethcore.Log
+}
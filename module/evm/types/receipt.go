@@ -0,0 +1,19 @@
+package types
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Receipt - structure of the receipt of an evm tx converted from its tendermint tx result
+// Note: the TxHash of the go-ethereum receipt is the ethereum hash of the tx, and TmTxHash is the tendermint one, which
+// are the same for the rlp-encoded evm tx
+type Receipt struct {
+	Receipt           *ethcore.Receipt `json:"receipt"`
+	TmTxHash          ethcmn.Hash      `json:"tm_tx_hash"`
+	From              ethcmn.Address   `json:"from"`
+	To                *ethcmn.Address  `json:"to"`
+	EffectiveGasPrice *big.Int         `json:"effective_gas_price"`
+}
@@ -1,25 +1,51 @@
 package types
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 
 	apptypes "github.com/okx/okbchain/app/types"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
 	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/okx/okbchain/x/evm/watcher"
 )
 
 // const
 const (
 	ModuleName      = evmtypes.ModuleName
 	defaultGasPrice = "0.000000001"
+
+	// MaxLogsBlockRange is the max number of blocks to scan in a single logs query
+	MaxLogsBlockRange = 2000
 )
 
 type (
 	QueryResCode    = evmtypes.QueryResCode
 	QueryResStorage = evmtypes.QueryResStorage
+	ResultData      = evmtypes.ResultData
+
+	Block              = watcher.Block
+	Transaction        = watcher.Transaction
+	TransactionReceipt = watcher.TransactionReceipt
+
+	ResultTx = ctypes.ResultTx
 )
 
 var (
 	DefaultGasPrice    = sdk.MustNewDecFromStr(defaultGasPrice).BigInt()
 	DefaultRPCGasLimit = big.NewInt(apptypes.DefaultRPCGasLimit)
 )
+
+// TransactOpts - structure of the options to send a tx to a contract
+type TransactOpts struct {
+	PrivKey *ecdsa.PrivateKey
+	// Nonce is the nonce of the sender at the latest committed height if nil, which doesn't count the txs in the
+	// mempool. Set it to send several txs in a row
+	Nonce *uint64
+	Value *big.Int
+	// GasLimit is estimated by the chain if zero
+	GasLimit uint64
+	// GasPrice is DefaultGasPrice if nil
+	GasPrice *big.Int
+}
@@ -3,8 +3,10 @@ package evm
 import (
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethcore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/codec"
 	authcli "github.com/okx/okbchain/libs/cosmos-sdk/x/auth/client/utils"
 	"github.com/okx/okbchain/libs/tendermint/crypto/etherhash"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
 	evmtypes "github.com/okx/okbchain/x/evm/types"
 )
 
@@ -25,11 +27,26 @@ func (ec evmClient) GetTxHash(signedTx *ethcore.Transaction) (txHash ethcmn.Hash
 		},
 	}
 
-	txBytes, err := authcli.GetTxEncoder(ec.GetCodec(), authcli.WithEthereumTx())(&tx)
+	return ethTxHash(ec.GetCodec(), &tx)
+}
+
+// GetEthTxHash calculates the ethereum hash of the evm tx in tendermint, whose tendermint hash is tx.Hash()
+// Note: both hashes are the keccak256 hash of the rlp-encoded tx, since the evm txs are accepted by the chain in rlp only
+func (ec evmClient) GetEthTxHash(tx tmtypes.Tx) (ethcmn.Hash, error) {
+	ethTx, err := ec.decodeEthTx(tx, 0)
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+
+	return ethTxHash(ec.GetCodec(), ethTx)
+}
+
+// ethTxHash calculates the hash of the rlp-encoded evm tx
+func ethTxHash(cdc *codec.Codec, tx *evmtypes.MsgEthereumTx) (txHash ethcmn.Hash, err error) {
+	txBytes, err := authcli.GetTxEncoder(cdc, authcli.WithEthereumTx())(tx)
 	if err != nil {
 		return
 	}
 
-	txHash = ethcmn.BytesToHash(etherhash.Sum(txBytes))
-	return
+	return ethcmn.BytesToHash(etherhash.Sum(txBytes)), nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/okex/exchain-go-sdk/module/auth"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +52,23 @@ func TestEvmClient_GetTxHash(t *testing.T) {
 	require.NotNil(t, txHash)
 	require.Equal(t, expectedTxHash, txHash.String())
 }
+
+func TestEvmClient_GetEthTxHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(cdc).AnyTimes()
+
+	tx := newSignedEthTx(t, config.ChainIDBigInt, 0, 30000)
+	ethHash, err := mockCli.Evm().GetEthTxHash(tx)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.BytesToHash(tx.Hash()), ethHash)
+
+	_, err = mockCli.Evm().GetEthTxHash(tmtypes.Tx("not an evm tx"))
+	require.Error(t, err)
+}
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
 	"github.com/okex/exchain-go-sdk/exposed"
 	"github.com/okex/exchain-go-sdk/module/evm/types"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
@@ -15,9 +18,12 @@ import (
 	apptypes "github.com/okx/okbchain/app/types"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth"
+	authcli "github.com/okx/okbchain/libs/cosmos-sdk/x/auth/client/utils"
 	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth/exported"
 	authtypes "github.com/okx/okbchain/libs/cosmos-sdk/x/auth/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
 	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/okx/okbchain/x/evm/watcher"
 )
 
 // Web3Proxy returns the client with exposed.Web3Proxy's behaviour
@@ -43,7 +49,7 @@ func (ec evmClient) BlockNumberProxy() (hexutil.Uint64, error) {
 
 // EstimateGasProxy returns the estimated gas according to the args as method "eth_estimateGas" without rest server routing
 func (ec evmClient) EstimateGasProxy(args rpctypes.CallArgs) (estimatedGas hexutil.Uint64, err error) {
-	simResponse, err := ec.doCallProxy(args, types.DefaultRPCGasLimit)
+	simResponse, err := ec.doCallProxy(args, types.DefaultRPCGasLimit, 0)
 	if err != nil {
 		return
 	}
@@ -52,28 +58,274 @@ func (ec evmClient) EstimateGasProxy(args rpctypes.CallArgs) (estimatedGas hexut
 	return
 }
 
+// SendRawTransactionProxy broadcasts the signed ethereum tx encoded in RLP as method "eth_sendRawTransaction" without
+// rest server routing
+// Note: the tx executed but failed in the block broadcast mode returns its hash without error as ethereum does, and its
+// receipt shows the failure
+func (ec evmClient) SendRawTransactionProxy(data hexutil.Bytes) (hash ethcmn.Hash, err error) {
+	tx := tmtypes.Tx(data)
+	if _, err = ec.decodeEthTx(tx, 0); err != nil {
+		return
+	}
+
+	resp, err := ec.Broadcast(tx, ec.GetConfig().BroadcastMode)
+	if err != nil {
+		return hash, fmt.Errorf("failed. broadcast tx error: %s", err)
+	}
+
+	// rejected before being committed
+	if resp.Height == 0 && resp.Code != 0 {
+		return hash, fmt.Errorf("failed. tx %s rejected: %s", resp.TxHash, resp.RawLog)
+	}
+
+	return ethcmn.BytesToHash(tx.Hash()), nil
+}
+
+// CallProxy executes a message call on the state of the block number as method "eth_call" without rest server routing
+func (ec evmClient) CallProxy(args rpctypes.CallArgs, blockNum rpctypes.BlockNumber) (hexutil.Bytes, error) {
+	if args.From == nil {
+		args.From = &ethcmn.Address{}
+	}
+
+	simResponse, err := ec.doCallProxy(args, types.DefaultRPCGasLimit, queryHeight(blockNum))
+	if err != nil {
+		return nil, err
+	}
+
+	resultData, err := evmtypes.DecodeResultData(simResponse.Result.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed. decode result data of the call error: %s", err)
+	}
+
+	return resultData.Ret, nil
+}
+
+// GetBalanceProxy returns the balance of the address on the block number as method "eth_getBalance" without rest
+// server routing
+func (ec evmClient) GetBalanceProxy(addr ethcmn.Address, blockNum rpctypes.BlockNumber) (*hexutil.Big, error) {
+	account, err := ec.accountAt(addr, queryHeight(blockNum))
+	if err != nil {
+		return nil, err
+	}
+
+	balance := new(big.Int)
+	if account != nil {
+		balance = account.GetCoins().AmountOf(sdk.DefaultBondDenom).BigInt()
+	}
+
+	return (*hexutil.Big)(balance), nil
+}
+
+// GetTransactionCountProxy returns the nonce of the address on the block number as method "eth_getTransactionCount"
+// without rest server routing
+func (ec evmClient) GetTransactionCountProxy(addr ethcmn.Address, blockNum rpctypes.BlockNumber) (*hexutil.Uint64,
+	error) {
+	account, err := ec.accountAt(addr, queryHeight(blockNum))
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce hexutil.Uint64
+	if account != nil {
+		nonce = hexutil.Uint64(account.GetSequence())
+	}
+
+	return &nonce, nil
+}
+
+// GetCodeProxy returns the contract code of the address on the block number as method "eth_getCode" without rest
+// server routing
+func (ec evmClient) GetCodeProxy(addr ethcmn.Address, blockNum rpctypes.BlockNumber) (hexutil.Bytes, error) {
+	path := fmt.Sprintf("custom/%s/%s/%s", evmtypes.RouterKey, evmtypes.QueryCode, addr.Hex())
+	res, _, err := ec.QueryWithHeight(path, nil, queryHeight(blockNum))
+	if err != nil {
+		return nil, utils.ErrClientQuery(err.Error())
+	}
+
+	var resCode types.QueryResCode
+	if err = ec.GetCodec().UnmarshalJSON(res, &resCode); err != nil {
+		return nil, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return resCode.Code, nil
+}
+
+// GetStorageAtProxy returns the storage value with the key of the address on the block number as method
+// "eth_getStorageAt" without rest server routing
+func (ec evmClient) GetStorageAtProxy(addr ethcmn.Address, keyStr string, blockNum rpctypes.BlockNumber) (
+	hexutil.Bytes, error) {
+	path := fmt.Sprintf("custom/%s/%s/%s/%s", evmtypes.RouterKey, evmtypes.QueryStorage, addr.Hex(),
+		utils.FormatKeyToHash(keyStr))
+	res, _, err := ec.QueryWithHeight(path, nil, queryHeight(blockNum))
+	if err != nil {
+		return nil, utils.ErrClientQuery(err.Error())
+	}
+
+	var resStorage types.QueryResStorage
+	if err = ec.GetCodec().UnmarshalJSON(res, &resStorage); err != nil {
+		return nil, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return resStorage.Value, nil
+}
+
+// GetBlockByNumberProxy returns the block of the block number as method "eth_getBlockByNumber" without rest server
+// routing. The full txs are returned if fullTx is true, otherwise only their hashes
+func (ec evmClient) GetBlockByNumberProxy(blockNum rpctypes.BlockNumber, fullTx bool) (*types.Block, error) {
+	var pHeight *int64
+	if height := queryHeight(blockNum); height > 0 {
+		pHeight = &height
+	}
+
+	resBlock, err := ec.Block(pHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.formatBlock(resBlock.Block, fullTx)
+}
+
+// GetBlockByHashProxy returns the block of the hash as method "eth_getBlockByHash" without rest server routing
+// Note: nil is returned without error if the block is not found
+func (ec evmClient) GetBlockByHashProxy(hash ethcmn.Hash, fullTx bool) (*types.Block, error) {
+	height, err := ec.hashToHeight(hash)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	resBlock, err := ec.Block(&height)
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.formatBlock(resBlock.Block, fullTx)
+}
+
+// GetTransactionByHashProxy returns the tx of the hash as method "eth_getTransactionByHash" without rest server routing
+// Note: nil is returned without error if the tx is not found
+func (ec evmClient) GetTransactionByHashProxy(hash ethcmn.Hash) (*types.Transaction, error) {
+	resTx, err := ec.Tx(hash.Bytes(), false)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	resBlock, err := ec.Block(&resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	ethTx, err := ec.decodeEthTx(resTx.Tx, resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return watcher.NewTransaction(ethTx, hash, ethcmn.BytesToHash(resBlock.Block.Hash()), uint64(resTx.Height),
+		uint64(resTx.Index))
+}
+
+// GetTransactionReceiptProxy returns the receipt of the tx as method "eth_getTransactionReceipt" without rest server
+// routing
+// Note: nil is returned without error if the tx is not found
+func (ec evmClient) GetTransactionReceiptProxy(hash ethcmn.Hash) (*types.TransactionReceipt, error) {
+	resTx, err := ec.Tx(hash.Bytes(), false)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	receipt, ethTx, err := ec.ethReceipt(resTx)
+	if err != nil {
+		return nil, err
+	}
+
+	var contractAddr *ethcmn.Address
+	if receipt.ContractAddress != (ethcmn.Address{}) {
+		contractAddr = &receipt.ContractAddress
+	}
+
+	return &types.TransactionReceipt{
+		Status:            hexutil.Uint64(receipt.Status),
+		CumulativeGasUsed: hexutil.Uint64(receipt.CumulativeGasUsed),
+		LogsBloom:         receipt.Bloom,
+		Logs:              receipt.Logs,
+		TransactionHash:   receipt.TxHash.String(),
+		ContractAddress:   contractAddr,
+		GasUsed:           hexutil.Uint64(receipt.GasUsed),
+		BlockHash:         receipt.BlockHash.String(),
+		BlockNumber:       hexutil.Uint64(receipt.BlockNumber.Uint64()),
+		TransactionIndex:  hexutil.Uint64(receipt.TransactionIndex),
+		From:              ethTx.EthereumAddress().String(),
+		To:                ethTx.To(),
+	}, nil
+}
+
+// GetLogsProxy returns the logs matching the filter query as method "eth_getLogs" without rest server routing
+// Note: the latest block is used if the from or the to block of the query is nil, and at most MaxLogsBlockRange blocks
+// are scanned in a query
+func (ec evmClient) GetLogsProxy(query ethereum.FilterQuery) ([]*ethcore.Log, error) {
+	fromHeight, toHeight, err := ec.logsRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*ethcore.Log, 0)
+	for height := fromHeight; height <= toHeight; height++ {
+		blockLogs, err := ec.matchedLogs(height, query.Addresses, query.Topics)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, blockLogs...)
+	}
+
+	return logs, nil
+}
+
 func (ec evmClient) accountNonce(addr ethcmn.Address) (nonce uint64, err error) {
-	// get account info from chain
+	account, err := ec.accountAt(addr, 0)
+	if err != nil {
+		return
+	}
+
+	if account == nil {
+		return nonce, errors.New("failed. your account has no record on the chain")
+	}
+
+	return account.GetSequence(), nil
+}
+
+// accountAt gets the account on the height, which is nil without error if the account has no record on the chain
+func (ec evmClient) accountAt(addr ethcmn.Address, height int64) (account exported.Account, err error) {
 	path := fmt.Sprintf("custom/%s/%s", auth.QuerierRoute, auth.QueryAccount)
 	bytes, err := ec.GetCodec().MarshalJSON(authtypes.NewQueryAccountParams(addr.Bytes()))
 	if err != nil {
-		return nonce, utils.ErrClientQuery(err.Error())
+		return account, utils.ErrMarshalJSON(err.Error())
 	}
 
-	res, _, err := ec.Query(path, bytes)
-	if res == nil {
-		return nonce, errors.New("failed. your account has no record on the chain")
+	res, _, err := ec.QueryWithHeight(path, bytes, height)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return nil, nil
+		}
+		return account, utils.ErrClientQuery(err.Error())
 	}
 
-	var account exported.Account
 	if err = ec.GetCodec().UnmarshalJSON(res, &account); err != nil {
-		return nonce, utils.ErrUnmarshalJSON(err.Error())
+		return account, utils.ErrUnmarshalJSON(err.Error())
 	}
 
-	return account.GetSequence(), err
+	return
 }
 
-func (ec evmClient) doCallProxy(args rpctypes.CallArgs, globalGasCap *big.Int) (
+func (ec evmClient) doCallProxy(args rpctypes.CallArgs, globalGasCap *big.Int, height int64) (
 	simResponse *sdk.SimulationResponse, err error) {
 	if args.From == nil {
 		return simResponse, errors.New("failed. empty from address in CallArgs")
@@ -112,25 +364,276 @@ func (ec evmClient) doCallProxy(args rpctypes.CallArgs, globalGasCap *big.Int) (
 
 	simMsg := evmtypes.NewMsgEthereumTx(nonce, args.To, value, gas, gasPrice, data)
 
-	var stdSig authtypes.StdSignature
-	tx := authtypes.NewStdTx([]sdk.Msg{simMsg}, authtypes.StdFee{}, []authtypes.StdSignature{stdSig}, "")
-	if err = tx.ValidateBasic(); err != nil {
+	// the unsigned ethereum tx in rlp is simulated as the sender in the path, same as the rpc of the chain does
+	txBytes, err := authcli.GetTxEncoder(nil, authcli.WithEthereumTx())(simMsg)
+	if err != nil {
+		return simResponse, fmt.Errorf("failed. encode the ethereum tx to simulate error: %s", err)
+	}
+
+	// Transaction simulation through query
+	res, _, err := ec.QueryWithHeight(fmt.Sprintf("app/simulate/%s", args.From.String()), txBytes, height)
+	if err != nil {
 		return
 	}
 
-	cdc := ec.GetCodec()
-	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	simResponse = new(sdk.SimulationResponse)
+
+	return simResponse, ec.GetCodec().UnmarshalBinaryBare(res, simResponse)
+}
+
+// hashToHeight gets the height of the block with the ethereum-formatted hash
+func (ec evmClient) hashToHeight(hash ethcmn.Hash) (int64, error) {
+	path := fmt.Sprintf("custom/%s/%s/%s", evmtypes.RouterKey, evmtypes.QueryHashToHeight, hash.Hex())
+	res, _, err := ec.Query(path, nil)
+	if err != nil {
+		return 0, utils.ErrClientQuery(err.Error())
+	}
+
+	var resBlockNumber evmtypes.QueryResBlockNumber
+	if err = ec.GetCodec().UnmarshalJSON(res, &resBlockNumber); err != nil {
+		return 0, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return resBlockNumber.Number, nil
+}
+
+// blockBloom gets the bloom of all the logs in the block of the height
+func (ec evmClient) blockBloom(height int64) (bloom ethcore.Bloom, err error) {
+	path := fmt.Sprintf("custom/%s/%s/%d", evmtypes.RouterKey, evmtypes.QueryBloom, height)
+	res, _, err := ec.QueryWithHeight(path, nil, height)
+	if err != nil {
+		return bloom, utils.ErrClientQuery(err.Error())
+	}
+
+	var resBloom evmtypes.QueryBloomFilter
+	if err = ec.GetCodec().UnmarshalJSON(res, &resBloom); err != nil {
+		return bloom, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return resBloom.Bloom, nil
+}
+
+// blockLogs gets all the logs emitted by the evm txs in the block of the height
+func (ec evmClient) blockLogs(height int64) (logs []*ethcore.Log, err error) {
+	resBlock, err := ec.Block(&height)
 	if err != nil {
 		return
 	}
 
-	// Transaction simulation through query
-	res, _, err := ec.Query("app/simulate", txBytes)
+	for _, tx := range resBlock.Block.Txs {
+		resTx, err := ec.Tx(tx.Hash(), false)
+		if err != nil {
+			return nil, err
+		}
+
+		if !resTx.TxResult.IsOK() {
+			continue
+		}
+
+		// skip the txs that aren't evm txs
+		resultData, err := evmtypes.DecodeResultData(resTx.TxResult.Data)
+		if err != nil {
+			continue
+		}
+
+		for _, log := range resultData.Logs {
+			if int64(log.BlockNumber) == height {
+				logs = append(logs, log)
+			}
+		}
+	}
+
+	return
+}
+
+// matchedLogs gets the logs matching the addresses and the topics in the block of the height, where the block isn't
+// fetched if missed by the bloom
+func (ec evmClient) matchedLogs(height int64, addrs []ethcmn.Address, topics [][]ethcmn.Hash) ([]*ethcore.Log,
+	error) {
+	bloom, err := ec.blockBloom(height)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bloomMatches(bloom, addrs, topics) {
+		return nil, nil
+	}
+
+	logs, err := ec.blockLogs(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterLogs(logs, addrs, topics), nil
+}
+
+// logsRange resolves the range of the block heights to scan for the filter query
+func (ec evmClient) logsRange(query ethereum.FilterQuery) (fromHeight, toHeight int64, err error) {
+	if query.BlockHash != nil {
+		if query.FromBlock != nil || query.ToBlock != nil {
+			return fromHeight, toHeight, errors.New("failed. block hash and block range can't be both set")
+		}
+
+		height, err := ec.hashToHeight(*query.BlockHash)
+		if err != nil {
+			return fromHeight, toHeight, err
+		}
+
+		return height, height, nil
+	}
+
+	latest, err := ec.BlockNumberProxy()
 	if err != nil {
 		return
 	}
 
-	simResponse = new(sdk.SimulationResponse)
+	fromHeight, toHeight = int64(latest), int64(latest)
+	if query.FromBlock != nil && query.FromBlock.Sign() >= 0 {
+		fromHeight = query.FromBlock.Int64()
+	}
+	if query.ToBlock != nil && query.ToBlock.Sign() >= 0 {
+		toHeight = query.ToBlock.Int64()
+	}
+
+	if fromHeight > toHeight {
+		return fromHeight, toHeight, fmt.Errorf("failed. from block %d is greater than to block %d", fromHeight,
+			toHeight)
+	}
+	if toHeight-fromHeight+1 > types.MaxLogsBlockRange {
+		return fromHeight, toHeight, fmt.Errorf("failed. block range [%d, %d] exceeds the max %d", fromHeight,
+			toHeight, types.MaxLogsBlockRange)
+	}
+
+	return
+}
+
+// formatBlock converts the tendermint block to the ethereum-formatted one
+func (ec evmClient) formatBlock(block *tmtypes.Block, fullTx bool) (*types.Block, error) {
+	blockHash := ethcmn.BytesToHash(block.Hash())
+	gasUsed := new(big.Int)
+	var txs []*types.Transaction
+	for i, tx := range block.Txs {
+		ethTx, err := ec.decodeEthTx(tx, block.Height)
+		if err != nil {
+			// skip the txs that aren't evm txs
+			continue
+		}
+
+		// the index is the position in the block, the same as the one in the tx result and the receipt
+		gasUsed.Add(gasUsed, new(big.Int).SetUint64(ethTx.GetGas()))
+		rpcTx, err := watcher.NewTransaction(ethTx, ethcmn.BytesToHash(tx.Hash()), blockHash, uint64(block.Height),
+			uint64(i))
+		if err == nil {
+			txs = append(txs, rpcTx)
+		}
+	}
+
+	bloom, err := ec.blockBloom(block.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpctypes.FormatBlock(block.Header, block.Size(), block.Hash(), int64(^uint32(0)), gasUsed, txs, bloom,
+		fullTx), nil
+}
 
-	return simResponse, cdc.UnmarshalBinaryBare(res, simResponse)
+// decodeEthTx decodes the raw tx into the evm tx
+func (ec evmClient) decodeEthTx(txBytes tmtypes.Tx, height int64) (*evmtypes.MsgEthereumTx, error) {
+	tx, err := evmtypes.TxDecoder(ec.GetCodec())(txBytes, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed. decode tx error: %s", err)
+	}
+
+	ethTx, ok := tx.(*evmtypes.MsgEthereumTx)
+	if !ok {
+		return nil, fmt.Errorf("failed. invalid tx type %T, expected %T", tx, &evmtypes.MsgEthereumTx{})
+	}
+
+	return ethTx, nil
+}
+
+// queryHeight converts the block number to the height to query with, where both the latest and the pending are
+// treated as the latest
+func queryHeight(blockNum rpctypes.BlockNumber) int64 {
+	if blockNum < 0 {
+		return 0
+	}
+
+	return blockNum.Int64()
+}
+
+// isNotFound checks whether the error shows that the tx or the block queried doesn't exist
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
+
+// bloomMatches checks whether the logs matching the addresses and the topics may be included in the bloom
+func bloomMatches(bloom ethcore.Bloom, addrs []ethcmn.Address, topics [][]ethcmn.Hash) bool {
+	if len(addrs) > 0 {
+		var included bool
+		for _, addr := range addrs {
+			if ethcore.BloomLookup(bloom, addr) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	for _, sub := range topics {
+		included := len(sub) == 0
+		for _, topic := range sub {
+			if ethcore.BloomLookup(bloom, topic) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterLogs returns the logs matching the addresses and the topics. An empty rule of the topics matches anything
+// in the position
+func filterLogs(logs []*ethcore.Log, addrs []ethcmn.Address, topics [][]ethcmn.Hash) (ret []*ethcore.Log) {
+Logs:
+	for _, log := range logs {
+		if len(addrs) > 0 {
+			var included bool
+			for _, addr := range addrs {
+				if log.Address == addr {
+					included = true
+					break
+				}
+			}
+			if !included {
+				continue
+			}
+		}
+
+		if len(topics) > len(log.Topics) {
+			continue
+		}
+		for i, sub := range topics {
+			match := len(sub) == 0
+			for _, topic := range sub {
+				if log.Topics[i] == topic {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue Logs
+			}
+		}
+
+		ret = append(ret, log)
+	}
+
+	return
 }
@@ -0,0 +1,450 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcore "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/auth"
+	"github.com/okex/exchain-go-sdk/module/evm/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	rpctypes "github.com/okx/okbchain/app/rpc/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	authtypes "github.com/okx/okbchain/libs/cosmos-sdk/x/auth/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newSignedEthTx(t *testing.T, chainID *big.Int, nonce, gasLimit uint64) tmtypes.Tx {
+	priv, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	to := ethcmn.HexToAddress(recAddrEth)
+	ethMsg := evmtypes.NewMsgEthereumTx(nonce, &to, big.NewInt(1024), gasLimit, big.NewInt(1), nil)
+	require.NoError(t, ethMsg.Sign(chainID, priv))
+	bytes, err := rlp.EncodeToBytes(&ethMsg)
+	require.NoError(t, err)
+
+	return bytes
+}
+
+func TestEvmClient_GetBalanceProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	ethAddr := ethcmn.BytesToAddress(accAddr.Bytes())
+	expectedRet := mockCli.BuildAccountBytes(addr, accPubkey, "", fmt.Sprintf("1024%s", sdk.DefaultBondDenom),
+		1, 8)
+	expectedPath := fmt.Sprintf("custom/%s/%s", authtypes.QuerierRoute, authtypes.QueryAccount)
+	expectedKey := mockCli.GetCodec().MustMarshalJSON(authtypes.NewQueryAccountParams(ethAddr.Bytes()))
+
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+	mockCli.EXPECT().QueryWithHeight(expectedPath, gomock.Eq(expectedKey), int64(10)).
+		Return(expectedRet, int64(10), nil).Times(2)
+
+	balance, err := mockCli.Evm().Web3Proxy().GetBalanceProxy(ethAddr, 10)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(1024).BigInt(), balance.ToInt())
+
+	nonce, err := mockCli.Evm().Web3Proxy().GetTransactionCountProxy(ethAddr, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), uint64(*nonce))
+
+	// the pending is treated as the latest, and an account without record has nothing
+	mockCli.EXPECT().QueryWithHeight(expectedPath, gomock.Eq(expectedKey), int64(0)).
+		Return(nil, int64(0), fmt.Errorf("account %s does not exist", accAddr)).Times(2)
+	balance, err = mockCli.Evm().Web3Proxy().GetBalanceProxy(ethAddr, rpctypes.PendingBlockNumber)
+	require.NoError(t, err)
+	require.Zero(t, balance.ToInt().Sign())
+
+	nonce, err = mockCli.Evm().Web3Proxy().GetTransactionCountProxy(ethAddr, rpctypes.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Zero(t, uint64(*nonce))
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, gomock.Eq(expectedKey), int64(0)).
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Evm().Web3Proxy().GetBalanceProxy(ethAddr, rpctypes.LatestBlockNumber)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, gomock.Eq(expectedKey), int64(0)).
+		Return(expectedRet[1:], int64(0), nil)
+	_, err = mockCli.Evm().Web3Proxy().GetTransactionCountProxy(ethAddr, rpctypes.LatestBlockNumber)
+	require.Error(t, err)
+}
+
+func TestEvmClient_GetCodeProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	contract := ethcmn.HexToAddress(contractAddr)
+	codePath := fmt.Sprintf("custom/%s/code/%s", evmtypes.RouterKey, contract.Hex())
+	storagePath := fmt.Sprintf("custom/%s/storage/%s/%s", evmtypes.RouterKey, contract.Hex(),
+		ethcmn.HexToHash(keyStr).Hex())
+
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+	mockCli.EXPECT().QueryWithHeight(codePath, nil, int64(10)).
+		Return(mockCli.BuildQueryResCode(codeContent), int64(10), nil)
+	mockCli.EXPECT().QueryWithHeight(storagePath, nil, int64(0)).
+		Return(mockCli.BuildQueryResStorage(storageValue), int64(10), nil)
+
+	code, err := mockCli.Evm().Web3Proxy().GetCodeProxy(contract, 10)
+	require.NoError(t, err)
+	require.Equal(t, codeContent, string(code))
+
+	value, err := mockCli.Evm().Web3Proxy().GetStorageAtProxy(contract, keyStr, rpctypes.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, storageValue, string(value))
+
+	mockCli.EXPECT().QueryWithHeight(codePath, nil, int64(0)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Evm().Web3Proxy().GetCodeProxy(contract, rpctypes.PendingBlockNumber)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(storagePath, nil, int64(0)).Return([]byte("bad"), int64(0), nil)
+	_, err = mockCli.Evm().Web3Proxy().GetStorageAtProxy(contract, keyStr, rpctypes.LatestBlockNumber)
+	require.Error(t, err)
+}
+
+func TestEvmClient_GetTransactionReceiptProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	height := int64(10)
+	prevTx, tx := newSignedEthTx(t, config.ChainIDBigInt, 0, 30000), newSignedEthTx(t, config.ChainIDBigInt, 1, 50000)
+	hash := ethcmn.BytesToHash(tx.Hash())
+	contract := ethcmn.HexToAddress(contractAddr)
+	log := &ethcore.Log{Address: contract, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x01")}, BlockNumber: 10}
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{
+		Bloom: ethcore.BytesToBloom(ethcore.LogsBloom([]*ethcore.Log{log})),
+		Logs:  []*ethcore.Log{log},
+	})
+	require.NoError(t, err)
+
+	block := &tmtypes.Block{
+		Header:     tmtypes.Header{ChainID: config.ChainID, Height: height, ValidatorsHash: []byte("validators")},
+		LastCommit: &tmtypes.Commit{},
+		Data:       tmtypes.Data{Txs: tmtypes.Txs{prevTx, tx}},
+	}
+	resTx := &ctypes.ResultTx{
+		Hash:     tx.Hash(),
+		Height:   height,
+		Index:    1,
+		Tx:       tx,
+		TxResult: abci.ResponseDeliverTx{Data: data, GasUsed: 21000},
+	}
+	blockResults := &ctypes.ResultBlockResults{
+		Height:     height,
+		TxsResults: []*abci.ResponseDeliverTx{{GasUsed: 30000}, &resTx.TxResult},
+	}
+
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+	mockCli.EXPECT().Tx(hash.Bytes(), false).Return(resTx, nil).Times(2)
+	mockCli.EXPECT().Block(&height).Return(&ctypes.ResultBlock{Block: block}, nil).Times(3)
+	mockCli.EXPECT().BlockResults(&height).Return(blockResults, nil).Times(2)
+
+	receipt, err := mockCli.Evm().Web3Proxy().GetTransactionReceiptProxy(hash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), uint64(receipt.Status))
+	require.Equal(t, uint64(51000), uint64(receipt.CumulativeGasUsed))
+	require.Equal(t, uint64(21000), uint64(receipt.GasUsed))
+	require.Equal(t, hash.String(), receipt.TransactionHash)
+	require.Equal(t, ethcmn.BytesToHash(block.Hash()).String(), receipt.BlockHash)
+	require.Equal(t, uint64(1), uint64(receipt.TransactionIndex))
+	require.Equal(t, 1, len(receipt.Logs))
+	require.True(t, ethcore.BloomLookup(receipt.LogsBloom, contract))
+	require.Nil(t, receipt.ContractAddress)
+	require.Equal(t, recAddrEth, receipt.To.Hex())
+	require.NotEmpty(t, receipt.From)
+
+	// the same as the receipt of the evm client
+	ethReceipt, err := mockCli.Evm().GetReceipt(resTx)
+	require.NoError(t, err)
+	require.Equal(t, ethReceipt.Receipt.Logs, receipt.Logs)
+	require.Equal(t, ethReceipt.Receipt.CumulativeGasUsed, uint64(receipt.CumulativeGasUsed))
+	require.Equal(t, ethReceipt.From.String(), receipt.From)
+
+	rpcTx, err := mockCli.Evm().Web3Proxy().GetTransactionByHashProxy(hash)
+	require.NoError(t, err)
+	require.Equal(t, hash, rpcTx.Hash)
+	require.Equal(t, uint64(1), uint64(rpcTx.Nonce))
+	require.Equal(t, height, rpcTx.BlockNumber.ToInt().Int64())
+	require.Equal(t, receipt.From, rpcTx.From.Hex())
+
+	// failed tx without logs
+	resTx.TxResult.Code = 1
+	mockCli.EXPECT().Tx(hash.Bytes(), false).Return(resTx, nil)
+	mockCli.EXPECT().Block(&height).Return(&ctypes.ResultBlock{Block: block}, nil)
+	mockCli.EXPECT().BlockResults(&height).Return(blockResults, nil)
+	receipt, err = mockCli.Evm().Web3Proxy().GetTransactionReceiptProxy(hash)
+	require.NoError(t, err)
+	require.Zero(t, uint64(receipt.Status))
+	require.Empty(t, receipt.Logs)
+	require.Equal(t, ethcore.Bloom{}, receipt.LogsBloom)
+
+	// not found
+	mockCli.EXPECT().Tx(hash.Bytes(), false).
+		Return(nil, fmt.Errorf("tx (%X) not found", hash.Bytes())).Times(2)
+	receipt, err = mockCli.Evm().Web3Proxy().GetTransactionReceiptProxy(hash)
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+	rpcTx, err = mockCli.Evm().Web3Proxy().GetTransactionByHashProxy(hash)
+	require.NoError(t, err)
+	require.Nil(t, rpcTx)
+
+	mockCli.EXPECT().Tx(hash.Bytes(), false).Return(nil, errors.New("default error"))
+	_, err = mockCli.Evm().Web3Proxy().GetTransactionReceiptProxy(hash)
+	require.Error(t, err)
+}
+
+func TestEvmClient_GetBlockProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	height := int64(10)
+	tx := newSignedEthTx(t, config.ChainIDBigInt, 0, 30000)
+	block := &tmtypes.Block{
+		Header:     tmtypes.Header{ChainID: config.ChainID, Height: height, ValidatorsHash: []byte("validators")},
+		LastCommit: &tmtypes.Commit{},
+		Data:       tmtypes.Data{Txs: tmtypes.Txs{[]byte("not an evm tx"), tx}},
+	}
+	blockHash := ethcmn.BytesToHash(block.Hash())
+	bloomPath := fmt.Sprintf("custom/%s/%s/%d", evmtypes.RouterKey, evmtypes.QueryBloom, height)
+	hashPath := fmt.Sprintf("custom/%s/%s/%s", evmtypes.RouterKey, evmtypes.QueryHashToHeight, blockHash.Hex())
+
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(cdc).AnyTimes()
+	mockCli.EXPECT().Block(&height).Return(&ctypes.ResultBlock{Block: block}, nil).Times(2)
+	mockCli.EXPECT().QueryWithHeight(bloomPath, nil, height).
+		Return(cdc.MustMarshalJSON(evmtypes.QueryBloomFilter{}), height, nil).Times(2)
+	mockCli.EXPECT().Query(hashPath, nil).
+		Return(cdc.MustMarshalJSON(evmtypes.QueryResBlockNumber{Number: height}), height, nil)
+
+	ethBlock, err := mockCli.Evm().Web3Proxy().GetBlockByNumberProxy(rpctypes.BlockNumber(height), false)
+	require.NoError(t, err)
+	require.Equal(t, blockHash, ethBlock.Hash)
+	require.Equal(t, uint64(height), uint64(ethBlock.Number))
+	require.Equal(t, int64(30000), ethBlock.GasUsed.ToInt().Int64())
+	require.Equal(t, []ethcmn.Hash{ethcmn.BytesToHash(tx.Hash())}, ethBlock.Transactions)
+
+	ethBlock, err = mockCli.Evm().Web3Proxy().GetBlockByHashProxy(blockHash, true)
+	require.NoError(t, err)
+	txs, ok := ethBlock.Transactions.([]*types.Transaction)
+	require.True(t, ok)
+	require.Equal(t, 1, len(txs))
+	require.Equal(t, blockHash, *txs[0].BlockHash)
+	// the position in the block
+	require.Equal(t, uint64(1), uint64(*txs[0].TransactionIndex))
+
+	mockCli.EXPECT().Query(hashPath, nil).
+		Return(nil, int64(0), fmt.Errorf("block height not found for hash %s", blockHash.Hex()))
+	ethBlock, err = mockCli.Evm().Web3Proxy().GetBlockByHashProxy(blockHash, true)
+	require.NoError(t, err)
+	require.Nil(t, ethBlock)
+
+	mockCli.EXPECT().Block(nil).Return(nil, errors.New("default error"))
+	_, err = mockCli.Evm().Web3Proxy().GetBlockByNumberProxy(rpctypes.PendingBlockNumber, false)
+	require.Error(t, err)
+}
+
+func TestEvmClient_GetLogsProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	height := int64(10)
+	tx := newSignedEthTx(t, config.ChainIDBigInt, 0, 30000)
+	contract := ethcmn.HexToAddress(contractAddr)
+	topicA, topicB := ethcmn.HexToHash("0x0a"), ethcmn.HexToHash("0x0b")
+	logs := []*ethcore.Log{
+		{Address: contract, Topics: []ethcmn.Hash{topicA}, BlockNumber: 10},
+		{Address: contract, Topics: []ethcmn.Hash{topicB, topicA}, BlockNumber: 10},
+	}
+	bloom := ethcore.BytesToBloom(ethcore.LogsBloom(logs))
+	data, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Bloom: bloom, Logs: logs})
+	require.NoError(t, err)
+
+	block := &tmtypes.Block{
+		Header:     tmtypes.Header{ChainID: config.ChainID, Height: height, ValidatorsHash: []byte("validators")},
+		LastCommit: &tmtypes.Commit{},
+		Data:       tmtypes.Data{Txs: tmtypes.Txs{tx}},
+	}
+	bloomPath := fmt.Sprintf("custom/%s/%s/%d", evmtypes.RouterKey, evmtypes.QueryBloom, height)
+
+	cdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(cdc).AnyTimes()
+	mockCli.EXPECT().BlockchainInfo(int64(0), int64(0)).
+		Return(&ctypes.ResultBlockchainInfo{LastHeight: height + 1}, nil).Times(5)
+	mockCli.EXPECT().QueryWithHeight(bloomPath, nil, height).
+		Return(cdc.MustMarshalJSON(evmtypes.QueryBloomFilter{Bloom: bloom}), height, nil).Times(3)
+	mockCli.EXPECT().Block(&height).Return(&ctypes.ResultBlock{Block: block}, nil).Times(2)
+	mockCli.EXPECT().Tx(tx.Hash(), false).
+		Return(&ctypes.ResultTx{Hash: tx.Hash(), Height: height, Tx: tx, TxResult: abci.ResponseDeliverTx{Data: data}},
+			nil).Times(2)
+
+	// [null, A] matches the second log only
+	ret, err := mockCli.Evm().Web3Proxy().GetLogsProxy(ethereum.FilterQuery{
+		Addresses: []ethcmn.Address{contract},
+		Topics:    [][]ethcmn.Hash{{}, {topicA}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ethcore.Log{logs[1]}, ret)
+
+	ret, err = mockCli.Evm().Web3Proxy().GetLogsProxy(ethereum.FilterQuery{
+		FromBlock: big.NewInt(height),
+		Topics:    [][]ethcmn.Hash{{topicA, topicB}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, logs, ret)
+
+	// missed by the bloom without fetching the block
+	ret, err = mockCli.Evm().Web3Proxy().GetLogsProxy(ethereum.FilterQuery{
+		Addresses: []ethcmn.Address{ethcmn.HexToAddress(recAddrEth)},
+	})
+	require.NoError(t, err)
+	require.Empty(t, ret)
+
+	// bad ranges
+	_, err = mockCli.Evm().Web3Proxy().GetLogsProxy(ethereum.FilterQuery{
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(5000),
+	})
+	require.Error(t, err)
+
+	_, err = mockCli.Evm().Web3Proxy().GetLogsProxy(ethereum.FilterQuery{
+		FromBlock: big.NewInt(20),
+		ToBlock:   big.NewInt(10),
+	})
+	require.Error(t, err)
+
+	blockHash := ethcmn.BytesToHash(block.Hash())
+	_, err = mockCli.Evm().Web3Proxy().GetLogsProxy(ethereum.FilterQuery{
+		BlockHash: &blockHash,
+		FromBlock: big.NewInt(height),
+	})
+	require.Error(t, err)
+}
+
+func TestEvmClient_SendRawTransactionProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastSync, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient))
+
+	tx := newSignedEthTx(t, config.ChainIDBigInt, 0, 30000)
+	hashHexStr := fmt.Sprintf("%X", tx.Hash())
+
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+	mockCli.EXPECT().GetConfig().Return(config).AnyTimes()
+	mockCli.EXPECT().Broadcast(gomock.Eq(tx), gosdktypes.BroadcastSync).
+		Return(sdk.TxResponse{TxHash: hashHexStr}, nil)
+
+	hash, err := mockCli.Evm().Web3Proxy().SendRawTransactionProxy([]byte(tx))
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.BytesToHash(tx.Hash()), hash)
+
+	mockCli.EXPECT().Broadcast(gomock.Eq(tx), gosdktypes.BroadcastSync).
+		Return(sdk.TxResponse{TxHash: hashHexStr, Code: 4, RawLog: "unauthorized"}, nil)
+	_, err = mockCli.Evm().Web3Proxy().SendRawTransactionProxy([]byte(tx))
+	require.Error(t, err)
+
+	mockCli.EXPECT().Broadcast(gomock.Eq(tx), gosdktypes.BroadcastSync).
+		Return(sdk.TxResponse{}, errors.New("default error"))
+	_, err = mockCli.Evm().Web3Proxy().SendRawTransactionProxy([]byte(tx))
+	require.Error(t, err)
+
+	_, err = mockCli.Evm().Web3Proxy().SendRawTransactionProxy([]byte("not an evm tx"))
+	require.Error(t, err)
+}
+
+func TestEvmClient_CallProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewEvmClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	from, to := ethcmn.BytesToAddress(accAddr.Bytes()), ethcmn.HexToAddress(contractAddr)
+	input, gas := hexutil.Bytes{0x01, 0x02}, hexutil.Uint64(30000)
+	args := rpctypes.CallArgs{From: &from, To: &to, Gas: &gas, Data: &input}
+
+	accountPath := fmt.Sprintf("custom/%s/%s", authtypes.QuerierRoute, authtypes.QueryAccount)
+	accountKey := mockCli.GetCodec().MustMarshalJSON(authtypes.NewQueryAccountParams(from.Bytes()))
+	simPath := fmt.Sprintf("app/simulate/%s", from.String())
+	resultData, err := evmtypes.EncodeResultData(&evmtypes.ResultData{Ret: []byte("ret")})
+	require.NoError(t, err)
+	simRes := mockCli.GetCodec().MustMarshalBinaryBare(sdk.SimulationResponse{
+		GasInfo: sdk.GasInfo{GasUsed: 21000},
+		Result:  &sdk.Result{Data: resultData},
+	})
+
+	// the unsigned ethereum tx with the nonce of the sender in rlp
+	checkSimTx := func(_ string, txBytes []byte, _ int64) {
+		var simMsg evmtypes.MsgEthereumTx
+		require.NoError(t, rlp.DecodeBytes(txBytes, &simMsg))
+		require.Equal(t, uint64(8), simMsg.Data.AccountNonce)
+		require.Equal(t, &to, simMsg.To())
+		require.Equal(t, uint64(gas), simMsg.Data.GasLimit)
+		require.Equal(t, []byte(input), simMsg.Data.Payload)
+	}
+
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+	mockCli.EXPECT().QueryWithHeight(accountPath, gomock.Eq(accountKey), int64(0)).
+		Return(mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okt", 1, 8), int64(0), nil).Times(2)
+	mockCli.EXPECT().QueryWithHeight(simPath, gomock.Any(), int64(10)).Do(checkSimTx).Return(simRes, int64(10), nil)
+	mockCli.EXPECT().QueryWithHeight(simPath, gomock.Any(), int64(0)).Do(checkSimTx).Return(simRes, int64(0), nil)
+
+	ret, err := mockCli.Evm().Web3Proxy().CallProxy(args, 10)
+	require.NoError(t, err)
+	require.Equal(t, []byte("ret"), []byte(ret))
+
+	estimatedGas, err := mockCli.Evm().Web3Proxy().EstimateGasProxy(args)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(22000), estimatedGas)
+
+	_, err = mockCli.Evm().Web3Proxy().EstimateGasProxy(rpctypes.CallArgs{})
+	require.Error(t, err)
+}
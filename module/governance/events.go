@@ -0,0 +1,93 @@
+package governance
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/okex/exchain-go-sdk/module/governance/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	govtypes "github.com/okx/okbchain/x/gov/types"
+)
+
+// ParseSubmitProposalEvents decodes all the submit_proposal events
+func (govClient) ParseSubmitProposalEvents(events sdk.StringEvents) (submits []types.SubmitProposalEvent, err error) {
+	for _, attrs := range utils.ParseEventAttributes(events, govtypes.EventTypeSubmitProposal) {
+		// the initial deposit emits a submit_proposal event with the voting_period_start attribute only
+		proposalIDStr, ok := attrs[govtypes.AttributeKeyProposalID]
+		if !ok {
+			continue
+		}
+
+		var submit types.SubmitProposalEvent
+		submit.ProposalID, err = parseProposalID(proposalIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		_, submit.VotingStarted = attrs[govtypes.AttributeKeyVotingPeriodStart]
+
+		submits = append(submits, submit)
+	}
+
+	return
+}
+
+// ParseProposalDepositEvents decodes all the proposal_deposit events
+func (govClient) ParseProposalDepositEvents(events sdk.StringEvents) (deposits []types.ProposalDepositEvent, err error) {
+	for _, attrs := range utils.ParseEventAttributes(events, govtypes.EventTypeProposalDeposit) {
+		var deposit types.ProposalDepositEvent
+		deposit.ProposalID, err = parseProposalID(attrs[govtypes.AttributeKeyProposalID])
+		if err != nil {
+			return nil, err
+		}
+
+		deposit.Amount, err = utils.ParseEventCoins(attrs[sdk.AttributeKeyAmount])
+		if err != nil {
+			return nil, err
+		}
+
+		deposits = append(deposits, deposit)
+	}
+
+	return
+}
+
+// ParseProposalVoteEvents decodes all the proposal_vote events
+func (govClient) ParseProposalVoteEvents(events sdk.StringEvents) (votes []types.ProposalVoteEvent, err error) {
+	for _, attrs := range utils.ParseEventAttributes(events, govtypes.EventTypeProposalVote) {
+		var vote types.ProposalVoteEvent
+		vote.ProposalID, err = parseProposalID(attrs[govtypes.AttributeKeyProposalID])
+		if err != nil {
+			return nil, err
+		}
+
+		vote.Option = attrs[govtypes.AttributeKeyOption]
+		votes = append(votes, vote)
+	}
+
+	return
+}
+
+// GetProposalID gets the id of the proposal submitted in the tx
+func (gc govClient) GetProposalID(resp sdk.TxResponse) (uint64, error) {
+	submits, err := gc.ParseSubmitProposalEvents(utils.GetEventsFromTxResponse(resp))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(submits) == 0 {
+		return 0, utils.ErrEventAttributeNotFound(govtypes.EventTypeSubmitProposal, govtypes.AttributeKeyProposalID)
+	}
+
+	return submits[0].ProposalID, nil
+}
+
+func parseProposalID(proposalIDStr string) (uint64, error) {
+	proposalID, err := strconv.ParseUint(proposalIDStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed. parse proposal id [%s] error: %s", proposalIDStr, err)
+	}
+
+	return proposalID, nil
+}
@@ -0,0 +1,94 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGovClient_GetProposalID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient))
+
+	resp := mocks.DefaultMockSuccessTxResponse()
+	resp.Logs = sdk.ABCIMessageLogs{{
+		Events: sdk.StringEvents{
+			{
+				Type: "proposal_deposit",
+				Attributes: []sdk.Attribute{
+					{Key: "amount", Value: "1.024000000000000000okt"},
+					{Key: "proposal_id", Value: "1024"},
+				},
+			},
+			{
+				Type: "submit_proposal",
+				Attributes: []sdk.Attribute{
+					{Key: "proposal_id", Value: "1024"},
+					{Key: "voting_period_start", Value: "1024"},
+				},
+			},
+		},
+	}}
+
+	proposalID, err := mockCli.Governance().GetProposalID(resp)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1024), proposalID)
+
+	events := resp.Logs[0].Events
+	submits, err := mockCli.Governance().ParseSubmitProposalEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(submits))
+	require.True(t, submits[0].VotingStarted)
+
+	deposits, err := mockCli.Governance().ParseProposalDepositEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deposits))
+	require.Equal(t, uint64(1024), deposits[0].ProposalID)
+	require.Equal(t, "1.024000000000000000okt", deposits[0].Amount.String())
+
+	// bad proposal id
+	resp.Logs[0].Events[1].Attributes[0].Value = "bad proposal id"
+	_, err = mockCli.Governance().GetProposalID(resp)
+	require.Error(t, err)
+
+	// no submit_proposal event
+	_, err = mockCli.Governance().GetProposalID(mocks.DefaultMockSuccessTxResponse())
+	require.Error(t, err)
+}
+
+func TestGovClient_ParseProposalVoteEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient))
+
+	events := sdk.StringEvents{{
+		Type: "proposal_vote",
+		Attributes: []sdk.Attribute{
+			{Key: "option", Value: "Yes"},
+			{Key: "proposal_id", Value: "1024"},
+		},
+	}}
+
+	votes, err := mockCli.Governance().ParseProposalVoteEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(votes))
+	require.Equal(t, uint64(1024), votes[0].ProposalID)
+	require.Equal(t, "Yes", votes[0].Option)
+
+	events[0].Attributes[1].Value = ""
+	_, err = mockCli.Governance().ParseProposalVoteEvents(events)
+	require.Error(t, err)
+}
@@ -5,8 +5,18 @@ import (
 	"github.com/okex/exchain-go-sdk/module/governance/types"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	"github.com/okx/okbchain/libs/cosmos-sdk/codec"
+	ibcclienttypes "github.com/okx/okbchain/libs/ibc-go/modules/core/02-client/types"
+	"github.com/okx/okbchain/libs/system"
+	feesplittypes "github.com/okx/okbchain/x/feesplit/types"
 	"github.com/okx/okbchain/x/gov"
-	paramstypes "github.com/okx/okbchain/x/params/types"
+	"github.com/okx/okbchain/x/params"
+	wasmtypes "github.com/okx/okbchain/x/wasm/types"
+)
+
+const (
+	// Amino names
+	feeSplitSharesProposalName = system.Chain + "/feesplit/SharesProposal"
+	clientUpdateProposalName   = "ibc.core.client.v1.ClientUpdateProposal"
 )
 
 var _ gosdktypes.Module = (*govClient)(nil)
@@ -16,9 +26,20 @@ type govClient struct {
 }
 
 // RegisterCodec registers the msg type in governance module
+// Note: the proposals of evm and distribution are registered by their own modules
 func (gc govClient) RegisterCodec(cdc *codec.Codec) {
 	gov.RegisterCodec(cdc)
-	cdc.RegisterConcrete(paramstypes.ParameterChangeProposal{}, "okexchain/params/ParameterChangeProposal", nil)
+	params.RegisterCodec(cdc)
+	cdc.RegisterConcrete(feesplittypes.FeeSplitSharesProposal{}, feeSplitSharesProposalName, nil)
+	cdc.RegisterConcrete(&ibcclienttypes.ClientUpdateProposal{}, clientUpdateProposalName, nil)
+	cdc.RegisterConcrete(&wasmtypes.MigrateContractProposal{}, "wasm/MigrateContractProposal", nil)
+	cdc.RegisterConcrete(&wasmtypes.UpdateAdminProposal{}, "wasm/UpdateAdminProposal", nil)
+	cdc.RegisterConcrete(&wasmtypes.ClearAdminProposal{}, "wasm/ClearAdminProposal", nil)
+	cdc.RegisterConcrete(&wasmtypes.PinCodesProposal{}, "wasm/PinCodesProposal", nil)
+	cdc.RegisterConcrete(&wasmtypes.UnpinCodesProposal{}, "wasm/UnpinCodesProposal", nil)
+	cdc.RegisterConcrete(&wasmtypes.UpdateDeploymentWhitelistProposal{}, "wasm/UpdateDeploymentWhitelistProposal", nil)
+	cdc.RegisterConcrete(&wasmtypes.UpdateWASMContractMethodBlockedListProposal{},
+		"wasm/UpdateWASMContractMethodBlockedListProposal", nil)
 }
 
 // Name returns the module name
@@ -64,3 +64,128 @@ func (gc govClient) QueryProposals(depositorAddrStr, voterAddrStr, status string
 
 	return
 }
+
+// QueryProposal gets the detail of a proposal by its ID
+func (gc govClient) QueryProposal(proposalID uint64) (proposal types.Proposal, err error) {
+	jsonBytes, err := gc.GetCodec().MarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	if err != nil {
+		return proposal, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = gc.query(govtypes.QueryProposal, jsonBytes, &proposal)
+	return
+}
+
+// QueryVotes gets all the votes on a proposal
+func (gc govClient) QueryVotes(proposalID uint64) (votes []types.Vote, err error) {
+	jsonBytes, err := gc.GetCodec().MarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	if err != nil {
+		return votes, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = gc.query(govtypes.QueryVotes, jsonBytes, &votes)
+	return
+}
+
+// QueryVote gets the vote of a voter on a proposal
+// Note: the voter address is accepted in both bech32 and hex format
+func (gc govClient) QueryVote(proposalID uint64, voterAddrStr string) (vote types.Vote, err error) {
+	voterAddr, err := utils.ToCosmosAddress(voterAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := gc.GetCodec().MarshalJSON(govtypes.NewQueryVoteParams(proposalID, voterAddr))
+	if err != nil {
+		return vote, utils.ErrMarshalJSON(err.Error())
+	}
+
+	if err = gc.query(govtypes.QueryVote, jsonBytes, &vote); err != nil {
+		return
+	}
+
+	// an empty vote is returned by the chain if the voter hasn't voted
+	if vote.Voter.Empty() {
+		return vote, fmt.Errorf("failed. %s hasn't voted on proposal %d", voterAddr, proposalID)
+	}
+
+	return
+}
+
+// QueryDeposits gets all the deposits on a proposal
+func (gc govClient) QueryDeposits(proposalID uint64) (deposits []types.Deposit, err error) {
+	jsonBytes, err := gc.GetCodec().MarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	if err != nil {
+		return deposits, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = gc.query(govtypes.QueryDeposits, jsonBytes, &deposits)
+	return
+}
+
+// QueryDeposit gets the deposit of a depositor on a proposal
+// Note: the depositor address is accepted in both bech32 and hex format
+func (gc govClient) QueryDeposit(proposalID uint64, depositorAddrStr string) (deposit types.Deposit, err error) {
+	depositorAddr, err := utils.ToCosmosAddress(depositorAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := gc.GetCodec().MarshalJSON(govtypes.NewQueryDepositParams(proposalID, depositorAddr))
+	if err != nil {
+		return deposit, utils.ErrMarshalJSON(err.Error())
+	}
+
+	if err = gc.query(govtypes.QueryDeposit, jsonBytes, &deposit); err != nil {
+		return
+	}
+
+	// an empty deposit is returned by the chain if the depositor hasn't deposited
+	if deposit.Depositor.Empty() {
+		return deposit, fmt.Errorf("failed. %s hasn't deposited on proposal %d", depositorAddr, proposalID)
+	}
+
+	return
+}
+
+// QueryTally gets the tally result of a proposal. It's the live tally during the voting period, and the final one
+// after the proposal is closed
+func (gc govClient) QueryTally(proposalID uint64) (tallyResult types.TallyResult, err error) {
+	jsonBytes, err := gc.GetCodec().MarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	if err != nil {
+		return tallyResult, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = gc.query(govtypes.QueryTally, jsonBytes, &tallyResult)
+	return
+}
+
+// QueryParams gets the current deposit, voting and tallying params of governance module
+func (gc govClient) QueryParams() (govParams types.Params, err error) {
+	if err = gc.query(fmt.Sprintf("%s/%s", govtypes.QueryParams, govtypes.ParamDeposit), nil,
+		&govParams.DepositParams); err != nil {
+		return
+	}
+
+	if err = gc.query(fmt.Sprintf("%s/%s", govtypes.QueryParams, govtypes.ParamVoting), nil,
+		&govParams.VotingParams); err != nil {
+		return
+	}
+
+	err = gc.query(fmt.Sprintf("%s/%s", govtypes.QueryParams, govtypes.ParamTallying), nil, &govParams.TallyParams)
+	return
+}
+
+func (gc govClient) query(route string, jsonBytes []byte, ptr interface{}) error {
+	path := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, route)
+	res, _, err := gc.Query(path, jsonBytes)
+	if err != nil {
+		return utils.ErrClientQuery(err.Error())
+	}
+
+	if err = gc.GetCodec().UnmarshalJSON(res, ptr); err != nil {
+		return utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return nil
+}
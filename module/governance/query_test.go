@@ -100,3 +100,214 @@ func TestGovClient_QueryProposals(t *testing.T) {
 	_, err = mockCli.Governance().QueryProposals(addr, addr, "unknown status", 1)
 	require.NoError(t, err)
 }
+
+func TestGovClient_QueryProposal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient))
+
+	proposalID := uint64(1024)
+	expectedCdc := mockCli.GetCodec()
+	expectedRet := expectedCdc.MustMarshalJSON(govtypes.Proposal{
+		Content:    govtypes.NewTextProposal("title", "description"),
+		ProposalID: proposalID,
+		Status:     govtypes.StatusVotingPeriod,
+	})
+	expectedParams := expectedCdc.MustMarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	expectedPath := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, govtypes.QueryProposal)
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+
+	proposal, err := mockCli.Governance().QueryProposal(proposalID)
+	require.NoError(t, err)
+	require.Equal(t, proposalID, proposal.ProposalID)
+	require.Equal(t, "title", proposal.GetTitle())
+	require.Equal(t, govtypes.StatusVotingPeriod, proposal.Status)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Governance().QueryProposal(proposalID)
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Governance().QueryProposal(proposalID)
+	require.Error(t, err)
+}
+
+func TestGovClient_QueryVotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient))
+
+	proposalID := uint64(1024)
+	voterAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	expectedVote := govtypes.NewVote(proposalID, voterAddr, govtypes.OptionYes)
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	votesPath := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, govtypes.QueryVotes)
+	votesParams := expectedCdc.MustMarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	mockCli.EXPECT().Query(votesPath, tmbytes.HexBytes(votesParams)).
+		Return(expectedCdc.MustMarshalJSON(govtypes.Votes{expectedVote}), int64(1024), nil)
+
+	votes, err := mockCli.Governance().QueryVotes(proposalID)
+	require.NoError(t, err)
+	require.Equal(t, []govtypes.Vote{expectedVote}, votes)
+
+	mockCli.EXPECT().Query(votesPath, tmbytes.HexBytes(votesParams)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Governance().QueryVotes(proposalID)
+	require.Error(t, err)
+
+	votePath := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, govtypes.QueryVote)
+	voteParams := expectedCdc.MustMarshalJSON(govtypes.NewQueryVoteParams(proposalID, voterAddr))
+	mockCli.EXPECT().Query(votePath, tmbytes.HexBytes(voteParams)).
+		Return(expectedCdc.MustMarshalJSON(expectedVote), int64(1024), nil)
+
+	// hex address is accepted
+	vote, err := mockCli.Governance().QueryVote(proposalID, "0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991")
+	require.NoError(t, err)
+	require.Equal(t, expectedVote, vote)
+
+	// the voter hasn't voted
+	mockCli.EXPECT().Query(votePath, tmbytes.HexBytes(voteParams)).
+		Return(expectedCdc.MustMarshalJSON(govtypes.Vote{}), int64(1024), nil)
+	_, err = mockCli.Governance().QueryVote(proposalID, addr)
+	require.Error(t, err)
+
+	_, err = mockCli.Governance().QueryVote(proposalID, addr[1:])
+	require.Error(t, err)
+}
+
+func TestGovClient_QueryDeposits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient))
+
+	proposalID := uint64(1024)
+	depositorAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	amount, err := sdk.ParseDecCoins("10.24okt")
+	require.NoError(t, err)
+	expectedDeposit := govtypes.NewDeposit(proposalID, depositorAddr, amount)
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	depositsPath := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, govtypes.QueryDeposits)
+	depositsParams := expectedCdc.MustMarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	mockCli.EXPECT().Query(depositsPath, tmbytes.HexBytes(depositsParams)).
+		Return(expectedCdc.MustMarshalJSON(govtypes.Deposits{expectedDeposit}), int64(1024), nil)
+
+	deposits, err := mockCli.Governance().QueryDeposits(proposalID)
+	require.NoError(t, err)
+	require.Equal(t, []govtypes.Deposit{expectedDeposit}, deposits)
+
+	mockCli.EXPECT().Query(depositsPath, tmbytes.HexBytes(depositsParams)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Governance().QueryDeposits(proposalID)
+	require.Error(t, err)
+
+	depositPath := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, govtypes.QueryDeposit)
+	depositParams := expectedCdc.MustMarshalJSON(govtypes.NewQueryDepositParams(proposalID, depositorAddr))
+	mockCli.EXPECT().Query(depositPath, tmbytes.HexBytes(depositParams)).
+		Return(expectedCdc.MustMarshalJSON(expectedDeposit), int64(1024), nil)
+
+	deposit, err := mockCli.Governance().QueryDeposit(proposalID, addr)
+	require.NoError(t, err)
+	require.Equal(t, expectedDeposit, deposit)
+
+	// the depositor hasn't deposited
+	mockCli.EXPECT().Query(depositPath, tmbytes.HexBytes(depositParams)).
+		Return(expectedCdc.MustMarshalJSON(govtypes.Deposit{}), int64(1024), nil)
+	_, err = mockCli.Governance().QueryDeposit(proposalID, addr)
+	require.Error(t, err)
+
+	_, err = mockCli.Governance().QueryDeposit(proposalID, addr[1:])
+	require.Error(t, err)
+}
+
+func TestGovClient_QueryTally(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient))
+
+	proposalID, mockPower := uint64(1024), sdk.MustNewDecFromStr("0.25")
+	expectedTally := govtypes.TallyResult{
+		TotalPower:      mockPower.MulInt64(4),
+		TotalVotedPower: mockPower,
+		Yes:             mockPower,
+		Abstain:         sdk.ZeroDec(),
+		No:              sdk.ZeroDec(),
+		NoWithVeto:      sdk.ZeroDec(),
+	}
+
+	expectedCdc := mockCli.GetCodec()
+	expectedRet := expectedCdc.MustMarshalJSON(expectedTally)
+	expectedParams := expectedCdc.MustMarshalJSON(govtypes.NewQueryProposalParams(proposalID))
+	expectedPath := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, govtypes.QueryTally)
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+
+	tallyResult, err := mockCli.Governance().QueryTally(proposalID)
+	require.NoError(t, err)
+	require.Equal(t, expectedTally, tallyResult)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Governance().QueryTally(proposalID)
+	require.Error(t, err)
+}
+
+func TestGovClient_QueryParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient))
+
+	minDeposit, err := sdk.ParseDecCoins("100okt")
+	require.NoError(t, err)
+	expectedParams := govtypes.NewParams(
+		govtypes.NewVotingParams(72*time.Hour),
+		govtypes.TallyParams{
+			Quorum:          sdk.MustNewDecFromStr("0.334"),
+			Threshold:       sdk.MustNewDecFromStr("0.5"),
+			Veto:            sdk.MustNewDecFromStr("0.334"),
+			YesInVotePeriod: sdk.MustNewDecFromStr("0.667"),
+		},
+		govtypes.NewDepositParams(minDeposit, 24*time.Hour),
+	)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	paramsPath := fmt.Sprintf("custom/%s/%s", govtypes.QuerierRoute, govtypes.QueryParams)
+	mockCli.EXPECT().Query(paramsPath+"/"+govtypes.ParamDeposit, nil).
+		Return(expectedCdc.MustMarshalJSON(expectedParams.DepositParams), int64(1024), nil)
+	mockCli.EXPECT().Query(paramsPath+"/"+govtypes.ParamVoting, nil).
+		Return(expectedCdc.MustMarshalJSON(expectedParams.VotingParams), int64(1024), nil)
+	mockCli.EXPECT().Query(paramsPath+"/"+govtypes.ParamTallying, nil).
+		Return(expectedCdc.MustMarshalJSON(expectedParams.TallyParams), int64(1024), nil)
+
+	govParams, err := mockCli.Governance().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, expectedParams, govParams)
+
+	mockCli.EXPECT().Query(paramsPath+"/"+govtypes.ParamDeposit, nil).
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Governance().QueryParams()
+	require.Error(t, err)
+}
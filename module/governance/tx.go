@@ -1,7 +1,11 @@
 package governance
 
 import (
+	"fmt"
+
+	"github.com/okex/exchain-go-sdk/module/governance/types"
 	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
 	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	distrcli "github.com/okx/okbchain/x/distribution/client/cli"
@@ -24,7 +28,7 @@ func (gc govClient) SubmitTextProposal(fromInfo keys.Info, passWd, proposalPath,
 		return
 	}
 
-	deposit, err := sdk.ParseDecCoins(proposal.Deposit)
+	deposit, err := utils.ParseDecCoins(proposal.Deposit)
 	if err != nil {
 		return
 	}
@@ -90,6 +94,25 @@ func (gc govClient) SubmitCommunityPoolSpendProposal(fromInfo keys.Info, passWd,
 	return gc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
 }
 
+// SubmitProposal submits the typed proposal on ExChain, which is validated before signing
+func (gc govClient) SubmitProposal(fromInfo keys.Info, passWd string, proposal types.ProposalContent, memo string,
+	accNum, seqNum uint64) (resp sdk.TxResponse, err error) {
+	if err = params.CheckKeyParams(fromInfo, passWd); err != nil {
+		return
+	}
+
+	if proposal == nil {
+		return resp, fmt.Errorf("failed. empty proposal")
+	}
+
+	if err = proposal.ValidateBasic(); err != nil {
+		return
+	}
+
+	msg := govtypes.NewMsgSubmitProposal(proposal.Content(), proposal.GetDeposit(), fromInfo.GetAddress())
+	return gc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
+}
+
 // Deposit increases the deposit amount on a specific proposal
 func (gc govClient) Deposit(fromInfo keys.Info, passWd, depositCoinsStr, memo string, proposalID, accNum,
 	seqNum uint64) (resp sdk.TxResponse, err error) {
@@ -97,7 +120,7 @@ func (gc govClient) Deposit(fromInfo keys.Info, passWd, depositCoinsStr, memo st
 		return
 	}
 
-	deposit, err := sdk.ParseDecCoins(depositCoinsStr)
+	deposit, err := utils.ParseDecCoins(depositCoinsStr)
 	if err != nil {
 		return
 	}
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/okex/exchain-go-sdk/mocks"
 	"github.com/okex/exchain-go-sdk/module/auth"
+	"github.com/okex/exchain-go-sdk/module/governance/types"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	"github.com/okex/exchain-go-sdk/utils"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
@@ -18,16 +20,12 @@ import (
 const (
 	textProposalFilePath               = "./text_proposal.json"
 	paramsChangeProposalFilePath       = "./param_change_proposal.json"
-	delistProposalFilePath             = "./delist_proposal.json"
 	communityPoolSpendProposalFilePath = "./community_pool_spend_proposal.json"
-	manageWhiteListProposalFilePath    = "./manage_white_list_proposal.json"
 	badProposalFilePath                = "./bad_proposal.json"
 
 	textProposalJSON               = `{"title":"Text Proposal","description":"text proposal description","proposal_type":"Text","deposit":"100okt"}`
 	paramsChangeProposalJSON       = `{"title":"Param Change Proposal","description":"param change proposal description","changes":[{"subspace":"staking","key":"MaxValidators","value":105}],"deposit":[{"denom":"okt","amount":"100"}],"height":"1024"}`
-	delistProposalJSON             = `{"title":"Delist Proposal","description":"delist proposal description","base_asset":"btc-000","quote_asset":"okt","deposit":[{"denom":"okt","amount":"100"}]}`
 	communityPoolSpendProposalJSON = `{"title":"Community Pool Spend Proposal","description":"community pool spend description","recipient":"ex1qj5c07sm6jetjz8f509qtrxgh4psxkv3ddyq7u","amount":[{"denom":"okt","amount":"10.24"}],"deposit":[{"denom":"okt","amount":"100"}]}`
-	manageWhiteListProposalJSON    = `{"title":"Manage White List Proposal","description":"manage white list description","pool_name":"pool1","is_added":true,"deposit":[{"denom":"tokt","amount":"100"}]}`
 )
 
 func TestGovClient_SubmitTextProposal(t *testing.T) {
@@ -161,9 +159,9 @@ func TestGovClient_SubmitParamChangeProposal(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestGovClient_SubmitDelistProposal(t *testing.T) {
-	// build the delist proposal JSON file
-	err := ioutil.WriteFile(delistProposalFilePath, []byte(delistProposalJSON), 0644)
+func TestGovClient_SubmitCommunityPoolSpendProposal(t *testing.T) {
+	// build the community pool spend proposal JSON file
+	err := ioutil.WriteFile(communityPoolSpendProposalFilePath, []byte(communityPoolSpendProposalJSON), 0644)
 	require.NoError(t, err)
 
 	ctrl := gomock.NewController(t)
@@ -189,45 +187,41 @@ func TestGovClient_SubmitDelistProposal(t *testing.T) {
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(mocks.DefaultMockSuccessTxResponse(), nil)
 
-	res, err := mockCli.Governance().SubmitDelistProposal(fromInfo, passWd, delistProposalFilePath, memo,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	res, err := mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, communityPoolSpendProposalFilePath,
+		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.NoError(t, err)
 	require.Equal(t, uint32(0), res.Code)
 
-	_, err = mockCli.Governance().SubmitDelistProposal(fromInfo, passWd, delistProposalFilePath[1:], memo,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, communityPoolSpendProposalFilePath[1:],
+		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 
-	// bad delist proposal JSON file
-	err = ioutil.WriteFile(badProposalFilePath, []byte(delistProposalJSON[1:]), 0644)
+	// bad community pool spend proposal JSON file
+	err = ioutil.WriteFile(badProposalFilePath, []byte(communityPoolSpendProposalJSON[1:]), 0644)
 	require.NoError(t, err)
-	_, err = mockCli.Governance().SubmitDelistProposal(fromInfo, passWd, badProposalFilePath, memo,
+	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, badProposalFilePath, memo,
 		accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(sdk.TxResponse{}, errors.New("default error"))
-	_, err = mockCli.Governance().SubmitDelistProposal(fromInfo, passWd, delistProposalFilePath, memo,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, communityPoolSpendProposalFilePath,
+		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 
-	_, err = mockCli.Governance().SubmitDelistProposal(fromInfo, "", delistProposalFilePath, memo,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, "", communityPoolSpendProposalFilePath,
+		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 
 	// remove the temporary files
-	err = os.Remove(delistProposalFilePath)
+	err = os.Remove(communityPoolSpendProposalFilePath)
 	require.NoError(t, err)
 	err = os.Remove(badProposalFilePath)
 	require.NoError(t, err)
 }
 
-func TestGovClient_SubmitCommunityPoolSpendProposal(t *testing.T) {
-	// build the community pool spend proposal JSON file
-	err := ioutil.WriteFile(communityPoolSpendProposalFilePath, []byte(communityPoolSpendProposalJSON), 0644)
-	require.NoError(t, err)
-
+func TestGovClient_Deposit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
@@ -241,7 +235,7 @@ func TestGovClient_SubmitCommunityPoolSpendProposal(t *testing.T) {
 
 	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okt", 1, 2)
 	expectedCdc := mockCli.GetCodec()
-	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(6)
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
 	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, int64(1024), nil)
 
 	accInfo, err := mockCli.Auth().QueryAccount(addr)
@@ -251,45 +245,32 @@ func TestGovClient_SubmitCommunityPoolSpendProposal(t *testing.T) {
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(mocks.DefaultMockSuccessTxResponse(), nil)
 
-	res, err := mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, communityPoolSpendProposalFilePath,
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	res, err := mockCli.Governance().Deposit(fromInfo, passWd, "100okt", memo, 1,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.NoError(t, err)
 	require.Equal(t, uint32(0), res.Code)
 
-	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, communityPoolSpendProposalFilePath[1:],
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	_, err = mockCli.Governance().Deposit(fromInfo, passWd, "100okt", memo, 0,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 
-	// bad community pool spend proposal JSON file
-	err = ioutil.WriteFile(badProposalFilePath, []byte(communityPoolSpendProposalJSON[1:]), 0644)
-	require.NoError(t, err)
-	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, badProposalFilePath, memo,
+	_, err = mockCli.Governance().Deposit(fromInfo, passWd, "100", memo, 1,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Governance().Deposit(fromInfo, "", "100okt", memo, 1,
 		accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(sdk.TxResponse{}, errors.New("default error"))
-	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, passWd, communityPoolSpendProposalFilePath,
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
-	require.Error(t, err)
-
-	_, err = mockCli.Governance().SubmitCommunityPoolSpendProposal(fromInfo, "", communityPoolSpendProposalFilePath,
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	_, err = mockCli.Governance().Deposit(fromInfo, passWd, "100okt", memo, 1,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
-
-	// remove the temporary files
-	err = os.Remove(communityPoolSpendProposalFilePath)
-	require.NoError(t, err)
-	err = os.Remove(badProposalFilePath)
-	require.NoError(t, err)
 }
 
-func TestGovClient_SubmitManageWhiteListProposal(t *testing.T) {
-	// build the manage white list proposal JSON file
-	err := ioutil.WriteFile(manageWhiteListProposalFilePath, []byte(manageWhiteListProposalJSON), 0644)
-	require.NoError(t, err)
-
+func TestGovClient_Vote(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
@@ -303,7 +284,7 @@ func TestGovClient_SubmitManageWhiteListProposal(t *testing.T) {
 
 	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okt", 1, 2)
 	expectedCdc := mockCli.GetCodec()
-	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(6)
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
 	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, int64(1024), nil)
 
 	accInfo, err := mockCli.Auth().QueryAccount(addr)
@@ -311,48 +292,192 @@ func TestGovClient_SubmitManageWhiteListProposal(t *testing.T) {
 
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
-		Return(mocks.DefaultMockSuccessTxResponse(), nil)
+		Return(mocks.DefaultMockSuccessTxResponse(), nil).Times(4)
 
-	res, err := mockCli.Governance().SubmitManageWhiteListProposal(fromInfo, passWd, manageWhiteListProposalFilePath,
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	res, err := mockCli.Governance().Vote(fromInfo, passWd, "yes", memo, 1, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
 	require.NoError(t, err)
 	require.Equal(t, uint32(0), res.Code)
 
-	_, err = mockCli.Governance().SubmitManageWhiteListProposal(fromInfo, passWd, manageWhiteListProposalFilePath[1:],
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
-	require.Error(t, err)
+	_, err = mockCli.Governance().Vote(fromInfo, passWd, "Abstain", memo, 1, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.NoError(t, err)
 
-	// bad community pool spend proposal JSON file
-	err = ioutil.WriteFile(badProposalFilePath, []byte(manageWhiteListProposalFilePath[1:]), 0644)
+	_, err = mockCli.Governance().Vote(fromInfo, passWd, "no", memo, 1, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
 	require.NoError(t, err)
-	require.Panics(t, func() {
-		_, _ = mockCli.Governance().SubmitManageWhiteListProposal(fromInfo, passWd, badProposalFilePath, memo,
-			accInfo.GetAccountNumber(), accInfo.GetSequence())
-	})
+
+	_, err = mockCli.Governance().Vote(fromInfo, passWd, "no_with_veto", memo, 1, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.NoError(t, err)
+
+	// error
+	_, err = mockCli.Governance().Vote(fromInfo, passWd, "yes", memo, 0, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Governance().Vote(fromInfo, "", "yes", memo, 1, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Governance().Vote(fromInfo, passWd, "", memo, 1, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
 
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(sdk.TxResponse{}, errors.New("default error"))
-	_, err = mockCli.Governance().SubmitManageWhiteListProposal(fromInfo, passWd, manageWhiteListProposalFilePath,
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	_, err = mockCli.Governance().Vote(fromInfo, passWd, "yes", memo, 1, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
 	require.Error(t, err)
+}
 
-	_, err = mockCli.Governance().SubmitManageWhiteListProposal(fromInfo, "", manageWhiteListProposalFilePath,
-		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
-	require.Error(t, err)
+func TestGovClient_SubmitProposal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okb")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
 
-	// remove the temporary files
-	err = os.Remove(manageWhiteListProposalFilePath)
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
 	require.NoError(t, err)
-	err = os.Remove(badProposalFilePath)
+
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okb", 1, 2)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, int64(1024), nil)
+
+	accInfo, err := mockCli.Auth().QueryAccount(addr)
 	require.NoError(t, err)
+
+	deposit, err := utils.ParseDecCoins("100okb")
+	require.NoError(t, err)
+	recipient, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	amount, err := utils.ParseDecCoins("10.24okb")
+	require.NoError(t, err)
+
+	proposals := []types.ProposalContent{
+		types.TextProposal{Title: "Text Proposal", Description: "text proposal description", Deposit: deposit},
+		types.ParamsChangeProposal{
+			Title:       "Param Change Proposal",
+			Description: "param change proposal description",
+			Changes:     []types.ParamChange{{Subspace: "staking", Key: "MaxValidators", Value: "105"}},
+			Height:      1024,
+			Deposit:     deposit,
+		},
+		types.CommunityPoolSpendProposal{
+			Title:       "Community Pool Spend Proposal",
+			Description: "community pool spend description",
+			Recipient:   recipient,
+			Amount:      amount,
+			Deposit:     deposit,
+		},
+		types.SoftwareUpgradeProposal{
+			Title:        "Software Upgrade Proposal",
+			Description:  "software upgrade proposal description",
+			Name:         "v1.1.0",
+			ExpectHeight: 1024,
+			Deposit:      deposit,
+		},
+		types.ManageContractDeploymentWhitelistProposal{
+			Title:            "Manage Contract Deployment Whitelist Proposal",
+			Description:      "manage contract deployment whitelist proposal description",
+			DistributorAddrs: []sdk.AccAddress{recipient},
+			IsAdded:          true,
+			Deposit:          deposit,
+		},
+		types.IBCClientUpdateProposal{
+			Title:              "IBC Client Update Proposal",
+			Description:        "ibc client update proposal description",
+			SubjectClientID:    "07-tendermint-0",
+			SubstituteClientID: "07-tendermint-1",
+			Deposit:            deposit,
+		},
+		types.FeeSplitSharesProposal{
+			Title:       "Fee Split Shares Proposal",
+			Description: "fee split shares proposal description",
+			Shares: []types.FeeSplitShares{
+				{ContractAddr: "0x5F6dCd1f9c0a5b0b5a0e5B6c7D8E9F0a1B2C3D4e", Share: sdk.NewDecWithPrec(5, 1)},
+			},
+			Deposit: deposit,
+		},
+		types.WasmUpdateAdminProposal{
+			Title:       "Wasm Update Admin Proposal",
+			Description: "wasm update admin proposal description",
+			Contract:    addr,
+			NewAdmin:    addr,
+			Deposit:     deposit,
+		},
+	}
+
+	mockCli.EXPECT().BuildAndBroadcast(
+		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
+		Return(mocks.DefaultMockSuccessTxResponse(), nil).Times(len(proposals))
+	for _, proposal := range proposals {
+		res, err := mockCli.Governance().SubmitProposal(fromInfo, passWd, proposal, memo, accInfo.GetAccountNumber(),
+			accInfo.GetSequence())
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), res.Code)
+	}
+
+	// error
+	badDeposit, err := utils.ParseDecCoins("100okt")
+	require.NoError(t, err)
+	badProposals := []types.ProposalContent{
+		nil,
+		types.TextProposal{Title: "", Description: "text proposal description", Deposit: deposit},
+		types.TextProposal{Title: "Text Proposal", Description: strings.Repeat("a", 5001), Deposit: deposit},
+		types.TextProposal{Title: "Text Proposal", Description: "text proposal description", Deposit: badDeposit},
+		types.ParamsChangeProposal{
+			Title:       "Param Change Proposal",
+			Description: "param change proposal description",
+			Changes:     []types.ParamChange{{Subspace: "staking", Key: "UnknownKey", Value: "105"}},
+			Deposit:     deposit,
+		},
+		types.ParamsChangeProposal{
+			Title:       "Param Change Proposal",
+			Description: "param change proposal description",
+			Changes:     []types.ParamChange{{Subspace: "unknown", Key: "MaxValidators", Value: "105"}},
+			Deposit:     deposit,
+		},
+		types.CommunityPoolSpendProposal{
+			Title:       "Community Pool Spend Proposal",
+			Description: "community pool spend description",
+			Amount:      amount,
+			Deposit:     deposit,
+		},
+		types.IBCClientUpdateProposal{
+			Title:              "IBC Client Update Proposal",
+			Description:        "ibc client update proposal description",
+			SubjectClientID:    "07-tendermint-0",
+			SubstituteClientID: "07-tendermint-0",
+			Deposit:            deposit,
+		},
+		types.WasmPinCodesProposal{
+			Title:       "Wasm Pin Codes Proposal",
+			Description: "wasm pin codes proposal description",
+			Deposit:     deposit,
+		},
+	}
+	for _, proposal := range badProposals {
+		_, err = mockCli.Governance().SubmitProposal(fromInfo, passWd, proposal, memo, accInfo.GetAccountNumber(),
+			accInfo.GetSequence())
+		require.Error(t, err)
+	}
+
+	_, err = mockCli.Governance().SubmitProposal(fromInfo, "", proposals[0], memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
 }
 
-func TestGovClient_Deposit(t *testing.T) {
+func TestGovClient_SubmitManageContractBlockedListProposal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
-		200000, 1.1, "0.00000001okt")
+		200000, 1.1, "0.00000001okb")
 	require.NoError(t, err)
 	mockCli := mocks.NewMockClient(t, ctrl, config)
 	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
@@ -360,7 +485,7 @@ func TestGovClient_Deposit(t *testing.T) {
 	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
 	require.NoError(t, err)
 
-	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okt", 1, 2)
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okb", 1, 2)
 	expectedCdc := mockCli.GetCodec()
 	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
 	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, int64(1024), nil)
@@ -368,40 +493,52 @@ func TestGovClient_Deposit(t *testing.T) {
 	accInfo, err := mockCli.Auth().QueryAccount(addr)
 	require.NoError(t, err)
 
+	deposit, err := utils.ParseDecCoins("100okb")
+	require.NoError(t, err)
+	contractAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+
+	proposal := types.ManageContractBlockedListProposal{
+		Title:         "Manage Contract Blocked List Proposal",
+		Description:   "manage contract blocked list proposal description",
+		ContractAddrs: []sdk.AccAddress{contractAddr},
+		IsAdded:       true,
+		Deposit:       deposit,
+	}
+
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(mocks.DefaultMockSuccessTxResponse(), nil)
 
-	res, err := mockCli.Governance().Deposit(fromInfo, passWd, "100okt", memo, 1,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	res, err := mockCli.Governance().SubmitProposal(fromInfo, passWd, proposal, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
 	require.NoError(t, err)
 	require.Equal(t, uint32(0), res.Code)
 
-	_, err = mockCli.Governance().Deposit(fromInfo, passWd, "100okt", memo, 0,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
-	require.Error(t, err)
-
-	_, err = mockCli.Governance().Deposit(fromInfo, passWd, "100", memo, 1,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
-	require.Error(t, err)
-
-	_, err = mockCli.Governance().Deposit(fromInfo, "", "100okt", memo, 1,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	// no contract to block
+	badProposal := proposal
+	badProposal.ContractAddrs = nil
+	_, err = mockCli.Governance().SubmitProposal(fromInfo, passWd, badProposal, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
 	require.Error(t, err)
 
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(sdk.TxResponse{}, errors.New("default error"))
-	_, err = mockCli.Governance().Deposit(fromInfo, passWd, "100okt", memo, 1,
-		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	_, err = mockCli.Governance().SubmitProposal(fromInfo, passWd, proposal, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Governance().SubmitProposal(fromInfo, "", proposal, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
 	require.Error(t, err)
 }
 
-func TestGovClient_Vote(t *testing.T) {
+func TestGovClient_SubmitManageContractDeploymentWhitelistProposal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
-		200000, 1.1, "0.00000001okt")
+		200000, 1.1, "0.00000001okb")
 	require.NoError(t, err)
 	mockCli := mocks.NewMockClient(t, ctrl, config)
 	mockCli.RegisterModule(NewGovClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
@@ -409,7 +546,7 @@ func TestGovClient_Vote(t *testing.T) {
 	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
 	require.NoError(t, err)
 
-	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okt", 1, 2)
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "", "1024okb", 1, 2)
 	expectedCdc := mockCli.GetCodec()
 	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
 	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, int64(1024), nil)
@@ -417,44 +554,43 @@ func TestGovClient_Vote(t *testing.T) {
 	accInfo, err := mockCli.Auth().QueryAccount(addr)
 	require.NoError(t, err)
 
-	mockCli.EXPECT().BuildAndBroadcast(
-		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
-		Return(mocks.DefaultMockSuccessTxResponse(), nil).Times(4)
-
-	res, err := mockCli.Governance().Vote(fromInfo, passWd, "yes", memo, 1, accInfo.GetAccountNumber(),
-		accInfo.GetSequence())
-	require.NoError(t, err)
-	require.Equal(t, uint32(0), res.Code)
-
-	_, err = mockCli.Governance().Vote(fromInfo, passWd, "Abstain", memo, 1, accInfo.GetAccountNumber(),
-		accInfo.GetSequence())
+	deposit, err := utils.ParseDecCoins("100okb")
 	require.NoError(t, err)
-
-	_, err = mockCli.Governance().Vote(fromInfo, passWd, "no", memo, 1, accInfo.GetAccountNumber(),
-		accInfo.GetSequence())
+	distributorAddr, err := sdk.AccAddressFromBech32(addr)
 	require.NoError(t, err)
 
-	_, err = mockCli.Governance().Vote(fromInfo, passWd, "no_with_veto", memo, 1, accInfo.GetAccountNumber(),
-		accInfo.GetSequence())
-	require.NoError(t, err)
+	proposal := types.ManageContractDeploymentWhitelistProposal{
+		Title:            "Manage Contract Deployment Whitelist Proposal",
+		Description:      "manage contract deployment whitelist proposal description",
+		DistributorAddrs: []sdk.AccAddress{distributorAddr},
+		IsAdded:          true,
+		Deposit:          deposit,
+	}
 
-	// error
-	_, err = mockCli.Governance().Vote(fromInfo, passWd, "yes", memo, 0, accInfo.GetAccountNumber(),
-		accInfo.GetSequence())
-	require.Error(t, err)
+	mockCli.EXPECT().BuildAndBroadcast(
+		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
+		Return(mocks.DefaultMockSuccessTxResponse(), nil)
 
-	_, err = mockCli.Governance().Vote(fromInfo, "", "yes", memo, 1, accInfo.GetAccountNumber(),
+	res, err := mockCli.Governance().SubmitProposal(fromInfo, passWd, proposal, memo, accInfo.GetAccountNumber(),
 		accInfo.GetSequence())
-	require.Error(t, err)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
 
-	_, err = mockCli.Governance().Vote(fromInfo, passWd, "", memo, 1, accInfo.GetAccountNumber(),
+	// no distributor to manage
+	badProposal := proposal
+	badProposal.DistributorAddrs = nil
+	_, err = mockCli.Governance().SubmitProposal(fromInfo, passWd, badProposal, memo, accInfo.GetAccountNumber(),
 		accInfo.GetSequence())
 	require.Error(t, err)
 
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(), accInfo.GetSequence()).
 		Return(sdk.TxResponse{}, errors.New("default error"))
-	_, err = mockCli.Governance().Vote(fromInfo, passWd, "yes", memo, 1, accInfo.GetAccountNumber(),
+	_, err = mockCli.Governance().SubmitProposal(fromInfo, passWd, proposal, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Governance().SubmitProposal(fromInfo, "", proposal, memo, accInfo.GetAccountNumber(),
 		accInfo.GetSequence())
 	require.Error(t, err)
 }
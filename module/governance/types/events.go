@@ -0,0 +1,24 @@
+package types
+
+import (
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// SubmitProposalEvent - structure of a new proposal decoded from the submit_proposal event
+// Note: VotingStarted is true if the initial deposit has activated the voting period
+type SubmitProposalEvent struct {
+	ProposalID    uint64
+	VotingStarted bool
+}
+
+// ProposalDepositEvent - structure of a deposit decoded from the proposal_deposit event
+type ProposalDepositEvent struct {
+	ProposalID uint64
+	Amount     sdk.SysCoins
+}
+
+// ProposalVoteEvent - structure of a vote decoded from the proposal_vote event
+type ProposalVoteEvent struct {
+	ProposalID uint64
+	Option     string
+}
@@ -0,0 +1,560 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	authtypes "github.com/okx/okbchain/libs/cosmos-sdk/x/auth/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/params/subspace"
+	sdkparamstypes "github.com/okx/okbchain/libs/cosmos-sdk/x/params/types"
+	ibcclienttypes "github.com/okx/okbchain/libs/ibc-go/modules/core/02-client/types"
+	"github.com/okx/okbchain/x/common"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+	feesplittypes "github.com/okx/okbchain/x/feesplit/types"
+	govtypes "github.com/okx/okbchain/x/gov/types"
+	paramstypes "github.com/okx/okbchain/x/params/types"
+	"github.com/okx/okbchain/x/slashing"
+	stakingtypes "github.com/okx/okbchain/x/staking/types"
+	tokentypes "github.com/okx/okbchain/x/token/types"
+	wasmtypes "github.com/okx/okbchain/x/wasm/types"
+)
+
+// ParamChange - structure of a param to change in a params change proposal, whose value is in JSON
+type ParamChange = sdkparamstypes.ParamChange
+
+// ProposalContent shows the expected behavior of a typed proposal to submit
+type ProposalContent interface {
+	Content() govtypes.Content
+	GetDeposit() sdk.SysCoins
+	ValidateBasic() error
+}
+
+// TextProposal - structure of a text proposal to submit
+type TextProposal struct {
+	Title       string
+	Description string
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the text proposal to the content on chain
+func (tp TextProposal) Content() govtypes.Content {
+	return govtypes.NewTextProposal(tp.Title, tp.Description)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (tp TextProposal) GetDeposit() sdk.SysCoins {
+	return tp.Deposit
+}
+
+// ValidateBasic validates the text proposal before signing
+func (tp TextProposal) ValidateBasic() error {
+	return validateProposal(tp)
+}
+
+// ParamsChangeProposal - structure of a proposal to submit to change a param at a height
+type ParamsChangeProposal struct {
+	Title       string
+	Description string
+	Changes     []ParamChange
+	Height      uint64
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the params change proposal to the content on chain
+func (pcp ParamsChangeProposal) Content() govtypes.Content {
+	return paramstypes.NewParameterChangeProposal(pcp.Title, pcp.Description, pcp.Changes, pcp.Height)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (pcp ParamsChangeProposal) GetDeposit() sdk.SysCoins {
+	return pcp.Deposit
+}
+
+// ValidateBasic validates the params change proposal before signing, where the keys of the params are checked against
+// their subspaces
+func (pcp ParamsChangeProposal) ValidateBasic() error {
+	if err := validateProposal(pcp); err != nil {
+		return err
+	}
+
+	return ValidateParamChanges(pcp.Changes)
+}
+
+// CommunityPoolSpendProposal - structure of a proposal to submit to spend the tokens from the community pool
+type CommunityPoolSpendProposal struct {
+	Title       string
+	Description string
+	Recipient   sdk.AccAddress
+	Amount      sdk.SysCoins
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the community pool spend proposal to the content on chain
+func (cpsp CommunityPoolSpendProposal) Content() govtypes.Content {
+	return distrtypes.NewCommunityPoolSpendProposal(cpsp.Title, cpsp.Description, cpsp.Recipient, cpsp.Amount)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (cpsp CommunityPoolSpendProposal) GetDeposit() sdk.SysCoins {
+	return cpsp.Deposit
+}
+
+// ValidateBasic validates the community pool spend proposal before signing
+func (cpsp CommunityPoolSpendProposal) ValidateBasic() error {
+	return validateProposal(cpsp)
+}
+
+// SoftwareUpgradeProposal - structure of a proposal to submit to upgrade the software of the chain at a height
+type SoftwareUpgradeProposal struct {
+	Title        string
+	Description  string
+	Name         string
+	ExpectHeight uint64
+	Config       string
+	Deposit      sdk.SysCoins
+}
+
+// Content converts the software upgrade proposal to the content on chain
+func (sup SoftwareUpgradeProposal) Content() govtypes.Content {
+	return paramstypes.NewUpgradeProposal(sup.Title, sup.Description, sup.Name, sup.ExpectHeight, sup.Config)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (sup SoftwareUpgradeProposal) GetDeposit() sdk.SysCoins {
+	return sup.Deposit
+}
+
+// ValidateBasic validates the software upgrade proposal before signing
+func (sup SoftwareUpgradeProposal) ValidateBasic() error {
+	return validateProposal(sup)
+}
+
+// ManageContractDeploymentWhitelistProposal - structure of a proposal to submit to add or delete the distributors
+// in the whitelist of the evm contract deployment
+type ManageContractDeploymentWhitelistProposal struct {
+	Title            string
+	Description      string
+	DistributorAddrs []sdk.AccAddress
+	IsAdded          bool
+	Deposit          sdk.SysCoins
+}
+
+// Content converts the contract deployment whitelist proposal to the content on chain
+func (mcdwp ManageContractDeploymentWhitelistProposal) Content() govtypes.Content {
+	return evmtypes.NewManageContractDeploymentWhitelistProposal(mcdwp.Title, mcdwp.Description,
+		mcdwp.DistributorAddrs, mcdwp.IsAdded)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (mcdwp ManageContractDeploymentWhitelistProposal) GetDeposit() sdk.SysCoins {
+	return mcdwp.Deposit
+}
+
+// ValidateBasic validates the contract deployment whitelist proposal before signing
+func (mcdwp ManageContractDeploymentWhitelistProposal) ValidateBasic() error {
+	return validateProposal(mcdwp)
+}
+
+// ManageContractBlockedListProposal - structure of a proposal to submit to add or delete the evm contracts in the
+// blocked list
+type ManageContractBlockedListProposal struct {
+	Title         string
+	Description   string
+	ContractAddrs []sdk.AccAddress
+	IsAdded       bool
+	Deposit       sdk.SysCoins
+}
+
+// Content converts the contract blocked list proposal to the content on chain
+func (mcblp ManageContractBlockedListProposal) Content() govtypes.Content {
+	return evmtypes.NewManageContractBlockedListProposal(mcblp.Title, mcblp.Description, mcblp.ContractAddrs,
+		mcblp.IsAdded)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (mcblp ManageContractBlockedListProposal) GetDeposit() sdk.SysCoins {
+	return mcblp.Deposit
+}
+
+// ValidateBasic validates the contract blocked list proposal before signing
+func (mcblp ManageContractBlockedListProposal) ValidateBasic() error {
+	return validateProposal(mcblp)
+}
+
+// BlockedContract - structure of an evm contract with the methods to block. All the methods are blocked if none
+// is specified
+type BlockedContract = evmtypes.BlockedContract
+
+// ContractMethod - structure of a method of the evm contract, which is identified by its signature
+type ContractMethod = evmtypes.ContractMethod
+
+// ManageContractMethodBlockedListProposal - structure of a proposal to submit to add or delete the methods of the evm
+// contracts in the blocked list
+type ManageContractMethodBlockedListProposal struct {
+	Title        string
+	Description  string
+	ContractList []BlockedContract
+	IsAdded      bool
+	Deposit      sdk.SysCoins
+}
+
+// Content converts the contract method blocked list proposal to the content on chain
+func (mcmblp ManageContractMethodBlockedListProposal) Content() govtypes.Content {
+	return evmtypes.NewManageContractMethodBlockedListProposal(mcmblp.Title, mcmblp.Description,
+		mcmblp.ContractList, mcmblp.IsAdded)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (mcmblp ManageContractMethodBlockedListProposal) GetDeposit() sdk.SysCoins {
+	return mcmblp.Deposit
+}
+
+// ValidateBasic validates the contract method blocked list proposal before signing
+func (mcmblp ManageContractMethodBlockedListProposal) ValidateBasic() error {
+	return validateProposal(mcmblp)
+}
+
+// IBCClientUpdateProposal - structure of a proposal to submit to update an expired or frozen ibc client with the
+// state of a substitute client
+// Note: the upgrade proposal of the ibc client isn't routed by the governance on chain, so it can't be submitted
+type IBCClientUpdateProposal struct {
+	Title              string
+	Description        string
+	SubjectClientID    string
+	SubstituteClientID string
+	Deposit            sdk.SysCoins
+}
+
+// Content converts the ibc client update proposal to the content on chain
+func (icup IBCClientUpdateProposal) Content() govtypes.Content {
+	return ibcclienttypes.NewClientUpdateProposal(icup.Title, icup.Description, icup.SubjectClientID,
+		icup.SubstituteClientID)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (icup IBCClientUpdateProposal) GetDeposit() sdk.SysCoins {
+	return icup.Deposit
+}
+
+// ValidateBasic validates the ibc client update proposal before signing
+func (icup IBCClientUpdateProposal) ValidateBasic() error {
+	return validateProposal(icup)
+}
+
+// FeeSplitShares - structure of the share of the fees to split to the withdrawer of a contract
+type FeeSplitShares = feesplittypes.Shares
+
+// FeeSplitSharesProposal - structure of a proposal to submit to set the shares of the fees to split of the contracts
+type FeeSplitSharesProposal struct {
+	Title       string
+	Description string
+	Shares      []FeeSplitShares
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the fee split shares proposal to the content on chain
+func (fssp FeeSplitSharesProposal) Content() govtypes.Content {
+	return feesplittypes.NewFeeSplitSharesProposal(fssp.Title, fssp.Description, fssp.Shares)
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (fssp FeeSplitSharesProposal) GetDeposit() sdk.SysCoins {
+	return fssp.Deposit
+}
+
+// ValidateBasic validates the fee split shares proposal before signing
+func (fssp FeeSplitSharesProposal) ValidateBasic() error {
+	return validateProposal(fssp)
+}
+
+// WasmMigrateContractProposal - structure of a proposal to submit to migrate a wasm contract to a new code
+type WasmMigrateContractProposal struct {
+	Title       string
+	Description string
+	Contract    string
+	CodeID      uint64
+	Msg         []byte
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the wasm contract migration proposal to the content on chain
+func (wmcp WasmMigrateContractProposal) Content() govtypes.Content {
+	return &wasmtypes.MigrateContractProposal{
+		Title:       wmcp.Title,
+		Description: wmcp.Description,
+		Contract:    wmcp.Contract,
+		CodeID:      wmcp.CodeID,
+		Msg:         wmcp.Msg,
+	}
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (wmcp WasmMigrateContractProposal) GetDeposit() sdk.SysCoins {
+	return wmcp.Deposit
+}
+
+// ValidateBasic validates the wasm contract migration proposal before signing
+func (wmcp WasmMigrateContractProposal) ValidateBasic() error {
+	return validateProposal(wmcp)
+}
+
+// WasmUpdateAdminProposal - structure of a proposal to submit to set a new admin of a wasm contract
+type WasmUpdateAdminProposal struct {
+	Title       string
+	Description string
+	Contract    string
+	NewAdmin    string
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the wasm admin update proposal to the content on chain
+func (wuap WasmUpdateAdminProposal) Content() govtypes.Content {
+	return &wasmtypes.UpdateAdminProposal{
+		Title:       wuap.Title,
+		Description: wuap.Description,
+		Contract:    wuap.Contract,
+		NewAdmin:    wuap.NewAdmin,
+	}
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (wuap WasmUpdateAdminProposal) GetDeposit() sdk.SysCoins {
+	return wuap.Deposit
+}
+
+// ValidateBasic validates the wasm admin update proposal before signing
+func (wuap WasmUpdateAdminProposal) ValidateBasic() error {
+	return validateProposal(wuap)
+}
+
+// WasmClearAdminProposal - structure of a proposal to submit to clear the admin of a wasm contract
+type WasmClearAdminProposal struct {
+	Title       string
+	Description string
+	Contract    string
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the wasm admin clearance proposal to the content on chain
+func (wcap WasmClearAdminProposal) Content() govtypes.Content {
+	return &wasmtypes.ClearAdminProposal{
+		Title:       wcap.Title,
+		Description: wcap.Description,
+		Contract:    wcap.Contract,
+	}
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (wcap WasmClearAdminProposal) GetDeposit() sdk.SysCoins {
+	return wcap.Deposit
+}
+
+// ValidateBasic validates the wasm admin clearance proposal before signing
+func (wcap WasmClearAdminProposal) ValidateBasic() error {
+	return validateProposal(wcap)
+}
+
+// WasmPinCodesProposal - structure of a proposal to submit to pin the wasm codes in the cache of the vm
+type WasmPinCodesProposal struct {
+	Title       string
+	Description string
+	CodeIDs     []uint64
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the wasm codes pinning proposal to the content on chain
+func (wpcp WasmPinCodesProposal) Content() govtypes.Content {
+	return &wasmtypes.PinCodesProposal{
+		Title:       wpcp.Title,
+		Description: wpcp.Description,
+		CodeIDs:     wpcp.CodeIDs,
+	}
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (wpcp WasmPinCodesProposal) GetDeposit() sdk.SysCoins {
+	return wpcp.Deposit
+}
+
+// ValidateBasic validates the wasm codes pinning proposal before signing
+func (wpcp WasmPinCodesProposal) ValidateBasic() error {
+	return validateProposal(wpcp)
+}
+
+// WasmUnpinCodesProposal - structure of a proposal to submit to unpin the wasm codes from the cache of the vm
+type WasmUnpinCodesProposal struct {
+	Title       string
+	Description string
+	CodeIDs     []uint64
+	Deposit     sdk.SysCoins
+}
+
+// Content converts the wasm codes unpinning proposal to the content on chain
+func (wucp WasmUnpinCodesProposal) Content() govtypes.Content {
+	return &wasmtypes.UnpinCodesProposal{
+		Title:       wucp.Title,
+		Description: wucp.Description,
+		CodeIDs:     wucp.CodeIDs,
+	}
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (wucp WasmUnpinCodesProposal) GetDeposit() sdk.SysCoins {
+	return wucp.Deposit
+}
+
+// ValidateBasic validates the wasm codes unpinning proposal before signing
+func (wucp WasmUnpinCodesProposal) ValidateBasic() error {
+	return validateProposal(wucp)
+}
+
+// WasmUpdateDeploymentWhitelistProposal - structure of a proposal to submit to replace the whitelist of the wasm code
+// uploading, where "all" and "nobody" are accepted as the only distributor
+type WasmUpdateDeploymentWhitelistProposal struct {
+	Title            string
+	Description      string
+	DistributorAddrs []string
+	Deposit          sdk.SysCoins
+}
+
+// Content converts the wasm deployment whitelist proposal to the content on chain
+func (wudwp WasmUpdateDeploymentWhitelistProposal) Content() govtypes.Content {
+	return &wasmtypes.UpdateDeploymentWhitelistProposal{
+		Title:            wudwp.Title,
+		Description:      wudwp.Description,
+		DistributorAddrs: wudwp.DistributorAddrs,
+	}
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (wudwp WasmUpdateDeploymentWhitelistProposal) GetDeposit() sdk.SysCoins {
+	return wudwp.Deposit
+}
+
+// ValidateBasic validates the wasm deployment whitelist proposal before signing
+func (wudwp WasmUpdateDeploymentWhitelistProposal) ValidateBasic() error {
+	return validateProposal(wudwp)
+}
+
+// WasmContractMethod - structure of a method of the wasm contract to block
+type WasmContractMethod = wasmtypes.Method
+
+// WasmUpdateContractMethodBlockedListProposal - structure of a proposal to submit to add or delete the methods of a
+// wasm contract in the blocked list
+type WasmUpdateContractMethodBlockedListProposal struct {
+	Title        string
+	Description  string
+	ContractAddr string
+	Methods      []*WasmContractMethod
+	IsDelete     bool
+	Deposit      sdk.SysCoins
+}
+
+// Content converts the wasm contract method blocked list proposal to the content on chain
+func (wucmblp WasmUpdateContractMethodBlockedListProposal) Content() govtypes.Content {
+	return &wasmtypes.UpdateWASMContractMethodBlockedListProposal{
+		Title:       wucmblp.Title,
+		Description: wucmblp.Description,
+		BlockedMethods: &wasmtypes.ContractMethods{
+			ContractAddr: wucmblp.ContractAddr,
+			Methods:      wucmblp.Methods,
+		},
+		IsDelete: wucmblp.IsDelete,
+	}
+}
+
+// GetDeposit gets the initial deposit of the proposal
+func (wucmblp WasmUpdateContractMethodBlockedListProposal) GetDeposit() sdk.SysCoins {
+	return wucmblp.Deposit
+}
+
+// ValidateBasic validates the wasm contract method blocked list proposal before signing
+func (wucmblp WasmUpdateContractMethodBlockedListProposal) ValidateBasic() error {
+	return validateProposal(wucmblp)
+}
+
+type paramSet interface {
+	ParamSetPairs() subspace.ParamSetPairs
+}
+
+// paramKeys is the keys of the params in each subspace that can be changed by a params change proposal
+var paramKeys = func() map[string]map[string]bool {
+	paramSets := map[string]paramSet{
+		authtypes.DefaultParamspace:  &authtypes.Params{},
+		stakingtypes.ModuleName:      &stakingtypes.Params{},
+		distrtypes.DefaultParamspace: &distrtypes.Params{},
+		slashing.DefaultParamspace:   &slashing.Params{},
+		evmtypes.DefaultParamspace:   &evmtypes.Params{},
+		tokentypes.DefaultParamspace: &tokentypes.Params{},
+		feesplittypes.ModuleName:     &feesplittypes.Params{},
+		wasmtypes.ModuleName:         &wasmtypes.Params{},
+		sdkparamstypes.ModuleName:    &paramstypes.Params{},
+	}
+
+	keys := make(map[string]map[string]bool, len(paramSets)+1)
+	for space, ps := range paramSets {
+		keys[space] = make(map[string]bool)
+		for _, pair := range ps.ParamSetPairs() {
+			keys[space][string(pair.Key)] = true
+		}
+	}
+
+	// the params of gov are registered in its key table instead of a param set
+	keys[govtypes.DefaultParamspace] = map[string]bool{
+		string(govtypes.ParamStoreKeyDepositParams): true,
+		string(govtypes.ParamStoreKeyVotingParams):  true,
+		string(govtypes.ParamStoreKeyTallyParams):   true,
+	}
+
+	return keys
+}()
+
+// ValidateParamChanges checks that the subspace and the key of each param change are known
+func ValidateParamChanges(changes []ParamChange) error {
+	for _, change := range changes {
+		keys, ok := paramKeys[change.Subspace]
+		if !ok {
+			return fmt.Errorf("failed. unknown param subspace %s", change.Subspace)
+		}
+
+		if !keys[change.Key] {
+			validKeys := make([]string, 0, len(keys))
+			for key := range keys {
+				validKeys = append(validKeys, key)
+			}
+			sort.Strings(validKeys)
+			return fmt.Errorf("failed. unknown param key %s in subspace %s, expected one of [%s]", change.Key,
+				change.Subspace, strings.Join(validKeys, ", "))
+		}
+	}
+
+	return nil
+}
+
+// ValidateProposalDeposit checks that the initial deposit of a proposal is valid and in the native token
+func ValidateProposalDeposit(deposit sdk.SysCoins) error {
+	if !deposit.IsValid() {
+		return fmt.Errorf("failed. invalid deposit %s", deposit)
+	}
+
+	for _, coin := range deposit {
+		if coin.Denom != common.NativeToken {
+			return fmt.Errorf("failed. deposit %s is not in the native token %s", coin, common.NativeToken)
+		}
+	}
+
+	return nil
+}
+
+// validateProposal checks the title, the description and the other fields of the content on chain, as well as the
+// initial deposit
+func validateProposal(proposal ProposalContent) error {
+	if err := proposal.Content().ValidateBasic(); err != nil {
+		return fmt.Errorf("failed. invalid proposal content: %s", err)
+	}
+
+	return ValidateProposalDeposit(proposal.GetDeposit())
+}
@@ -10,7 +10,14 @@ const (
 )
 
 type (
-	Proposal = govtypes.Proposal
+	Proposal      = govtypes.Proposal
+	Vote          = govtypes.Vote
+	Deposit       = govtypes.Deposit
+	TallyResult   = govtypes.TallyResult
+	Params        = govtypes.Params
+	DepositParams = govtypes.DepositParams
+	VotingParams  = govtypes.VotingParams
+	TallyParams   = govtypes.TallyParams
 )
 
 // ProposalJSON - structure for a standard proposal from the JSON file
@@ -0,0 +1,171 @@
+package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/okex/exchain-go-sdk/utils"
+	govutils "github.com/okx/okbchain/x/gov/client/utils"
+	govtypes "github.com/okx/okbchain/x/gov/types"
+)
+
+// Rule - structure of a voting rule. A rule matches a proposal when all of its conditions are met, where an empty
+// condition matches any proposal
+type Rule struct {
+	Name string `json:"name"`
+	// ProposalTypes are the types of the proposal content, like ParameterChange, CommunityPoolSpend and Text
+	ProposalTypes []string `json:"proposal_types,omitempty"`
+	// Proposers are the addresses allowed to submit the proposal, in both bech32 and hex format
+	Proposers []string `json:"proposers,omitempty"`
+	// Option is the option to vote: yes/no/no_with_veto/abstain
+	Option string `json:"option"`
+}
+
+// Rules - structure of the rule file. The first rule matched decides the option to vote, and the default option is
+// voted when no rule matches. Nothing is voted when neither matches
+type Rules struct {
+	Rules         []Rule `json:"rules"`
+	DefaultOption string `json:"default_option,omitempty"`
+}
+
+// Decision - structure of the option decided for a proposal and the reason
+type Decision struct {
+	Option string `json:"option,omitempty"`
+	Rule   string `json:"rule,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// LoadRules loads the rules from a JSON formatted rule file
+func LoadRules(filePath string) (Rules, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Rules{}, fmt.Errorf("failed. open rule file error: %s", err)
+	}
+	defer file.Close()
+
+	return ParseRules(file)
+}
+
+// ParseRules parses and validates the JSON formatted rules. The options and the proposers are normalized
+func ParseRules(reader io.Reader) (rules Rules, err error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&rules); err != nil {
+		return rules, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		if len(rule.Name) == 0 {
+			rule.Name = fmt.Sprintf("rule #%d", i+1)
+		}
+
+		if rule.Option, err = normalizeOption(rule.Option); err != nil {
+			return rules, fmt.Errorf("failed. %s: %s", rule.Name, err)
+		}
+
+		for j, proposerStr := range rule.Proposers {
+			proposer, err := utils.ToCosmosAddress(proposerStr)
+			if err != nil {
+				return rules, fmt.Errorf("failed. %s: invalid proposer %s: %s", rule.Name, proposerStr, err)
+			}
+			rule.Proposers[j] = proposer.String()
+		}
+	}
+
+	if len(rules.DefaultOption) != 0 {
+		if rules.DefaultOption, err = normalizeOption(rules.DefaultOption); err != nil {
+			return rules, fmt.Errorf("failed. default option: %s", err)
+		}
+	}
+
+	return rules, nil
+}
+
+// NeedProposer shows whether any rule is conditioned on the proposer, which has to be looked up from the txs
+func (r Rules) NeedProposer() bool {
+	for _, rule := range r.Rules {
+		if len(rule.Proposers) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decide decides the option to vote for the proposal of a specific type from the proposer
+func (r Rules) Decide(proposalType, proposerStr string) Decision {
+	for _, rule := range r.Rules {
+		if !containsFold(rule.ProposalTypes, proposalType) {
+			continue
+		}
+
+		if len(rule.Proposers) != 0 && !contains(rule.Proposers, proposerStr) {
+			continue
+		}
+
+		return Decision{
+			Option: rule.Option,
+			Rule:   rule.Name,
+			Reason: fmt.Sprintf("%s matches %s proposal from %s", rule.Name, proposalType, proposerOrUnknown(proposerStr)),
+		}
+	}
+
+	if len(r.DefaultOption) != 0 {
+		return Decision{
+			Option: r.DefaultOption,
+			Reason: fmt.Sprintf("no rule matches %s proposal from %s, default option applied", proposalType,
+				proposerOrUnknown(proposerStr)),
+		}
+	}
+
+	return Decision{
+		Reason: fmt.Sprintf("no rule matches %s proposal from %s and no default option", proposalType,
+			proposerOrUnknown(proposerStr)),
+	}
+}
+
+func normalizeOption(option string) (string, error) {
+	normalized := govutils.NormalizeVoteOption(option)
+	if _, err := govtypes.VoteOptionFromString(normalized); err != nil {
+		return "", fmt.Errorf("invalid option %q, expected yes/no/no_with_veto/abstain", option)
+	}
+
+	return normalized, nil
+}
+
+// containsFold matches any value with an empty list
+func containsFold(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+
+	for _, elem := range list {
+		if strings.EqualFold(elem, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(list []string, value string) bool {
+	for _, elem := range list {
+		if elem == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func proposerOrUnknown(proposerStr string) string {
+	if len(proposerStr) == 0 {
+		return "unknown proposer"
+	}
+
+	return proposerStr
+}
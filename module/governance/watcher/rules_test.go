@@ -0,0 +1,64 @@
+package watcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const rulesJSON = `{
+	"rules": [
+		{"name": "params from core", "proposal_types": ["ParameterChange"], "proposers": ["0x04a987fa1bd4b2b908e9a3ca058cc8bd43035991"], "option": "yes"},
+		{"proposal_types": ["text"], "option": "no_with_veto"}
+	],
+	"default_option": "abstain"
+}`
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(strings.NewReader(rulesJSON))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(rules.Rules))
+	require.True(t, rules.NeedProposer())
+
+	// normalized
+	require.Equal(t, "params from core", rules.Rules[0].Name)
+	require.Equal(t, []string{proposer}, rules.Rules[0].Proposers)
+	require.Equal(t, "Yes", rules.Rules[0].Option)
+	require.Equal(t, "rule #2", rules.Rules[1].Name)
+	require.Equal(t, "NoWithVeto", rules.Rules[1].Option)
+	require.Equal(t, "Abstain", rules.DefaultOption)
+
+	decision := rules.Decide("ParameterChange", proposer)
+	require.Equal(t, "Yes", decision.Option)
+	require.Equal(t, "params from core", decision.Rule)
+
+	decision = rules.Decide("ParameterChange", "")
+	require.Equal(t, "Abstain", decision.Option)
+	require.Empty(t, decision.Rule)
+
+	decision = rules.Decide("Text", "")
+	require.Equal(t, "NoWithVeto", decision.Option)
+	require.Equal(t, "rule #2", decision.Rule)
+
+	rules.DefaultOption = ""
+	decision = rules.Decide("CommunityPoolSpend", proposer)
+	require.Empty(t, decision.Option)
+	require.NotEmpty(t, decision.Reason)
+
+	// error
+	_, err = ParseRules(strings.NewReader(`{"rules": [{"option": "maybe"}]}`))
+	require.Error(t, err)
+
+	_, err = ParseRules(strings.NewReader(`{"rules": [{"proposers": ["ex1bad"], "option": "yes"}]}`))
+	require.Error(t, err)
+
+	_, err = ParseRules(strings.NewReader(`{"rules": [], "default_option": "maybe"}`))
+	require.Error(t, err)
+
+	_, err = ParseRules(strings.NewReader(`{"rules": [{"option": "yes", "proposal_type": "Text"}]}`))
+	require.Error(t, err)
+
+	_, err = LoadRules("./not_exist.json")
+	require.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/utils"
+)
+
+// actions in the audit trail
+const (
+	// ActionVote means that the watcher voted on the proposal
+	ActionVote = "vote"
+	// ActionVoteFailed means that the vote of the watcher failed and will be tried again in the next run
+	ActionVoteFailed = "vote_failed"
+	// ActionAlreadyVoted means that the voter has voted on the proposal without the watcher
+	ActionAlreadyVoted = "already_voted"
+	// ActionSkip means that the watcher decided not to vote on the proposal
+	ActionSkip = "skip"
+)
+
+// ProposalRecord - structure of what the watcher knows and did about a proposal
+type ProposalRecord struct {
+	Title    string `json:"title"`
+	Type     string `json:"type"`
+	Proposer string `json:"proposer,omitempty"`
+	// Status is the last status notified
+	Status string `json:"status,omitempty"`
+	// Done shows that the watcher has voted, or decided not to vote on the proposal
+	Done   bool   `json:"done"`
+	Option string `json:"option,omitempty"`
+	TxHash string `json:"tx_hash,omitempty"`
+}
+
+// State - structure of the progress of the watcher, which is persisted after each run and each vote
+type State struct {
+	Voter     string                     `json:"voter"`
+	Proposals map[uint64]*ProposalRecord `json:"proposals"`
+}
+
+// AuditEntry - structure of an entry in the audit trail, which records what the watcher did and why
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Height     int64     `json:"height"`
+	ProposalID uint64    `json:"proposal_id"`
+	Title      string    `json:"title"`
+	Type       string    `json:"type"`
+	Proposer   string    `json:"proposer,omitempty"`
+	Action     string    `json:"action"`
+	Decision
+	TxHash string `json:"tx_hash,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// LoadState loads the state from the file. The existence is false if the file doesn't exist
+func LoadState(filePath string) (state State, exists bool, err error) {
+	bytes, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return state, false, nil
+	}
+	if err != nil {
+		return state, false, fmt.Errorf("failed. read state error: %s", err)
+	}
+
+	if err = json.Unmarshal(bytes, &state); err != nil {
+		return state, true, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return state, true, nil
+}
+
+// SaveState saves the state to the file atomically, so that a crash never leaves a broken state behind
+func SaveState(filePath string, state State) error {
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return utils.ErrMarshalJSON(err.Error())
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed. create temp state error: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.Write(bytes); err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed. write state error: %s", err)
+	}
+
+	if err = os.Rename(tmpFile.Name(), filePath); err != nil {
+		return fmt.Errorf("failed. replace state error: %s", err)
+	}
+
+	return nil
+}
+
+// AppendAudit appends the entry to the audit trail file as a JSON line
+func AppendAudit(filePath string, entry AuditEntry) error {
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return utils.ErrMarshalJSON(err.Error())
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed. open audit trail error: %s", err)
+	}
+
+	if _, err = file.Write(append(bytes, '\n')); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed. write audit trail error: %s", err)
+	}
+
+	return nil
+}
+
+// LoadAudit loads all the entries of the audit trail file
+func LoadAudit(filePath string) (entries []AuditEntry, err error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed. open audit trail error: %s", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var entry AuditEntry
+		if err = decoder.Decode(&entry); err != nil {
+			return entries, utils.ErrUnmarshalJSON(err.Error())
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
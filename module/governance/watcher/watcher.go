@@ -0,0 +1,397 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/governance/types"
+	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	govtypes "github.com/okx/okbchain/x/gov/types"
+)
+
+// const
+const (
+	// DefaultInterval is the default interval between two checks of the long-running watcher
+	DefaultInterval = time.Minute
+)
+
+// kinds of the notifications
+const (
+	// EventNewProposal means that a proposal is found for the first time
+	EventNewProposal = "new_proposal"
+	// EventStatusChanged means that a proposal moves to the voting period or its final status
+	EventStatusChanged = "status_changed"
+	// EventRemoved means that a proposal is deleted on chain, which happens when it fails to reach the min deposit
+	EventRemoved = "removed"
+	// EventVoted means that the watcher voted on a proposal
+	EventVoted = "voted"
+)
+
+// Client shows the expected behavior of the client that the watcher works with
+type Client interface {
+	Auth() exposed.Auth
+	Governance() exposed.Governance
+	Tendermint() exposed.Tendermint
+}
+
+// Event - structure of a notification from the watcher
+type Event struct {
+	Kind       string `json:"kind"`
+	Height     int64  `json:"height"`
+	ProposalID uint64 `json:"proposal_id"`
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status,omitempty"`
+	Option     string `json:"option,omitempty"`
+	TxHash     string `json:"tx_hash,omitempty"`
+}
+
+// Sink shows the expected behavior of the destination of the notifications, like a chat bot or a mailer
+type Sink interface {
+	Notify(event Event) error
+}
+
+// SinkFunc is an adapter to use a function as the sink
+type SinkFunc func(event Event) error
+
+// Notify calls the function with the event
+func (sf SinkFunc) Notify(event Event) error {
+	return sf(event)
+}
+
+// Options - structure of the options of the watcher
+type Options struct {
+	Interval time.Duration
+	// VoteLeadTime makes the watcher vote once the voting period ends within the lead time, which leaves the voter a
+	// chance to vote manually before. Votes as soon as the voting period starts by 0
+	VoteLeadTime time.Duration
+}
+
+// Report - structure of the result of a check by the watcher
+type Report struct {
+	Height    int64     `json:"height"`
+	BlockTime time.Time `json:"block_time"`
+	DryRun    bool      `json:"dry_run"`
+	Events    []Event   `json:"events,omitempty"`
+	// Audits are the entries appended to the audit trail in the run, or the ones to append in the dry-run mode
+	Audits []AuditEntry `json:"audits,omitempty"`
+	// Pending are the ids of the proposals in the voting period to vote on later within the lead time
+	Pending []uint64 `json:"pending,omitempty"`
+	// NotifyErrors are the errors of the sink, whose events are notified again in the next run
+	NotifyErrors []string `json:"notify_errors,omitempty"`
+}
+
+// Watcher - structure of the governance watcher, which notifies the proposal transitions and votes on the proposals
+// by the rules
+// Note: a vote accepted by the node is taken as done, so the broadcast mode block is recommended to make sure that
+// the vote is committed
+type Watcher struct {
+	cli       Client
+	fromInfo  keys.Info
+	passWd    string
+	memo      string
+	rules     Rules
+	statePath string
+	auditPath string
+	sink      Sink
+	opts      Options
+}
+
+// NewWatcher creates a new instance of Watcher. The sink is optional
+func NewWatcher(cli Client, fromInfo keys.Info, passWd, memo string, rules Rules, statePath, auditPath string,
+	sink Sink, opts Options) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.VoteLeadTime < 0 {
+		opts.VoteLeadTime = 0
+	}
+
+	return &Watcher{
+		cli:       cli,
+		fromInfo:  fromInfo,
+		passWd:    passWd,
+		memo:      memo,
+		rules:     rules,
+		statePath: statePath,
+		auditPath: auditPath,
+		sink:      sink,
+		opts:      opts,
+	}
+}
+
+// Run checks the proposals every interval until the context is done. The report and the error of each check are
+// passed to the callback
+func (w *Watcher) Run(ctx context.Context, onReport func(Report, error)) error {
+	for {
+		report, err := w.RunOnce(false)
+		if onReport != nil {
+			onReport(report, err)
+		}
+
+		timer := time.NewTimer(w.opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce notifies the new proposals and the status transitions since the last run, and votes on the proposals in
+// the voting period by the rules. Nothing is sent, notified or saved in the dry-run mode
+// Note: the proposals already in their final status at the first run are recorded without notification
+func (w *Watcher) RunOnce(dryRun bool) (report Report, err error) {
+	report.DryRun = dryRun
+	if err = params.CheckKeyParams(w.fromInfo, w.passWd); err != nil {
+		return
+	}
+
+	voterStr := w.fromInfo.GetAddress().String()
+	state, exists, err := LoadState(w.statePath)
+	if err != nil {
+		return
+	}
+
+	if exists && state.Voter != voterStr {
+		return report, fmt.Errorf("failed. state %s belongs to another voter %s", w.statePath, state.Voter)
+	}
+
+	if !exists {
+		state.Voter = voterStr
+	}
+	if state.Proposals == nil {
+		state.Proposals = make(map[uint64]*ProposalRecord)
+	}
+
+	status, err := w.cli.Tendermint().QueryStatus()
+	if err != nil {
+		return report, fmt.Errorf("failed. query status error: %s", err)
+	}
+	report.Height, report.BlockTime = status.SyncInfo.LatestBlockHeight, status.SyncInfo.LatestBlockTime
+
+	proposals, err := w.cli.Governance().QueryProposals("", "", "", 0)
+	if err != nil {
+		return
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ProposalID < proposals[j].ProposalID
+	})
+
+	votedProposals, err := w.cli.Governance().QueryProposals("", voterStr, govtypes.StatusVotingPeriod.String(), 0)
+	if err != nil {
+		return
+	}
+	voted := make(map[uint64]bool, len(votedProposals))
+	for _, proposal := range votedProposals {
+		voted[proposal.ProposalID] = true
+	}
+
+	seen := make(map[uint64]bool, len(proposals))
+	for _, proposal := range proposals {
+		seen[proposal.ProposalID] = true
+		record, ok := state.Proposals[proposal.ProposalID]
+		if !ok {
+			record = &ProposalRecord{Title: proposal.GetTitle(), Type: proposal.ProposalType()}
+			state.Proposals[proposal.ProposalID] = record
+		}
+
+		statusStr := proposal.Status.String()
+		if record.Status != statusStr {
+			if !exists && isFinalStatus(proposal.Status) {
+				record.Status, record.Done = statusStr, true
+			} else {
+				kind := EventStatusChanged
+				if len(record.Status) == 0 {
+					kind = EventNewProposal
+				}
+				if w.notify(&report, Event{
+					Kind:       kind,
+					ProposalID: proposal.ProposalID,
+					FromStatus: record.Status,
+					ToStatus:   statusStr,
+				}, record) {
+					record.Status = statusStr
+				}
+			}
+		}
+
+		if proposal.Status != govtypes.StatusVotingPeriod || record.Done {
+			continue
+		}
+
+		if err = w.vote(&state, &report, proposal, record, voted[proposal.ProposalID]); err != nil {
+			return
+		}
+	}
+
+	w.removeUnseen(&state, &report, seen)
+	if dryRun {
+		return
+	}
+
+	err = SaveState(w.statePath, state)
+	return
+}
+
+// vote votes on the proposal in the voting period by the rules once the voting period ends within the lead time
+func (w *Watcher) vote(state *State, report *Report, proposal types.Proposal, record *ProposalRecord,
+	voted bool) (err error) {
+	remaining := proposal.VotingEndTime.Sub(report.BlockTime)
+	if remaining <= 0 {
+		// the voting period is over and the proposal is waiting for the tally
+		return nil
+	}
+
+	if w.opts.VoteLeadTime > 0 && remaining > w.opts.VoteLeadTime {
+		report.Pending = append(report.Pending, proposal.ProposalID)
+		return nil
+	}
+
+	entry := AuditEntry{
+		Height:     report.Height,
+		ProposalID: proposal.ProposalID,
+		Title:      record.Title,
+		Type:       record.Type,
+	}
+	if voted {
+		record.Done = true
+		entry.Action, entry.Reason = ActionAlreadyVoted, "the voter has voted on the proposal"
+		return w.audit(state, report, entry)
+	}
+
+	if w.rules.NeedProposer() && len(record.Proposer) == 0 {
+		if record.Proposer, err = w.queryProposer(proposal.ProposalID); err != nil {
+			return
+		}
+	}
+
+	entry.Proposer = record.Proposer
+	entry.Decision = w.rules.Decide(record.Type, record.Proposer)
+	if len(entry.Option) == 0 {
+		record.Done = true
+		entry.Action = ActionSkip
+		return w.audit(state, report, entry)
+	}
+
+	entry.Action = ActionVote
+	if report.DryRun {
+		return w.audit(state, report, entry)
+	}
+
+	account, err := w.cli.Auth().QueryAccount(state.Voter)
+	if err != nil {
+		return
+	}
+
+	resp, err := w.cli.Governance().Vote(w.fromInfo, w.passWd, entry.Option, w.memo, proposal.ProposalID,
+		account.GetAccountNumber(), account.GetSequence())
+	if err == nil && resp.Code != 0 {
+		err = fmt.Errorf("failed. vote tx %s failed: %s", resp.TxHash, resp.RawLog)
+	}
+	entry.TxHash = resp.TxHash
+	if err != nil {
+		// the vote is tried again in the next run
+		entry.Action, entry.Error = ActionVoteFailed, err.Error()
+		return w.audit(state, report, entry)
+	}
+
+	record.Done, record.Option, record.TxHash = true, entry.Option, resp.TxHash
+	if err = w.audit(state, report, entry); err != nil {
+		return
+	}
+
+	w.notify(report, Event{Kind: EventVoted, ProposalID: proposal.ProposalID, Option: entry.Option,
+		TxHash: resp.TxHash}, record)
+	return nil
+}
+
+// audit appends the entry to the audit trail and saves the state, so that the action is never taken twice
+func (w *Watcher) audit(state *State, report *Report, entry AuditEntry) error {
+	entry.Time = time.Now().UTC()
+	report.Audits = append(report.Audits, entry)
+	if report.DryRun {
+		return nil
+	}
+
+	if err := AppendAudit(w.auditPath, entry); err != nil {
+		return err
+	}
+
+	return SaveState(w.statePath, *state)
+}
+
+// notify passes the event to the sink and shows whether it's delivered
+func (w *Watcher) notify(report *Report, event Event, record *ProposalRecord) bool {
+	event.Height, event.Title, event.Type = report.Height, record.Title, record.Type
+	report.Events = append(report.Events, event)
+	if report.DryRun || w.sink == nil {
+		return true
+	}
+
+	if err := w.sink.Notify(event); err != nil {
+		report.NotifyErrors = append(report.NotifyErrors, fmt.Sprintf("proposal %d %s: %s", event.ProposalID,
+			event.Kind, err))
+		return false
+	}
+
+	return true
+}
+
+// removeUnseen drops the records of the proposals deleted on chain
+func (w *Watcher) removeUnseen(state *State, report *Report, seen map[uint64]bool) {
+	var ids []uint64
+	for id := range state.Proposals {
+		if !seen[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		record := state.Proposals[id]
+		if len(record.Status) != 0 && !isFinalStatusStr(record.Status) {
+			if !w.notify(report, Event{Kind: EventRemoved, ProposalID: id, FromStatus: record.Status}, record) {
+				continue
+			}
+		}
+		delete(state.Proposals, id)
+	}
+}
+
+// queryProposer looks up the proposer from the message event of the tx that submits the proposal
+func (w *Watcher) queryProposer(proposalID uint64) (string, error) {
+	eventsStr := fmt.Sprintf("%s.%s=%d", govtypes.EventTypeSubmitProposal, govtypes.AttributeKeyProposalID,
+		proposalID)
+	res, err := w.cli.Tendermint().QueryTxsByEvents(eventsStr, 1, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed. query submission tx of proposal %d error: %s", proposalID, err)
+	}
+
+	for _, pResultTx := range res.Txs {
+		for _, attrs := range utils.ParseEventAttributes(utils.GetEventsFromResultTx(pResultTx), sdk.EventTypeMessage) {
+			if attrs[sdk.AttributeKeyModule] == govtypes.AttributeValueCategory && len(attrs[sdk.AttributeKeySender]) != 0 {
+				return attrs[sdk.AttributeKeySender], nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("failed. proposer of proposal %d not found in the txs", proposalID)
+}
+
+func isFinalStatus(status govtypes.ProposalStatus) bool {
+	return status == govtypes.StatusPassed || status == govtypes.StatusRejected || status == govtypes.StatusFailed
+}
+
+func isFinalStatusStr(statusStr string) bool {
+	status, err := govtypes.ProposalStatusFromString(statusStr)
+	return err == nil && isFinalStatus(status)
+}
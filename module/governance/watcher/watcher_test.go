@@ -0,0 +1,338 @@
+package watcher
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/governance/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	govtypes "github.com/okx/okbchain/x/gov/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	name     = "alice"
+	passWd   = "12345678"
+	mnemonic = "giggle sibling fun arrow elevator spoon blood grocery laugh tortoise culture tool"
+	memo     = "my memo"
+	proposer = "ex1qj5c07sm6jetjz8f509qtrxgh4psxkv3ddyq7u"
+)
+
+var genesisTime = time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeChain simulates the proposals and the votes of the voter on top of the shared fake chain
+type fakeChain struct {
+	*mocks.FakeChain
+	proposals []types.Proposal
+	proposers map[uint64]string
+	// votes are the options voted by the voter
+	votes map[uint64]string
+}
+
+func newFakeChain() *fakeChain {
+	fc := &fakeChain{
+		FakeChain: mocks.NewFakeChain(100, 8),
+		proposers: make(map[uint64]string),
+		votes:     make(map[uint64]string),
+	}
+	fc.BlockTime, fc.BroadcastBlock = genesisTime, true
+	return fc
+}
+
+func (fc *fakeChain) addProposal(id uint64, content types.ProposalContent, status govtypes.ProposalStatus,
+	votingEndTime time.Time, proposerStr string) {
+	fc.proposals = append(fc.proposals, types.Proposal{
+		Content:       content.Content(),
+		ProposalID:    id,
+		Status:        status,
+		VotingEndTime: votingEndTime,
+	})
+	fc.proposers[id] = proposerStr
+}
+
+func (fc *fakeChain) setStatus(id uint64, status govtypes.ProposalStatus, votingEndTime time.Time) {
+	for i := range fc.proposals {
+		if fc.proposals[i].ProposalID == id {
+			fc.proposals[i].Status, fc.proposals[i].VotingEndTime = status, votingEndTime
+		}
+	}
+}
+
+func (fc *fakeChain) removeProposal(id uint64) {
+	for i := range fc.proposals {
+		if fc.proposals[i].ProposalID == id {
+			fc.proposals = append(fc.proposals[:i], fc.proposals[i+1:]...)
+			return
+		}
+	}
+}
+
+func (fc *fakeChain) Governance() exposed.Governance { return fakeGov{chain: fc} }
+func (fc *fakeChain) Tendermint() exposed.Tendermint {
+	return fakeTendermint{Tendermint: fc.FakeChain.Tendermint(), chain: fc}
+}
+
+type fakeGov struct {
+	exposed.Governance
+	chain *fakeChain
+}
+
+func (fg fakeGov) QueryProposals(_, voterAddrStr, status string, _ uint64) (proposals []types.Proposal, err error) {
+	for _, proposal := range fg.chain.proposals {
+		if len(status) != 0 && proposal.Status.String() != status {
+			continue
+		}
+		if _, ok := fg.chain.votes[proposal.ProposalID]; len(voterAddrStr) != 0 && !ok {
+			continue
+		}
+		proposals = append(proposals, proposal)
+	}
+
+	return
+}
+
+func (fg fakeGov) Vote(_ keys.Info, _, voteOption, _ string, proposalID, _, seqNum uint64) (sdk.TxResponse, error) {
+	resp, err := fg.chain.Commit(seqNum)
+	if err == nil && resp.Code == 0 {
+		fg.chain.votes[proposalID] = voteOption
+	}
+
+	return resp, err
+}
+
+type fakeTendermint struct {
+	exposed.Tendermint
+	chain *fakeChain
+}
+
+func (ft fakeTendermint) QueryTxsByEvents(eventsStr string, _, _ int) (*ctypes.ResultTxSearch, error) {
+	prefix := fmt.Sprintf("%s.%s=", govtypes.EventTypeSubmitProposal, govtypes.AttributeKeyProposalID)
+	proposalID, err := strconv.ParseUint(strings.TrimPrefix(eventsStr, prefix), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	proposerStr, ok := ft.chain.proposers[proposalID]
+	if !ok {
+		return &ctypes.ResultTxSearch{}, nil
+	}
+
+	events := []abci.Event{{
+		Type: sdk.EventTypeMessage,
+		Attributes: []kv.Pair{
+			{Key: []byte(sdk.AttributeKeyAction), Value: []byte(govtypes.TypeMsgSubmitProposal)},
+			{Key: []byte(sdk.AttributeKeyModule), Value: []byte(govtypes.AttributeValueCategory)},
+			{Key: []byte(sdk.AttributeKeySender), Value: []byte(proposerStr)},
+		},
+	}}
+	return &ctypes.ResultTxSearch{
+		Txs:        []*ctypes.ResultTx{{TxResult: abci.ResponseDeliverTx{Events: events}}},
+		TotalCount: 1,
+	}, nil
+}
+
+func newTestWatcher(t *testing.T, chain *fakeChain, sink Sink) (*Watcher, string, string) {
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	rules, err := ParseRules(strings.NewReader(`{"rules": [
+		{"name": "params from core", "proposal_types": ["ParameterChange"], "proposers": ["` + proposer + `"], "option": "yes"},
+		{"proposal_types": ["Text"], "option": "no"}
+	]}`))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	statePath, auditPath := filepath.Join(dir, "state.json"), filepath.Join(dir, "audit.jsonl")
+	return NewWatcher(chain, fromInfo, passWd, memo, rules, statePath, auditPath, sink,
+		Options{VoteLeadTime: 2 * time.Hour}), statePath, auditPath
+}
+
+func TestWatcher_RunOnce(t *testing.T) {
+	chain := newFakeChain()
+	paramsChange := types.ParamsChangeProposal{
+		Title:       "Param Change Proposal",
+		Description: "param change proposal description",
+		Changes:     []types.ParamChange{{Subspace: "staking", Key: "MaxValidators", Value: "105"}},
+	}
+	text := types.TextProposal{Title: "Text Proposal", Description: "text proposal description"}
+	spend := types.CommunityPoolSpendProposal{Title: "Spend Proposal", Description: "spend proposal description"}
+
+	chain.addProposal(1, text, govtypes.StatusPassed, genesisTime.Add(-time.Hour), proposer)
+	chain.addProposal(2, text, govtypes.StatusDepositPeriod, time.Time{}, proposer)
+	chain.addProposal(3, paramsChange, govtypes.StatusVotingPeriod, genesisTime.Add(time.Hour), proposer)
+	chain.addProposal(4, spend, govtypes.StatusVotingPeriod, genesisTime.Add(time.Hour), proposer)
+
+	var notified []Event
+	sinkErr := error(nil)
+	watcher, statePath, auditPath := newTestWatcher(t, chain, SinkFunc(func(event Event) error {
+		if sinkErr != nil {
+			return sinkErr
+		}
+		notified = append(notified, event)
+		return nil
+	}))
+
+	// dry run
+	report, err := watcher.RunOnce(true)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(report.Events))
+	require.Equal(t, 2, len(report.Audits))
+	require.Equal(t, ActionVote, report.Audits[0].Action)
+	require.Equal(t, "Yes", report.Audits[0].Option)
+	require.Equal(t, proposer, report.Audits[0].Proposer)
+	require.Equal(t, ActionSkip, report.Audits[1].Action)
+	require.Empty(t, notified)
+	require.Empty(t, chain.votes)
+	_, exists, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// the passed proposal before the first run isn't notified
+	report, err = watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(notified))
+	for i, kind := range []string{EventNewProposal, EventNewProposal, EventVoted, EventNewProposal} {
+		require.Equal(t, kind, notified[i].Kind)
+		require.Equal(t, []uint64{2, 3, 3, 4}[i], notified[i].ProposalID)
+	}
+	require.Equal(t, chain.Txs[0].Hash.String(), notified[2].TxHash)
+	require.Equal(t, map[uint64]string{3: "Yes"}, chain.votes)
+
+	entries, err := LoadAudit(auditPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	require.Equal(t, ActionVote, entries[0].Action)
+	require.Equal(t, "params from core", entries[0].Rule)
+	require.Equal(t, chain.Txs[0].Hash.String(), entries[0].TxHash)
+	require.Equal(t, ActionSkip, entries[1].Action)
+	require.Equal(t, uint64(4), entries[1].ProposalID)
+
+	// nothing to do without changes
+	report, err = watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Empty(t, report.Events)
+	require.Empty(t, report.Audits)
+
+	// transitions, where the voting period of the text proposal ends beyond the lead time
+	chain.Height, chain.BlockTime = 200, genesisTime.Add(90*time.Minute)
+	chain.setStatus(2, govtypes.StatusVotingPeriod, genesisTime.Add(6*time.Hour))
+	chain.setStatus(3, govtypes.StatusPassed, genesisTime.Add(time.Hour))
+	chain.addProposal(5, text, govtypes.StatusDepositPeriod, time.Time{}, proposer)
+	sinkErr = errors.New("sink unavailable")
+	report, err = watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(report.Events))
+	require.Equal(t, 3, len(report.NotifyErrors))
+	require.Equal(t, []uint64{2}, report.Pending)
+
+	// the events failed to notify are notified again
+	sinkErr, notified = nil, nil
+	report, err = watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(notified))
+	require.Equal(t, EventStatusChanged, notified[0].Kind)
+	require.Equal(t, govtypes.StatusDepositPeriod.String(), notified[0].FromStatus)
+	require.Equal(t, govtypes.StatusVotingPeriod.String(), notified[0].ToStatus)
+	require.Equal(t, govtypes.StatusPassed.String(), notified[1].ToStatus)
+	require.Equal(t, EventNewProposal, notified[2].Kind)
+
+	// the voter votes manually before the lead time, and the proposal failed to reach the min deposit is removed
+	chain.votes[2] = "No"
+	chain.BlockTime = genesisTime.Add(5 * time.Hour)
+	chain.removeProposal(5)
+	notified = nil
+	report, err = watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(notified))
+	require.Equal(t, EventRemoved, notified[0].Kind)
+	require.Equal(t, uint64(5), notified[0].ProposalID)
+	require.Equal(t, 1, len(report.Audits))
+	require.Equal(t, ActionAlreadyVoted, report.Audits[0].Action)
+	require.Equal(t, "No", chain.votes[2])
+
+	state, exists, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, 4, len(state.Proposals))
+	require.True(t, state.Proposals[2].Done)
+	require.Equal(t, "Yes", state.Proposals[3].Option)
+
+	entries, err = LoadAudit(auditPath)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+}
+
+func TestWatcher_RunOnceVoteFailed(t *testing.T) {
+	chain := newFakeChain()
+	text := types.TextProposal{Title: "Text Proposal", Description: "text proposal description"}
+	chain.addProposal(1, text, govtypes.StatusVotingPeriod, genesisTime.Add(time.Hour), proposer)
+	watcher, _, auditPath := newTestWatcher(t, chain, nil)
+
+	// the failed vote is tried again in the next run
+	chain.Fail = true
+	report, err := watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(report.Audits))
+	require.Equal(t, ActionVoteFailed, report.Audits[0].Action)
+	require.Equal(t, chain.Txs[0].Hash.String(), report.Audits[0].TxHash)
+	require.Empty(t, chain.votes)
+
+	report, err = watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(report.Audits))
+	require.Equal(t, ActionVote, report.Audits[0].Action)
+	require.Equal(t, "No", report.Audits[0].Option)
+	require.Equal(t, map[uint64]string{1: "No"}, chain.votes)
+
+	entries, err := LoadAudit(auditPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+
+	// the voting period is over
+	chain.addProposal(2, text, govtypes.StatusVotingPeriod, genesisTime.Add(-time.Minute), proposer)
+	report, err = watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Empty(t, report.Audits)
+	require.Empty(t, report.Pending)
+
+	// error
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	otherWatcher := NewWatcher(chain, fromInfo, "", memo, Rules{}, watcher.statePath, auditPath, nil, Options{})
+	_, err = otherWatcher.RunOnce(false)
+	require.Error(t, err)
+}
+
+func TestWatcher_RunOnceNoLeadTime(t *testing.T) {
+	chain := newFakeChain()
+	text := types.TextProposal{Title: "Text Proposal", Description: "text proposal description"}
+	chain.addProposal(1, text, govtypes.StatusVotingPeriod, genesisTime.Add(6*time.Hour), proposer)
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	rules, err := ParseRules(strings.NewReader(`{"rules": [{"proposal_types": ["Text"], "option": "no"}]}`))
+	require.NoError(t, err)
+	dir := t.TempDir()
+	watcher := NewWatcher(chain, fromInfo, passWd, memo, rules, filepath.Join(dir, "state.json"),
+		filepath.Join(dir, "audit.jsonl"), nil, Options{})
+
+	// votes as soon as the voting period starts
+	report, err := watcher.RunOnce(false)
+	require.NoError(t, err)
+	require.Empty(t, report.Pending)
+	require.Equal(t, 1, len(report.Audits))
+	require.Equal(t, ActionVote, report.Audits[0].Action)
+	require.Equal(t, map[uint64]string{1: "No"}, chain.votes)
+}
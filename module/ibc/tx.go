@@ -3,8 +3,8 @@ package ibc
 import (
 	"fmt"
 	"github.com/okex/exchain-go-sdk/module/auth"
+	"github.com/okex/exchain-go-sdk/utils"
 	ibcmsg "github.com/okx/okbchain/libs/cosmos-sdk/types/ibc-adapter"
-	"math/big"
 	"strings"
 
 	"github.com/okex/exchain-go-sdk/module"
@@ -60,33 +60,30 @@ func (ibc ibcClient) Transfer(priKey tmcrypto.PrivKey, srcChannel string, receiv
 
 		return sdk.TxResponse{}, err
 	}
-	coins, err := sdk.ParseDecCoins(amount)
+	coins, err := utils.ParseDecCoins(amount)
 	if err != nil {
 		return sdk.TxResponse{}, err
 	}
-
-	coin := coins[0]
-
-	if coin.Denom == sdk.DefaultIbcWei {
-		coin.Amount = sdk.Dec{
-			coin.Amount.Div(coin.Amount.BigInt(), big.NewInt(sdk.DefaultDecInt)),
-		}
+	if len(coins) != 1 {
+		return sdk.TxResponse{}, fmt.Errorf("failed. only one kind of coin can be transferred: %s", amount)
 	}
-	if coin.Denom == "okt" || coin.Denom == "OKT" {
-		coin.Denom = "wei"
+
+	// okt is converted to wei in base denom without truncation
+	token, err := utils.DecCoinToCoinAdapter(coins[0])
+	if err != nil {
+		return sdk.TxResponse{}, err
 	}
 
-	//
-	if !strings.HasPrefix(coin.Denom, "ibc/") {
-		denomTrace := ibc_type.ParseDenomTrace(coin.Denom)
-		coin.Denom = denomTrace.IBCDenom()
+	if !strings.HasPrefix(token.Denom, "ibc/") {
+		denomTrace := ibc_type.ParseDenomTrace(token.Denom)
+		token.Denom = denomTrace.IBCDenom()
 	}
 
 	// generate msg
 	msg := &ibc_type.MsgTransfer{
 		SourcePort:       src_port,
 		SourceChannel:    srcChannel,
-		Token:            sdk.NewCoinAdapter(coin.Denom, sdk.NewIntFromBigInt(coin.Amount.BigInt())),
+		Token:            token,
 		Sender:           sdk.AccAddress(pubKey.Address().Bytes()).String(),
 		Receiver:         receiver,
 		TimeoutHeight:    timeoutHeight,
@@ -0,0 +1,355 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/slashing/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
+)
+
+// const
+const (
+	// DefaultInterval is the default interval between two polls of the long-running monitor
+	DefaultInterval = 10 * time.Second
+	// DefaultConsecutiveMissed is the default number of blocks missed in a row that raises an alert
+	DefaultConsecutiveMissed = 5
+	// DefaultMaxBlocksPerPoll is the default max number of blocks checked in a poll
+	DefaultMaxBlocksPerPoll = 50
+
+	// the max page limit of the validator set on the node
+	validatorsPageLimit = 100
+)
+
+// kinds of the alert
+const (
+	// AlertMissedThreshold means that the missed blocks counter in the window reaches the alert ratio of the max
+	// missed blocks before the downtime jail
+	AlertMissedThreshold = "missed_threshold"
+	// AlertConsecutiveMissed means that the validator missed the blocks in a row
+	AlertConsecutiveMissed = "consecutive_missed"
+	// AlertJailed means that the validator has been jailed
+	AlertJailed = "jailed"
+	// AlertTombstoned means that the validator has been tombstoned for double signing
+	AlertTombstoned = "tombstoned"
+)
+
+// DefaultAlertRatio is the default ratio of the max missed blocks before the downtime jail that raises an alert
+var DefaultAlertRatio = sdk.NewDecWithPrec(5, 1)
+
+// Client shows the expected behavior of the client that the monitor works with
+type Client interface {
+	Slashing() exposed.Slashing
+	Staking() exposed.Staking
+	Tendermint() exposed.Tendermint
+}
+
+// Options - structure of the options of the monitor
+type Options struct {
+	// AlertRatio is the ratio of the max missed blocks before the downtime jail, at which the missed blocks counter
+	// raises an alert. It should be in (0, 1]
+	AlertRatio        sdk.Dec
+	ConsecutiveMissed int64
+	Interval          time.Duration
+	// MaxBlocksPerPoll limits the blocks checked in a poll. The older blocks are skipped when the monitor falls behind
+	MaxBlocksPerPoll int64
+}
+
+// Alert - structure of an alert raised by the monitor
+type Alert struct {
+	Kind        string `json:"kind"`
+	ConsAddress string `json:"cons_address"`
+	Height      int64  `json:"height"`
+	Message     string `json:"message"`
+}
+
+// ValidatorStatus - structure of the uptime of a validator
+type ValidatorStatus struct {
+	ConsAddress string `json:"cons_address"`
+	// Signed and Missed are the numbers of the blocks that the validator signed or missed in the poll, while it was in
+	// the validator set
+	Signed            int64 `json:"signed"`
+	Missed            int64 `json:"missed"`
+	ConsecutiveMissed int64 `json:"consecutive_missed"`
+	LastSignedHeight  int64 `json:"last_signed_height,omitempty"`
+	// MissedBlocksCounter is the number of the blocks missed in the signed blocks window on chain
+	MissedBlocksCounter int64 `json:"missed_blocks_counter"`
+	MaxMissed           int64 `json:"max_missed"`
+	// BlocksToJail is the number of the blocks that the validator can still miss in the window before the jail
+	BlocksToJail int64 `json:"blocks_to_jail"`
+	// Jailed is the jail flag of the validator in staking, which stays on until an unjail tx after the jail period
+	Jailed      bool      `json:"jailed"`
+	JailedUntil time.Time `json:"jailed_until"`
+	Tombstoned  bool      `json:"tombstoned"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Report - structure of the result of a poll by the monitor
+type Report struct {
+	// FromHeight and ToHeight are the range of the blocks checked in the poll. FromHeight is greater than ToHeight when
+	// there's no new block
+	FromHeight int64             `json:"from_height"`
+	ToHeight   int64             `json:"to_height"`
+	BlockTime  time.Time         `json:"block_time"`
+	Statuses   []ValidatorStatus `json:"statuses"`
+	Alerts     []Alert           `json:"alerts"`
+}
+
+// tracker keeps the progress of a validator between polls, so that each alert is raised only once until it's
+// resolved
+type tracker struct {
+	consAddr           sdk.ConsAddress
+	consecutive        int64
+	lastSignedHeight   int64
+	consecutiveAlerted bool
+	thresholdAlerted   bool
+	tombstoneAlerted   bool
+	jailedUntilAlerted time.Time
+}
+
+// Monitor - structure of the uptime monitor, which tracks the commit signatures of a set of validators block by block
+// and raises the alerts before the downtime jail threshold is hit
+type Monitor struct {
+	cli        Client
+	trackers   []*tracker
+	opts       Options
+	lastHeight int64
+}
+
+// NewMonitor creates a new instance of Monitor with the consensus addresses of the validators to track
+func NewMonitor(cli Client, consAddrsStr []string, opts Options) (*Monitor, error) {
+	if len(consAddrsStr) == 0 {
+		return nil, errors.New("failed. empty consensus address")
+	}
+
+	trackers := make([]*tracker, len(consAddrsStr))
+	for i, consAddrStr := range consAddrsStr {
+		consAddr, err := sdk.ConsAddressFromBech32(consAddrStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed. invalid consensus address %s: %s", consAddrStr, err)
+		}
+		trackers[i] = &tracker{consAddr: consAddr}
+	}
+
+	if opts.AlertRatio.IsNil() {
+		opts.AlertRatio = DefaultAlertRatio
+	}
+	if !opts.AlertRatio.IsPositive() || opts.AlertRatio.GT(sdk.OneDec()) {
+		return nil, fmt.Errorf("failed. alert ratio %s is out of (0, 1]", opts.AlertRatio)
+	}
+	if opts.ConsecutiveMissed <= 0 {
+		opts.ConsecutiveMissed = DefaultConsecutiveMissed
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.MaxBlocksPerPoll <= 0 {
+		opts.MaxBlocksPerPoll = DefaultMaxBlocksPerPoll
+	}
+
+	return &Monitor{
+		cli:      cli,
+		trackers: trackers,
+		opts:     opts,
+	}, nil
+}
+
+// Run polls every interval until the context is done. The report and the error of each poll are passed to the callback
+func (m *Monitor) Run(ctx context.Context, onReport func(Report, error)) error {
+	for {
+		report, err := m.RunOnce()
+		if onReport != nil {
+			onReport(report, err)
+		}
+
+		timer := time.NewTimer(m.opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce checks the commit signatures of the blocks since the last poll and the signing infos of the validators
+// Note: the commit of the latest block isn't canonical yet, so the blocks are checked up to the one before the latest
+func (m *Monitor) RunOnce() (report Report, err error) {
+	status, err := m.cli.Tendermint().QueryStatus()
+	if err != nil {
+		return report, fmt.Errorf("failed. query status error: %s", err)
+	}
+
+	report.ToHeight, report.BlockTime = status.SyncInfo.LatestBlockHeight-1, status.SyncInfo.LatestBlockTime
+	report.FromHeight = m.lastHeight + 1
+	if m.lastHeight == 0 || report.ToHeight-m.lastHeight > m.opts.MaxBlocksPerPoll {
+		report.FromHeight = report.ToHeight - m.opts.MaxBlocksPerPoll + 1
+	}
+	if report.FromHeight < 1 {
+		report.FromHeight = 1
+	}
+
+	params, err := m.cli.Slashing().QueryParams()
+	if err != nil {
+		return report, fmt.Errorf("failed. query slashing params error: %s", err)
+	}
+	maxMissed := maxMissedBlocks(params)
+	threshold := m.opts.AlertRatio.MulInt64(maxMissed).TruncateInt64()
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	vals, err := m.cli.Staking().QueryValidators()
+	if err != nil {
+		return report, fmt.Errorf("failed. query validators error: %s", err)
+	}
+	// the jail flag in staking stays on until the validator is unjailed
+	jailed := make(map[string]bool, len(vals))
+	for _, val := range vals {
+		jailed[val.ConsAddress().String()] = val.Jailed
+	}
+
+	report.Statuses = make([]ValidatorStatus, len(m.trackers))
+	for i, t := range m.trackers {
+		consAddr := t.consAddr.String()
+		report.Statuses[i] = ValidatorStatus{ConsAddress: consAddr, MaxMissed: maxMissed, Jailed: jailed[consAddr]}
+	}
+
+	for height := report.FromHeight; height <= report.ToHeight; height++ {
+		if err = m.checkBlock(height, &report); err != nil {
+			report.ToHeight = height - 1
+			break
+		}
+		m.lastHeight = height
+	}
+
+	for i, t := range m.trackers {
+		m.checkSigningInfo(t, &report.Statuses[i], threshold, &report)
+	}
+
+	return report, err
+}
+
+// checkBlock counts the signatures of the validators tracked in the commit of a block
+func (m *Monitor) checkBlock(height int64, report *Report) error {
+	commit, err := m.cli.Tendermint().QueryCommitResult(height)
+	if err != nil {
+		return fmt.Errorf("failed. query commit of height %d error: %s", height, err)
+	}
+	if commit.Commit == nil {
+		return fmt.Errorf("failed. empty commit of height %d", height)
+	}
+
+	vals, err := m.queryValidatorSet(height)
+	if err != nil {
+		return err
+	}
+
+	// the signatures are in the order of the validator set, and an absent one carries no address
+	sigs := commit.Commit.Signatures
+	for i, t := range m.trackers {
+		signed, inSet := false, false
+		for j, val := range vals {
+			if bytes.Equal(val.Address, t.consAddr) && j < len(sigs) {
+				signed, inSet = !sigs[j].Absent(), true
+				break
+			}
+		}
+		if !inSet {
+			continue
+		}
+
+		valStatus := &report.Statuses[i]
+		if signed {
+			valStatus.Signed++
+			t.consecutive, t.lastSignedHeight, t.consecutiveAlerted = 0, height, false
+			continue
+		}
+
+		valStatus.Missed++
+		t.consecutive++
+		if t.consecutive >= m.opts.ConsecutiveMissed && !t.consecutiveAlerted {
+			t.consecutiveAlerted = true
+			report.Alerts = append(report.Alerts, Alert{
+				Kind:        AlertConsecutiveMissed,
+				ConsAddress: valStatus.ConsAddress,
+				Height:      height,
+				Message:     fmt.Sprintf("%d blocks missed in a row up to height %d", t.consecutive, height),
+			})
+		}
+	}
+
+	return nil
+}
+
+// queryValidatorSet pages through the whole validator set of the height
+func (m *Monitor) queryValidatorSet(height int64) (vals []*tmtypes.Validator, err error) {
+	for page := 1; ; page++ {
+		valsResult, err := m.cli.Tendermint().QueryValidatorsResultByPage(height, page, validatorsPageLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed. query validators of height %d error: %s", height, err)
+		}
+
+		vals = append(vals, valsResult.Validators...)
+		if len(valsResult.Validators) == 0 || len(vals) >= valsResult.Total {
+			return vals, nil
+		}
+	}
+}
+
+// checkSigningInfo fills the status of a validator with its signing info on chain and raises the alerts
+func (m *Monitor) checkSigningInfo(t *tracker, valStatus *ValidatorStatus, threshold int64, report *Report) {
+	valStatus.ConsecutiveMissed, valStatus.LastSignedHeight = t.consecutive, t.lastSignedHeight
+
+	info, err := m.cli.Slashing().QuerySigningInfo(valStatus.ConsAddress)
+	if err != nil {
+		valStatus.Error = err.Error()
+		return
+	}
+
+	valStatus.MissedBlocksCounter, valStatus.Tombstoned = info.MissedBlocksCounter, info.Tombstoned
+	valStatus.JailedUntil = info.JailedUntil
+	if valStatus.BlocksToJail = valStatus.MaxMissed - info.MissedBlocksCounter; valStatus.BlocksToJail < 0 {
+		valStatus.BlocksToJail = 0
+	}
+
+	newAlert := func(kind, message string) {
+		report.Alerts = append(report.Alerts, Alert{
+			Kind:        kind,
+			ConsAddress: valStatus.ConsAddress,
+			Height:      report.ToHeight,
+			Message:     message,
+		})
+	}
+
+	if info.Tombstoned && !t.tombstoneAlerted {
+		t.tombstoneAlerted = true
+		newAlert(AlertTombstoned, "tombstoned for double signing")
+	}
+
+	if valStatus.Jailed && !info.JailedUntil.Equal(t.jailedUntilAlerted) {
+		t.jailedUntilAlerted = info.JailedUntil
+		newAlert(AlertJailed, fmt.Sprintf("jailed until %s", info.JailedUntil.Format(time.RFC3339)))
+	}
+
+	// the counter is reset on the jail, which rearms the alert
+	switch {
+	case info.MissedBlocksCounter < threshold:
+		t.thresholdAlerted = false
+	case !t.thresholdAlerted:
+		t.thresholdAlerted = true
+		newAlert(AlertMissedThreshold, fmt.Sprintf("%d blocks missed in the window with %d allowed, jailed after %d more",
+			info.MissedBlocksCounter, valStatus.MaxMissed, valStatus.BlocksToJail))
+	}
+}
+
+// maxMissedBlocks gets the max number of the blocks missed in the window without the jail, in the same way as the
+// slashing module
+func maxMissedBlocks(params types.Params) int64 {
+	return params.SignedBlocksWindow - params.MinSignedPerWindow.MulInt64(params.SignedBlocksWindow).RoundInt64()
+}
@@ -0,0 +1,263 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/slashing/types"
+	stakingtypes "github.com/okex/exchain-go-sdk/module/staking/types"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/libs/tendermint/crypto/ed25519"
+	tmtypesbase "github.com/okx/okbchain/libs/tendermint/types"
+	"github.com/okx/okbchain/x/slashing"
+	"github.com/stretchr/testify/require"
+)
+
+var genesisTime = time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func newConsPubKey(b byte) ed25519.PubKeyEd25519 {
+	return ed25519.GenPrivKeyFromSecret([]byte{b}).PubKey().(ed25519.PubKeyEd25519)
+}
+
+func newConsAddr(b byte) sdk.ConsAddress {
+	return sdk.ConsAddress(newConsPubKey(b).Address())
+}
+
+// fakeChain simulates the commits and the signing infos of the validators on top of the shared fake chain
+type fakeChain struct {
+	*mocks.FakeChain
+	// vals are the validators in the validator set, which is paged by pageLimit at most
+	vals      []sdk.ConsAddress
+	pageLimit int
+	// absent are the validators that missed the block of a height
+	absent     map[int64][]sdk.ConsAddress
+	infos      map[string]types.ValidatorSigningInfo
+	failHeight int64
+}
+
+func newFakeChain() *fakeChain {
+	fc := &fakeChain{
+		FakeChain: mocks.NewFakeChain(0, 0),
+		pageLimit: validatorsPageLimit,
+		absent:    make(map[int64][]sdk.ConsAddress),
+		infos:     make(map[string]types.ValidatorSigningInfo),
+	}
+	fc.BlockTime = genesisTime
+	return fc
+}
+
+func (fc *fakeChain) miss(consAddr sdk.ConsAddress, heights ...int64) {
+	for _, height := range heights {
+		fc.absent[height] = append(fc.absent[height], consAddr)
+	}
+}
+
+func (fc *fakeChain) setInfo(consAddr sdk.ConsAddress, counter int64, jailedUntil time.Time, tombstoned bool) {
+	fc.infos[consAddr.String()] = slashing.NewValidatorSigningInfo(consAddr, 1, 0, jailedUntil, tombstoned, counter, 0)
+}
+
+func (fc *fakeChain) Slashing() exposed.Slashing { return fakeSlashing{chain: fc} }
+func (fc *fakeChain) Tendermint() exposed.Tendermint {
+	return fakeTendermint{Tendermint: fc.FakeChain.Tendermint(), chain: fc}
+}
+
+type fakeSlashing struct {
+	exposed.Slashing
+	chain *fakeChain
+}
+
+func (fakeSlashing) QueryParams() (types.Params, error) {
+	params := slashing.DefaultParams()
+	params.SignedBlocksWindow, params.MinSignedPerWindow = 100, sdk.NewDecWithPrec(5, 1)
+	return params, nil
+}
+
+func (fs fakeSlashing) QuerySigningInfo(consAddrStr string) (types.ValidatorSigningInfo, error) {
+	info, ok := fs.chain.infos[consAddrStr]
+	if !ok {
+		return info, errors.New("no signing info found")
+	}
+
+	return info, nil
+}
+
+type fakeTendermint struct {
+	exposed.Tendermint
+	chain *fakeChain
+}
+
+func (ft fakeTendermint) QueryCommitResult(height int64) (*tmtypes.ResultCommit, error) {
+	if height == ft.chain.failHeight {
+		return nil, errors.New("default error")
+	}
+
+	var sigs []tmtypesbase.CommitSig
+	for _, consAddr := range ft.chain.vals {
+		sig := tmtypesbase.NewCommitSigForBlock([]byte("sig"), consAddr.Bytes(), ft.chain.BlockTime)
+		for _, absent := range ft.chain.absent[height] {
+			if absent.Equals(consAddr) {
+				sig = tmtypesbase.NewCommitSigAbsent()
+			}
+		}
+		sigs = append(sigs, sig)
+	}
+
+	return &tmtypes.ResultCommit{SignedHeader: tmtypesbase.SignedHeader{
+		Commit: &tmtypesbase.Commit{Height: height, Signatures: sigs},
+	}}, nil
+}
+
+func (ft fakeTendermint) QueryValidatorsResultByPage(height int64, page, limit int) (*tmtypes.ResultValidators,
+	error) {
+	if limit > ft.chain.pageLimit {
+		limit = ft.chain.pageLimit
+	}
+
+	var vals []*tmtypesbase.Validator
+	for i := (page - 1) * limit; i < page*limit && i < len(ft.chain.vals); i++ {
+		vals = append(vals, &tmtypesbase.Validator{Address: ft.chain.vals[i].Bytes(), VotingPower: 10})
+	}
+
+	return &tmtypes.ResultValidators{
+		BlockHeight: height,
+		Validators:  vals,
+		Count:       len(vals),
+		Total:       len(ft.chain.vals),
+	}, nil
+}
+
+func TestMonitor_RunOnce(t *testing.T) {
+	valA, valB, valC, valD := newConsAddr(1), newConsAddr(2), newConsAddr(3), newConsAddr(4)
+	chain := newFakeChain()
+	chain.vals, chain.pageLimit = []sdk.ConsAddress{valA, valB, valC}, 2
+	chain.setInfo(valA, 3, time.Time{}, false)
+	chain.setInfo(valB, 0, time.Time{}, false)
+	chain.setInfo(valC, 0, time.Time{}, false)
+
+	monitor, err := NewMonitor(chain, []string{valA.String(), valB.String(), valC.String(), valD.String()},
+		Options{ConsecutiveMissed: 3, MaxBlocksPerPoll: 10})
+	require.NoError(t, err)
+
+	// 1. the first poll checks the last blocks before the latest
+	chain.Height = 11
+	chain.miss(valA, 8, 9, 10)
+	chain.miss(valC, 5)
+	report, err := monitor.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), report.FromHeight)
+	require.Equal(t, int64(10), report.ToHeight)
+	require.Equal(t, []Alert{{
+		Kind:        AlertConsecutiveMissed,
+		ConsAddress: valA.String(),
+		Height:      10,
+		Message:     "3 blocks missed in a row up to height 10",
+	}}, report.Alerts)
+
+	statusA := report.Statuses[0]
+	require.Equal(t, int64(7), statusA.Signed)
+	require.Equal(t, int64(3), statusA.Missed)
+	require.Equal(t, int64(3), statusA.ConsecutiveMissed)
+	require.Equal(t, int64(7), statusA.LastSignedHeight)
+	require.Equal(t, int64(3), statusA.MissedBlocksCounter)
+	require.Equal(t, int64(50), statusA.MaxMissed)
+	require.Equal(t, int64(47), statusA.BlocksToJail)
+	require.False(t, statusA.Jailed)
+	// on the second page of the validator set
+	require.Equal(t, int64(9), report.Statuses[2].Signed)
+	require.Equal(t, int64(1), report.Statuses[2].Missed)
+	// not in the validator set
+	statusD := report.Statuses[3]
+	require.Zero(t, statusD.Signed+statusD.Missed)
+	require.NotEmpty(t, statusD.Error)
+
+	// 2. the streak goes on without another alert, and the counter reaches the alert ratio
+	chain.Height, chain.BlockTime = 13, genesisTime.Add(10*time.Second)
+	chain.miss(valA, 11)
+	chain.setInfo(valA, 25, time.Time{}, false)
+	chain.setInfo(valB, 0, time.Time{}, true)
+	report, err = monitor.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, int64(11), report.FromHeight)
+	require.Equal(t, int64(12), report.ToHeight)
+	require.Equal(t, 2, len(report.Alerts))
+	require.Equal(t, AlertTombstoned, report.Alerts[1].Kind)
+	require.Equal(t, valB.String(), report.Alerts[1].ConsAddress)
+	require.Equal(t, AlertMissedThreshold, report.Alerts[0].Kind)
+	require.Equal(t, valA.String(), report.Alerts[0].ConsAddress)
+	require.Equal(t, int64(12), report.Alerts[0].Height)
+	require.Equal(t, int64(25), report.Statuses[0].BlocksToJail)
+	require.Equal(t, int64(0), report.Statuses[0].ConsecutiveMissed)
+	require.Equal(t, int64(12), report.Statuses[0].LastSignedHeight)
+
+	// 3. no new block and no alert raised twice
+	chain.setInfo(valA, 30, time.Time{}, false)
+	report, err = monitor.RunOnce()
+	require.NoError(t, err)
+	require.True(t, report.FromHeight > report.ToHeight)
+	require.Empty(t, report.Alerts)
+
+	// 4. jailed, which resets the counter
+	chain.Height, chain.BlockTime = 14, genesisTime.Add(20*time.Second)
+	jailedUntil := chain.BlockTime.Add(time.Hour)
+	chain.setInfo(valA, 0, jailedUntil, false)
+	chain.Validators = append(chain.Validators, stakingtypes.Validator{ConsPubKey: newConsPubKey(1), Jailed: true})
+	report, err = monitor.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(report.Alerts))
+	require.Equal(t, AlertJailed, report.Alerts[0].Kind)
+	require.True(t, report.Statuses[0].Jailed)
+	require.Equal(t, jailedUntil, report.Statuses[0].JailedUntil)
+
+	// 5. the threshold alert is rearmed after the jail, and the validator is still jailed after the jail period until
+	// it's unjailed
+	chain.Height, chain.BlockTime = 15, jailedUntil.Add(time.Second)
+	chain.setInfo(valA, 25, jailedUntil, false)
+	report, err = monitor.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(report.Alerts))
+	require.Equal(t, AlertMissedThreshold, report.Alerts[0].Kind)
+	require.True(t, report.Statuses[0].Jailed)
+
+	// 6. the poll stops at the failed block and resumes from it in the next poll
+	chain.Height, chain.failHeight = 20, 17
+	report, err = monitor.RunOnce()
+	require.Error(t, err)
+	require.Equal(t, int64(15), report.FromHeight)
+	require.Equal(t, int64(16), report.ToHeight)
+
+	chain.failHeight = 0
+	report, err = monitor.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, int64(17), report.FromHeight)
+	require.Equal(t, int64(19), report.ToHeight)
+
+	// 7. the older blocks are skipped when the monitor falls behind
+	chain.Height = 100
+	report, err = monitor.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, int64(90), report.FromHeight)
+	require.Equal(t, int64(99), report.ToHeight)
+}
+
+func TestNewMonitor(t *testing.T) {
+	chain := newFakeChain()
+	_, err := NewMonitor(chain, nil, Options{})
+	require.Error(t, err)
+
+	_, err = NewMonitor(chain, []string{"exvalcons1bad"}, Options{})
+	require.Error(t, err)
+
+	_, err = NewMonitor(chain, []string{newConsAddr(1).String()}, Options{AlertRatio: sdk.NewDec(2)})
+	require.Error(t, err)
+
+	monitor, err := NewMonitor(chain, []string{newConsAddr(1).String()}, Options{})
+	require.NoError(t, err)
+	require.True(t, DefaultAlertRatio.Equal(monitor.opts.AlertRatio))
+	require.Equal(t, int64(DefaultConsecutiveMissed), monitor.opts.ConsecutiveMissed)
+	require.Equal(t, DefaultInterval, monitor.opts.Interval)
+	require.Equal(t, int64(DefaultMaxBlocksPerPoll), monitor.opts.MaxBlocksPerPoll)
+}
@@ -0,0 +1,105 @@
+package slashing
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/okex/exchain-go-sdk/module/slashing/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/x/slashing"
+)
+
+// QueryParams gets the current params of slashing module
+func (sc slashingClient) QueryParams() (params types.Params, err error) {
+	err = sc.query(slashing.QueryParameters, nil, &params)
+	return
+}
+
+// QuerySigningInfo gets the signing info of a validator by its consensus address
+func (sc slashingClient) QuerySigningInfo(consAddrStr string) (signingInfo types.ValidatorSigningInfo, err error) {
+	consAddr, err := sdk.ConsAddressFromBech32(consAddrStr)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := sc.GetCodec().MarshalJSON(slashing.NewQuerySigningInfoParams(consAddr))
+	if err != nil {
+		return signingInfo, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = sc.query(slashing.QuerySigningInfo, jsonBytes, &signingInfo)
+	return
+}
+
+// QuerySigningInfos gets a page of the signing infos of all validators
+func (sc slashingClient) QuerySigningInfos(page, limit int) (signingInfos []types.ValidatorSigningInfo, err error) {
+	jsonBytes, err := sc.GetCodec().MarshalJSON(slashing.NewQuerySigningInfosParams(page, limit))
+	if err != nil {
+		return signingInfos, utils.ErrMarshalJSON(err.Error())
+	}
+
+	err = sc.query(slashing.QuerySigningInfos, jsonBytes, &signingInfos)
+	return
+}
+
+// QueryMissedBlocks gets the missed-blocks bitmap of a validator in the current signed blocks window, sorted by the
+// index in the window
+// Note: the bitmap is sparse, and the index not returned has never been signed in the window
+func (sc slashingClient) QueryMissedBlocks(consAddrStr string) (missedBlocks []types.MissedBlock, err error) {
+	consAddr, err := sdk.ConsAddressFromBech32(consAddrStr)
+	if err != nil {
+		return
+	}
+
+	prefix := slashing.GetValidatorMissedBlockBitArrayPrefixKey(consAddr)
+	res, _, err := sc.QueryStore(prefix, slashing.StoreKey, "subspace")
+	if err != nil {
+		return missedBlocks, utils.ErrClientQuery(err.Error())
+	}
+	if len(res) == 0 {
+		return
+	}
+
+	var kvPairs []sdk.KVPair
+	if err = sc.GetCodec().UnmarshalBinaryLengthPrefixed(res, &kvPairs); err != nil {
+		return missedBlocks, fmt.Errorf("failed. unmarshal missed blocks error: %s", err)
+	}
+
+	for _, kvPair := range kvPairs {
+		if len(kvPair.Key) != len(prefix)+8 {
+			return nil, fmt.Errorf("failed. invalid missed block key: %X", kvPair.Key)
+		}
+
+		var missed bool
+		if err = sc.GetCodec().UnmarshalBinaryLengthPrefixed(kvPair.Value, &missed); err != nil {
+			return nil, fmt.Errorf("failed. unmarshal missed block error: %s", err)
+		}
+
+		missedBlocks = append(missedBlocks, types.MissedBlock{
+			Index:  int64(binary.LittleEndian.Uint64(kvPair.Key[len(prefix):])),
+			Missed: missed,
+		})
+	}
+
+	sort.Slice(missedBlocks, func(i, j int) bool {
+		return missedBlocks[i].Index < missedBlocks[j].Index
+	})
+
+	return
+}
+
+func (sc slashingClient) query(route string, jsonBytes []byte, ptr interface{}) error {
+	path := fmt.Sprintf("custom/%s/%s", slashing.QuerierRoute, route)
+	res, _, err := sc.Query(path, jsonBytes)
+	if err != nil {
+		return utils.ErrClientQuery(err.Error())
+	}
+
+	if err = sc.GetCodec().UnmarshalJSON(res, ptr); err != nil {
+		return utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package slashing
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/slashing/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	tmbytes "github.com/okx/okbchain/libs/tendermint/libs/bytes"
+	"github.com/okx/okbchain/x/slashing"
+	"github.com/stretchr/testify/require"
+)
+
+const consAddr = "exvalcons1w9sl5du760f70q5772hdx8slzmk9zrklwtvf05"
+
+func TestSlashingClient_QueryParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewSlashingClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	expectedParams := slashing.DefaultParams()
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(expectedParams)
+	expectedPath := fmt.Sprintf("custom/%s/%s", slashing.QuerierRoute, slashing.QueryParameters)
+	mockCli.EXPECT().Query(expectedPath, nil).Return(expectedRet, int64(1024), nil)
+
+	params, err := mockCli.Slashing().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, expectedParams.SignedBlocksWindow, params.SignedBlocksWindow)
+	require.True(t, expectedParams.MinSignedPerWindow.Equal(params.MinSignedPerWindow))
+	require.Equal(t, expectedParams.DowntimeJailDuration, params.DowntimeJailDuration)
+
+	mockCli.EXPECT().Query(expectedPath, nil).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Slashing().QueryParams()
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, nil).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Slashing().QueryParams()
+	require.Error(t, err)
+}
+
+func TestSlashingClient_QuerySigningInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewSlashingClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	consAddress, err := sdk.ConsAddressFromBech32(consAddr)
+	require.NoError(t, err)
+	expectedInfo := slashing.NewValidatorSigningInfo(consAddress, 1024, 10, time.Unix(1600000000, 0).UTC(),
+		false, 5, 0)
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(expectedInfo)
+
+	// single
+	expectedPath := fmt.Sprintf("custom/%s/%s", slashing.QuerierRoute, slashing.QuerySigningInfo)
+	expectedParams := mockCli.GetCodec().MustMarshalJSON(slashing.NewQuerySigningInfoParams(consAddress))
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+	signingInfo, err := mockCli.Slashing().QuerySigningInfo(consAddr)
+	require.NoError(t, err)
+	require.Equal(t, expectedInfo, signingInfo)
+
+	_, err = mockCli.Slashing().QuerySigningInfo(consAddr[1:])
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(nil, int64(0),
+		errors.New("default error"))
+	_, err = mockCli.Slashing().QuerySigningInfo(consAddr)
+	require.Error(t, err)
+
+	// page
+	expectedPath = fmt.Sprintf("custom/%s/%s", slashing.QuerierRoute, slashing.QuerySigningInfos)
+	expectedParams = mockCli.GetCodec().MustMarshalJSON(slashing.NewQuerySigningInfosParams(1, 10))
+	expectedRet = mockCli.GetCodec().MustMarshalJSON([]types.ValidatorSigningInfo{expectedInfo})
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet, int64(1024), nil)
+	signingInfos, err := mockCli.Slashing().QuerySigningInfos(1, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.ValidatorSigningInfo{expectedInfo}, signingInfos)
+
+	mockCli.EXPECT().Query(expectedPath, tmbytes.HexBytes(expectedParams)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Slashing().QuerySigningInfos(1, 10)
+	require.Error(t, err)
+}
+
+func TestSlashingClient_QueryMissedBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewSlashingClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	consAddress, err := sdk.ConsAddressFromBech32(consAddr)
+	require.NoError(t, err)
+	prefix := slashing.GetValidatorMissedBlockBitArrayPrefixKey(consAddress)
+	newPair := func(index int64, missed bool) sdk.KVPair {
+		indexBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint64(indexBytes, uint64(index))
+		return sdk.KVPair{
+			Key:   append(append([]byte{}, prefix...), indexBytes...),
+			Value: mockCli.GetCodec().MustMarshalBinaryLengthPrefixed(missed),
+		}
+	}
+
+	// the keys are iterated in the byte order of little endian
+	expectedRet := mockCli.GetCodec().MustMarshalBinaryLengthPrefixed([]sdk.KVPair{
+		newPair(256, true), newPair(0, false), newPair(1, true),
+	})
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(prefix), slashing.StoreKey, "subspace").
+		Return(expectedRet, int64(1024), nil)
+	missedBlocks, err := mockCli.Slashing().QueryMissedBlocks(consAddr)
+	require.NoError(t, err)
+	require.Equal(t, []types.MissedBlock{
+		slashing.NewMissedBlock(0, false), slashing.NewMissedBlock(1, true), slashing.NewMissedBlock(256, true),
+	}, missedBlocks)
+
+	// empty
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(prefix), slashing.StoreKey, "subspace").
+		Return(nil, int64(1024), nil)
+	missedBlocks, err = mockCli.Slashing().QueryMissedBlocks(consAddr)
+	require.NoError(t, err)
+	require.Empty(t, missedBlocks)
+
+	// error
+	_, err = mockCli.Slashing().QueryMissedBlocks(consAddr[1:])
+	require.Error(t, err)
+
+	badRet := mockCli.GetCodec().MustMarshalBinaryLengthPrefixed([]sdk.KVPair{{Key: prefix, Value: []byte{0}}})
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(prefix), slashing.StoreKey, "subspace").
+		Return(badRet, int64(1024), nil)
+	_, err = mockCli.Slashing().QueryMissedBlocks(consAddr)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryStore(tmbytes.HexBytes(prefix), slashing.StoreKey, "subspace").
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Slashing().QueryMissedBlocks(consAddr)
+	require.Error(t, err)
+}
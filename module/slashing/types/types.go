@@ -8,3 +8,16 @@ import (
 const (
 	ModuleName = slashing.ModuleName
 )
+
+// statuses of the validator in the signing info, which are internal to the slashing module
+const (
+	ValStatusCreated    = 0x00
+	ValStatusDestroying = 0x01
+	ValStatusDestroyed  = 0x02
+)
+
+type (
+	Params               = slashing.Params
+	ValidatorSigningInfo = slashing.ValidatorSigningInfo
+	MissedBlock          = slashing.MissedBlock
+)
@@ -0,0 +1,290 @@
+package unjailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/slashing/types"
+	"github.com/okex/exchain-go-sdk/module/tendermint/tracker"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/x/slashing"
+)
+
+// const
+const (
+	// DefaultInterval is the default interval between two checks of the long-running routine
+	DefaultInterval = time.Minute
+	// DefaultMaxBlockAge is the default max age of the latest block, beyond which the node is treated as catching up
+	DefaultMaxBlockAge = time.Minute
+	// DefaultConfirmTimeout is the default duration to wait for the confirmation of a tx
+	DefaultConfirmTimeout = time.Minute
+	// DefaultPollInterval is the default interval to poll a tx
+	DefaultPollInterval = 2 * time.Second
+)
+
+// actions taken in a check
+const (
+	// ActionNone means that the validator isn't jailed
+	ActionNone = "none"
+	// ActionWait means that the jail period of the validator hasn't ended yet
+	ActionWait = "wait"
+	// ActionRefuse means that a guard refuses to unjail the validator
+	ActionRefuse = "refuse"
+	// ActionNotify means that the validator would be unjailed without the notification-only mode
+	ActionNotify = "notify"
+	// ActionUnjail means that an unjail tx is sent
+	ActionUnjail = "unjail"
+)
+
+// Client shows the expected behavior of the client that the routine works with
+type Client interface {
+	Auth() exposed.Auth
+	Staking() exposed.Staking
+	Slashing() exposed.Slashing
+	Tendermint() exposed.Tendermint
+}
+
+// Options - structure of the options of the routine
+type Options struct {
+	// NotifyOnly reports the validator to unjail without sending any tx
+	NotifyOnly bool
+	// MinSelfDelegation is the min self delegation of the validator required to unjail. Any positive one is accepted
+	// by nil or zero
+	MinSelfDelegation sdk.Dec
+	// MaxBlockAge refuses to unjail when the latest block of the node is older than it by the local clock
+	MaxBlockAge    time.Duration
+	Interval       time.Duration
+	ConfirmTimeout time.Duration
+	PollInterval   time.Duration
+}
+
+// Report - structure of the result of a check by the routine
+type Report struct {
+	Height            int64     `json:"height"`
+	BlockTime         time.Time `json:"block_time"`
+	CatchingUp        bool      `json:"catching_up"`
+	Jailed            bool      `json:"jailed"`
+	JailedUntil       time.Time `json:"jailed_until"`
+	Tombstoned        bool      `json:"tombstoned"`
+	MinSelfDelegation sdk.Dec   `json:"min_self_delegation"`
+	Action            string    `json:"action"`
+	Reason            string    `json:"reason,omitempty"`
+	// TxHash is the hash of the unjail tx, which is confirmed on chain once Confirmed is true
+	TxHash    string `json:"tx_hash,omitempty"`
+	Confirmed bool   `json:"confirmed"`
+}
+
+// Unjailer - structure of the opt-in operator routine, which unjails the own validator once its jail period ends and
+// all the guards pass
+type Unjailer struct {
+	cli      Client
+	fromInfo keys.Info
+	passWd   string
+	memo     string
+	opts     Options
+	// pending is the unjail tx sent but not confirmed yet
+	pending *tracker.Record
+}
+
+// NewUnjailer creates a new instance of Unjailer for the validator operated by the key
+func NewUnjailer(cli Client, fromInfo keys.Info, passWd, memo string, opts Options) *Unjailer {
+	if opts.MinSelfDelegation.IsNil() {
+		opts.MinSelfDelegation = sdk.ZeroDec()
+	}
+	if opts.MaxBlockAge <= 0 {
+		opts.MaxBlockAge = DefaultMaxBlockAge
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.ConfirmTimeout <= 0 {
+		opts.ConfirmTimeout = DefaultConfirmTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	return &Unjailer{
+		cli:      cli,
+		fromInfo: fromInfo,
+		passWd:   passWd,
+		memo:     memo,
+		opts:     opts,
+	}
+}
+
+// Run checks the validator every interval until the context is done. The report and the error of each check are
+// passed to the callback
+func (u *Unjailer) Run(ctx context.Context, onReport func(Report, error)) error {
+	for {
+		report, err := u.RunOnce()
+		if onReport != nil {
+			onReport(report, err)
+		}
+
+		timer := time.NewTimer(u.opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce checks the jailed status of the validator and unjails it when the jail period ends and all the guards pass
+// Note: an unjail tx unconfirmed is looked up in the next check, and sent again with the same sequence only if it's
+// never committed
+func (u *Unjailer) RunOnce() (report Report, err error) {
+	if u.fromInfo == nil {
+		return report, errors.New("failed. no key info of the validator operator")
+	}
+	if !u.opts.NotifyOnly {
+		if err = params.CheckKeyParams(u.fromInfo, u.passWd); err != nil {
+			return
+		}
+	}
+
+	status, err := u.cli.Tendermint().QueryStatus()
+	if err != nil {
+		return report, fmt.Errorf("failed. query status error: %s", err)
+	}
+	report.Height, report.BlockTime = status.SyncInfo.LatestBlockHeight, status.SyncInfo.LatestBlockTime
+	report.CatchingUp = status.SyncInfo.CatchingUp
+
+	valAddr := sdk.ValAddress(u.fromInfo.GetAddress())
+	validator, err := u.cli.Staking().QueryValidator(valAddr.String())
+	if err != nil {
+		return
+	}
+
+	report.Jailed, report.MinSelfDelegation = validator.Jailed, validator.MinSelfDelegation
+	if !validator.Jailed {
+		u.pending = nil
+		report.Action, report.Reason = ActionNone, fmt.Sprintf("validator %s isn't jailed", valAddr)
+		return
+	}
+
+	consAddr := sdk.ConsAddress(validator.ConsPubKey.Address())
+	info, err := u.cli.Slashing().QuerySigningInfo(consAddr.String())
+	if err != nil {
+		return
+	}
+	report.JailedUntil, report.Tombstoned = info.JailedUntil, info.Tombstoned
+
+	if reason := u.guard(report, info); len(reason) != 0 {
+		report.Action, report.Reason = ActionRefuse, reason
+		return
+	}
+
+	if report.BlockTime.Before(info.JailedUntil) {
+		report.Action = ActionWait
+		report.Reason = fmt.Sprintf("jailed until %s, %s left", info.JailedUntil.Format(time.RFC3339),
+			info.JailedUntil.Sub(report.BlockTime))
+		return
+	}
+
+	if u.opts.NotifyOnly {
+		report.Action = ActionNotify
+		report.Reason = fmt.Sprintf("jail period ended at %s and validator %s can be unjailed",
+			info.JailedUntil.Format(time.RFC3339), valAddr)
+		return
+	}
+
+	report.Action = ActionUnjail
+	err = u.unjail(&report)
+	return
+}
+
+// guard returns the reason to refuse unjailing the validator, or empty if all the guards pass
+func (u *Unjailer) guard(report Report, info types.ValidatorSigningInfo) string {
+	switch {
+	case info.Tombstoned:
+		return "validator is tombstoned and can never be unjailed"
+	case info.ValidatorStatus == types.ValStatusDestroying || info.ValidatorStatus == types.ValStatusDestroyed:
+		return "validator is being destroyed"
+	case !report.MinSelfDelegation.IsPositive() || report.MinSelfDelegation.LT(u.opts.MinSelfDelegation):
+		return fmt.Sprintf("self delegation %s is below the min %s", report.MinSelfDelegation,
+			u.opts.MinSelfDelegation)
+	case report.CatchingUp:
+		return "node is catching up"
+	case time.Since(report.BlockTime) > u.opts.MaxBlockAge:
+		return fmt.Sprintf("latest block at height %d is older than %s", report.Height, u.opts.MaxBlockAge)
+	}
+
+	return ""
+}
+
+// unjail sends the unjail tx, or resolves the one unconfirmed in the last check, and waits for its confirmation
+func (u *Unjailer) unjail(report *Report) error {
+	account, err := u.cli.Auth().QueryAccount(u.fromInfo.GetAddress().String())
+	if err != nil {
+		return err
+	}
+
+	txTracker := tracker.NewTracker(u.cli, u.fromInfo.GetAddress().String(), u.opts.ConfirmTimeout,
+		u.opts.PollInterval)
+	if u.pending != nil {
+		report.TxHash = u.pending.TxHash
+		pResultTx, err := txTracker.Resolve(u.pending, account.GetSequence(), isUnjailTx)
+		switch {
+		case err == tracker.ErrTxNotFound:
+			// the sequence consumed by another tx means that the unjail tx will never be committed
+			u.pending = nil
+		case err != nil:
+			return fmt.Errorf("failed. resolve unjail tx with sequence %d error: %s", u.pending.Sequence, err)
+		case pResultTx != nil:
+			return u.finish(report)
+		}
+	}
+
+	record, seqNum := new(tracker.Record), account.GetSequence()
+	if err = txTracker.Bind(record, seqNum); err != nil {
+		return err
+	}
+
+	u.pending = record
+	resp, err := u.cli.Slashing().Unjail(u.fromInfo, u.passWd, u.memo, account.GetAccountNumber(), seqNum)
+	err = record.ApplyResponse(resp, err)
+	report.TxHash = record.TxHash
+	switch {
+	case !record.InFlight():
+		return u.finish(report)
+	case err != nil:
+		return fmt.Errorf("failed. send unjail tx with sequence %d error: %s", seqNum, err)
+	case txTracker.WaitConfirm(record) == nil:
+		return fmt.Errorf("failed. unjail tx %s is unconfirmed and will be checked again", record.TxHash)
+	}
+
+	return u.finish(report)
+}
+
+// finish drops the pending tx committed and reports its result
+func (u *Unjailer) finish(report *Report) error {
+	record := u.pending
+	u.pending, report.TxHash = nil, record.TxHash
+	if record.Status == tracker.StatusFailed {
+		return fmt.Errorf("failed. unjail tx %s failed: %s", record.TxHash, record.Log)
+	}
+
+	report.Confirmed = true
+	return nil
+}
+
+// isUnjailTx shows whether the tx carries an unjail msg
+func isUnjailTx(pResultTx *tmtypes.ResultTx) (bool, error) {
+	for _, attrs := range utils.ParseEventAttributes(utils.GetEventsFromResultTx(pResultTx), sdk.EventTypeMessage) {
+		if attrs[sdk.AttributeKeyAction] == (slashing.MsgUnjail{}).Type() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
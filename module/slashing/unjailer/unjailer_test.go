@@ -0,0 +1,242 @@
+package unjailer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/slashing/types"
+	stakingtypes "github.com/okex/exchain-go-sdk/module/staking/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	"github.com/okx/okbchain/libs/tendermint/crypto/ed25519"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	"github.com/okx/okbchain/x/slashing"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	name     = "alice"
+	passWd   = "12345678"
+	mnemonic = "giggle sibling fun arrow elevator spoon blood grocery laugh tortoise culture tool"
+	memo     = "my memo"
+)
+
+// fakeChain simulates the validator of the operator and its unjail txs on top of the shared fake chain
+type fakeChain struct {
+	*mocks.FakeChain
+	info    types.ValidatorSigningInfo
+	unjails int
+}
+
+func newFakeChain(valAddr sdk.ValAddress) *fakeChain {
+	consPubKey := ed25519.GenPrivKey().PubKey()
+	fc := &fakeChain{
+		FakeChain: mocks.NewFakeChain(100, 8),
+		info: types.ValidatorSigningInfo{
+			Address:     sdk.ConsAddress(consPubKey.Address()),
+			JailedUntil: time.Now().Add(-time.Minute),
+		},
+	}
+	fc.BroadcastBlock = true
+	fc.Validators = []stakingtypes.Validator{{
+		OperatorAddress:   valAddr,
+		ConsPubKey:        consPubKey,
+		Jailed:            true,
+		MinSelfDelegation: sdk.NewDec(10000),
+	}}
+	return fc
+}
+
+// validator returns the validator of the operator
+func (fc *fakeChain) validator() *stakingtypes.Validator {
+	return &fc.Validators[0]
+}
+
+// commitUnjail commits an unjail tx with the sequence, which unjails the validator once it succeeds
+func (fc *fakeChain) commitUnjail(seqNum uint64) (sdk.TxResponse, error) {
+	seq := fc.Sequence
+	resp, err := fc.Commit(seqNum, abci.Event{Type: sdk.EventTypeMessage, Attributes: []kv.Pair{
+		{Key: []byte(sdk.AttributeKeyAction), Value: []byte(slashing.MsgUnjail{}.Type())},
+	}})
+	if fc.Sequence != seq && fc.LastTx().TxResult.IsOK() {
+		fc.validator().Jailed = false
+	}
+
+	return resp, err
+}
+
+func (fc *fakeChain) Slashing() exposed.Slashing { return fakeSlashing{chain: fc} }
+
+type fakeSlashing struct {
+	exposed.Slashing
+	chain *fakeChain
+}
+
+func (fs fakeSlashing) QuerySigningInfo(consAddrStr string) (types.ValidatorSigningInfo, error) {
+	if consAddrStr != fs.chain.info.Address.String() {
+		return types.ValidatorSigningInfo{}, errors.New("no signing info found")
+	}
+
+	return fs.chain.info, nil
+}
+
+func (fs fakeSlashing) Unjail(_ keys.Info, _, _ string, _, seqNum uint64) (sdk.TxResponse, error) {
+	fs.chain.unjails++
+	return fs.chain.commitUnjail(seqNum)
+}
+
+func newTestUnjailer(t *testing.T, opts Options) (*Unjailer, *fakeChain) {
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	opts.ConfirmTimeout, opts.PollInterval = 10*time.Millisecond, time.Millisecond
+	chain := newFakeChain(sdk.ValAddress(fromInfo.GetAddress()))
+	return NewUnjailer(chain, fromInfo, passWd, memo, opts), chain
+}
+
+func TestUnjailer_RunOnce(t *testing.T) {
+	unjailer, chain := newTestUnjailer(t, Options{})
+
+	// still in the jail period
+	jailedUntil := chain.info.JailedUntil
+	chain.info.JailedUntil = chain.BlockTime.Add(time.Hour)
+	report, err := unjailer.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, ActionWait, report.Action)
+	require.True(t, report.Jailed)
+	require.Equal(t, 0, chain.unjails)
+
+	// failed in the block broadcast mode
+	chain.info.JailedUntil, chain.Fail = jailedUntil, true
+	report, err = unjailer.RunOnce()
+	require.Error(t, err)
+	require.Equal(t, ActionUnjail, report.Action)
+	require.False(t, report.Confirmed)
+	require.Equal(t, uint64(9), chain.Sequence)
+
+	// unjailed with the next sequence
+	report, err = unjailer.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, ActionUnjail, report.Action)
+	require.True(t, report.Confirmed)
+	require.NotEmpty(t, report.TxHash)
+	require.Equal(t, 2, chain.unjails)
+
+	report, err = unjailer.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, ActionNone, report.Action)
+	require.Equal(t, 2, chain.unjails)
+}
+
+func TestUnjailer_RunOncePending(t *testing.T) {
+	unjailer, chain := newTestUnjailer(t, Options{})
+
+	// unconfirmed in time
+	chain.Pend = true
+	report, err := unjailer.RunOnce()
+	require.Error(t, err)
+	require.False(t, report.Confirmed)
+	txHash := report.TxHash
+	require.NotNil(t, unjailer.pending)
+
+	// confirmed in the next check without sending again, while the validator queried lags behind
+	_, err = chain.commitUnjail(chain.Sequence)
+	require.NoError(t, err)
+	chain.validator().Jailed = true
+	report, err = unjailer.RunOnce()
+	require.NoError(t, err)
+	require.True(t, report.Confirmed)
+	require.Equal(t, txHash, report.TxHash)
+	require.Equal(t, 1, chain.unjails)
+	require.Nil(t, unjailer.pending)
+
+	// dropped and sent again with the same sequence
+	chain.Pend = true
+	_, err = unjailer.RunOnce()
+	require.Error(t, err)
+	require.Equal(t, uint64(9), unjailer.pending.Sequence)
+
+	report, err = unjailer.RunOnce()
+	require.NoError(t, err)
+	require.True(t, report.Confirmed)
+	require.Equal(t, 3, chain.unjails)
+	require.Equal(t, uint64(10), chain.Sequence)
+}
+
+func TestUnjailer_RunOnceResolve(t *testing.T) {
+	unjailer, chain := newTestUnjailer(t, Options{})
+
+	// committed without the response, and looked up in the history while the validator queried lags behind
+	chain.Crash = true
+	_, err := unjailer.RunOnce()
+	require.Error(t, err)
+	require.Empty(t, unjailer.pending.TxHash)
+
+	chain.validator().Jailed = true
+	report, err := unjailer.RunOnce()
+	require.NoError(t, err)
+	require.True(t, report.Confirmed)
+	require.Equal(t, chain.LastTx().Hash.String(), report.TxHash)
+	require.Equal(t, 1, chain.unjails)
+	require.Nil(t, unjailer.pending)
+
+	// rejected, and the sequence is consumed by another tx
+	chain.validator().Jailed, chain.Reject = true, true
+	_, err = unjailer.RunOnce()
+	require.Error(t, err)
+	require.NotNil(t, unjailer.pending)
+	_, err = chain.Commit(chain.Sequence)
+	require.NoError(t, err)
+
+	report, err = unjailer.RunOnce()
+	require.NoError(t, err)
+	require.True(t, report.Confirmed)
+	require.Equal(t, 3, chain.unjails)
+	require.Equal(t, uint64(11), chain.Sequence)
+	require.Nil(t, unjailer.pending)
+}
+
+func TestUnjailer_Guards(t *testing.T) {
+	unjailer, chain := newTestUnjailer(t, Options{MinSelfDelegation: sdk.NewDec(100)})
+
+	testCases := []struct {
+		name   string
+		modify func(*fakeChain)
+	}{
+		{"tombstoned", func(fc *fakeChain) { fc.info.Tombstoned = true }},
+		{"destroying", func(fc *fakeChain) { fc.info.ValidatorStatus = types.ValStatusDestroying }},
+		{"no self delegation", func(fc *fakeChain) { fc.validator().MinSelfDelegation = sdk.ZeroDec() }},
+		{"low self delegation", func(fc *fakeChain) { fc.validator().MinSelfDelegation = sdk.NewDec(99) }},
+		{"catching up", func(fc *fakeChain) { fc.CatchingUp = true }},
+		{"stale block", func(fc *fakeChain) { fc.BlockTime = time.Now().Add(-time.Hour) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator, info, blockTime := *chain.validator(), chain.info, chain.BlockTime
+			tc.modify(chain)
+			report, err := unjailer.RunOnce()
+			require.NoError(t, err)
+			require.Equal(t, ActionRefuse, report.Action)
+			require.NotEmpty(t, report.Reason)
+			require.Equal(t, 0, chain.unjails)
+			*chain.validator(), chain.info, chain.BlockTime, chain.CatchingUp = validator, info, blockTime, false
+		})
+	}
+
+	// notification-only mode
+	unjailer.opts.NotifyOnly, unjailer.passWd = true, ""
+	report, err := unjailer.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, ActionNotify, report.Action)
+	require.Equal(t, 0, chain.unjails)
+
+	unjailer.opts.NotifyOnly = false
+	_, err = unjailer.RunOnce()
+	require.Error(t, err)
+}
@@ -0,0 +1,544 @@
+package compounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/tendermint/tracker"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/x/common"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+	stakingtypes "github.com/okx/okbchain/x/staking/types"
+)
+
+// const
+const (
+	// DefaultInterval is the default interval between two checks of the long-running agent
+	DefaultInterval = 10 * time.Minute
+	// DefaultConfirmTimeout is the default duration to wait for the confirmation of a tx
+	DefaultConfirmTimeout = time.Minute
+	// DefaultPollInterval is the default interval to poll a tx
+	DefaultPollInterval = 2 * time.Second
+)
+
+// Client shows the expected behavior of the client that the agent works with
+type Client interface {
+	GetConfig() gosdktypes.ClientConfig
+	Auth() exposed.Auth
+	Staking() exposed.Staking
+	Distribution() exposed.Distribution
+	Tendermint() exposed.Tendermint
+}
+
+// Options - structure of the options of the agent
+type Options struct {
+	// MinProfit is the threshold of the rewards net of the estimated fees of a round to claim. Any positive profit by
+	// nil or zero
+	MinProfit sdk.Dec
+	// FeePerTx estimates the fee of each tx in a round. Defaults to the fees of the client config, or the gas prices
+	// multiplied by the gas of the client config
+	FeePerTx       sdk.Dec
+	Interval       time.Duration
+	ConfirmTimeout time.Duration
+	PollInterval   time.Duration
+}
+
+// Report - structure of the result of a check by the agent
+type Report struct {
+	Height int64 `json:"height"`
+	DryRun bool  `json:"dry_run"`
+	// Resumed shows that a round interrupted by the last run is resumed instead of a new check
+	Resumed        bool    `json:"resumed"`
+	PendingRewards sdk.Dec `json:"pending_rewards"`
+	EstimatedFees  sdk.Dec `json:"estimated_fees"`
+	NetRewards     sdk.Dec `json:"net_rewards"`
+	Threshold      sdk.Dec `json:"threshold"`
+	NeedAddShares  bool    `json:"need_add_shares"`
+	// Claim shows whether a round is started, or would be started in the dry-run mode
+	Claim  bool   `json:"claim"`
+	Reason string `json:"reason,omitempty"`
+	// Round is the round finished or interrupted in the run
+	Round *Round `json:"round,omitempty"`
+}
+
+// Agent - structure of the auto-compounding agent, which withdraws the rewards of the delegator, deposits them back
+// and adds shares to the validators configured in rounds
+// Note: the delegator account is supposed to be dedicated to the agent, because the agent detects the tx of a round by
+// the account sequence after a crash
+type Agent struct {
+	cli         Client
+	fromInfo    keys.Info
+	passWd      string
+	memo        string
+	statePath   string
+	valAddrsStr []string
+	opts        Options
+}
+
+// NewAgent creates a new instance of Agent
+func NewAgent(cli Client, fromInfo keys.Info, passWd, memo, statePath string, valAddrsStr []string,
+	opts Options) *Agent {
+	if opts.MinProfit.IsNil() {
+		opts.MinProfit = sdk.ZeroDec()
+	}
+	if opts.FeePerTx.IsNil() {
+		opts.FeePerTx = estimateFeePerTx(cli.GetConfig())
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.ConfirmTimeout <= 0 {
+		opts.ConfirmTimeout = DefaultConfirmTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	return &Agent{
+		cli:         cli,
+		fromInfo:    fromInfo,
+		passWd:      passWd,
+		memo:        memo,
+		statePath:   statePath,
+		valAddrsStr: valAddrsStr,
+		opts:        opts,
+	}
+}
+
+// Run checks the rewards and compounds them every interval until the context is done. The report and the error of
+// each check are passed to the callback
+func (a *Agent) Run(ctx context.Context, onReport func(Report, error)) error {
+	for {
+		report, err := a.RunOnce(false)
+		if onReport != nil {
+			onReport(report, err)
+		}
+
+		timer := time.NewTimer(a.opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce resumes the round interrupted by the last run, or checks the rewards and runs a new round once the net
+// rewards reach the threshold. Nothing is sent or saved in the dry-run mode
+// Note: once the rewards are withdrawn, the amount claimed is persisted and only that amount is deposited in the
+// following runs, so a crash between the withdrawal and the deposit never withdraws twice or deposits other funds
+func (a *Agent) RunOnce(dryRun bool) (report Report, err error) {
+	report.DryRun = dryRun
+	if err = params.CheckKeyParams(a.fromInfo, a.passWd); err != nil {
+		return
+	}
+
+	if err = checkValAddrs(a.valAddrsStr); err != nil {
+		return
+	}
+
+	delAddr := a.fromInfo.GetAddress()
+	state, exists, err := LoadState(a.statePath)
+	if err != nil {
+		return
+	}
+
+	if exists && state.DelegatorAddress != delAddr.String() {
+		return report, fmt.Errorf("failed. state %s belongs to another delegator %s", a.statePath,
+			state.DelegatorAddress)
+	}
+
+	if !exists {
+		state = State{
+			DelegatorAddress: delAddr.String(),
+			Phase:            PhaseIdle,
+			TotalCompounded:  sdk.ZeroDec(),
+		}
+	}
+
+	if state.Phase != PhaseIdle {
+		report.Resumed, report.Round = true, state.Round
+		if dryRun {
+			report.Reason = fmt.Sprintf("round started at height %d is interrupted in phase %s and will be resumed",
+				state.Round.StartHeight, state.Phase)
+			return
+		}
+
+		err = a.runRound(&state)
+		return
+	}
+
+	if err = a.check(&report); err != nil || !report.Claim || dryRun {
+		return
+	}
+
+	account, err := a.cli.Auth().QueryAccount(delAddr.String())
+	if err != nil {
+		return
+	}
+
+	state.AccountNumber, state.Phase = account.GetAccountNumber(), PhaseWithdrawing
+	state.Round = &Round{
+		StartHeight:    report.Height,
+		PendingRewards: report.PendingRewards,
+		Fees:           report.EstimatedFees,
+		NeedAddShares:  report.NeedAddShares,
+	}
+	if err = SaveState(a.statePath, state); err != nil {
+		return
+	}
+
+	report.Round = state.Round
+	err = a.runRound(&state)
+	return
+}
+
+// check decides whether to claim the rewards of the delegator with the current state on chain
+func (a *Agent) check(report *Report) error {
+	delAddrStr := a.fromInfo.GetAddress().String()
+	status, err := a.cli.Tendermint().QueryStatus()
+	if err != nil {
+		return fmt.Errorf("failed. query status error: %s", err)
+	}
+	report.Height = status.SyncInfo.LatestBlockHeight
+
+	withdrawAddr, err := a.cli.Distribution().QueryWithdrawAddress(delAddrStr)
+	if err != nil {
+		return err
+	}
+
+	if withdrawAddr.String() != delAddrStr {
+		return fmt.Errorf("failed. rewards of %s are withdrawn to %s and can't be compounded", delAddrStr,
+			withdrawAddr)
+	}
+
+	delResp, err := a.cli.Staking().QueryDelegator(delAddrStr)
+	if err != nil {
+		return err
+	}
+
+	report.NeedAddShares = !isSameSet(delResp.ValidatorAddresses, a.valAddrsStr)
+	if report.NeedAddShares && !delResp.ProxyAddress.Empty() {
+		return fmt.Errorf("failed. delegator %s is bound to proxy %s and can't add shares to the validators",
+			delAddrStr, delResp.ProxyAddress)
+	}
+
+	totalRewards, err := a.cli.Distribution().QueryDelegatorTotalRewards(delAddrStr)
+	if err != nil {
+		return err
+	}
+
+	stakingParams, err := a.cli.Staking().QueryParams(0)
+	if err != nil {
+		return err
+	}
+
+	txNum := int64(2)
+	if report.NeedAddShares {
+		txNum++
+	}
+	report.PendingRewards = totalRewards.Total.AmountOf(common.NativeToken)
+	report.EstimatedFees = a.opts.FeePerTx.MulInt64(txNum)
+	report.NetRewards = report.PendingRewards.Sub(report.EstimatedFees)
+	report.Threshold = sdk.MaxDec(a.opts.MinProfit, stakingParams.MinDelegation)
+
+	distrParams, err := a.cli.Distribution().QueryParams()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !distrParams.WithdrawRewardEnabled:
+		report.Reason = "withdrawing rewards is disabled on chain"
+	case !report.NetRewards.IsPositive() || report.NetRewards.LT(report.Threshold):
+		report.Reason = fmt.Sprintf("net rewards %s haven't reached the threshold %s", report.NetRewards,
+			report.Threshold)
+	default:
+		report.Claim = true
+	}
+
+	return nil
+}
+
+// runRound runs the round of the state from its current phase to the end
+func (a *Agent) runRound(state *State) error {
+	round := state.Round
+	for {
+		switch state.Phase {
+		case PhaseWithdrawing:
+			if round.Withdraw == nil {
+				round.Withdraw = &TxRecord{}
+			}
+			// the phase is moved on by the claim, which is retried from the confirmed withdrawal if it fails
+			events, err := a.exec(state, round.Withdraw, distrtypes.EventTypeWithdrawRewards,
+				func(accNum, seqNum uint64) (sdk.TxResponse, error) {
+					return a.cli.Distribution().WithdrawDelegatorAllRewards(a.fromInfo, a.passWd, a.memo, accNum, seqNum)
+				}, nil)
+			if err != nil {
+				// nothing is withdrawn by the failed tx, so the round is dropped
+				if round.Withdraw.Status == StatusFailed {
+					state.Phase, state.Round = PhaseIdle, nil
+					if saveErr := SaveState(a.statePath, *state); saveErr != nil {
+						return saveErr
+					}
+				}
+				return err
+			}
+
+			if err = a.claim(state, events); err != nil {
+				return err
+			}
+		case PhaseDepositing:
+			if round.Deposit == nil {
+				round.Deposit = &TxRecord{}
+			}
+			depositStr := sdk.NewDecCoinFromDec(common.NativeToken, round.DepositAmount).String()
+			_, err := a.exec(state, round.Deposit, stakingtypes.EventTypeDelegate,
+				func(accNum, seqNum uint64) (sdk.TxResponse, error) {
+					return a.cli.Staking().Deposit(a.fromInfo, a.passWd, depositStr, a.memo, accNum, seqNum)
+				}, func() {
+					if round.NeedAddShares {
+						state.Phase = PhaseAddingShares
+					} else {
+						finish(state)
+					}
+				})
+			if err != nil {
+				// the rewards claimed are still in the account, so the deposit is sent again in the next run
+				return a.retryLater(state, &round.Deposit, err)
+			}
+
+			if state.Phase == PhaseIdle {
+				return nil
+			}
+		case PhaseAddingShares:
+			if round.AddShares == nil {
+				round.AddShares = &TxRecord{}
+			}
+			_, err := a.exec(state, round.AddShares, stakingtypes.EventTypeAddShares,
+				func(accNum, seqNum uint64) (sdk.TxResponse, error) {
+					return a.cli.Staking().AddShares(a.fromInfo, a.passWd, a.valAddrsStr, a.memo, accNum, seqNum)
+				}, func() {
+					finish(state)
+				})
+			if err != nil {
+				return a.retryLater(state, &round.AddShares, err)
+			}
+
+			return nil
+		default:
+			return fmt.Errorf("failed. unknown phase %s in state %s", state.Phase, a.statePath)
+		}
+	}
+}
+
+// claim records the rewards withdrawn in the events, and moves the round to the deposit
+func (a *Agent) claim(state *State, events sdk.StringEvents) error {
+	withdrawals, err := a.cli.Distribution().ParseWithdrawRewardsEvents(events)
+	if err != nil {
+		return err
+	}
+
+	round := state.Round
+	round.Claimed = sdk.ZeroDec()
+	for _, withdrawal := range withdrawals {
+		round.Claimed = round.Claimed.Add(withdrawal.Amount.AmountOf(common.NativeToken))
+	}
+
+	// the fees of the round are paid out of the rewards, so that the principal is never touched
+	round.DepositAmount = round.Claimed.Sub(round.Fees)
+	stakingParams, err := a.cli.Staking().QueryParams(0)
+	if err != nil {
+		return err
+	}
+
+	if round.DepositAmount.LT(stakingParams.MinDelegation) {
+		state.Phase, state.Round = PhaseIdle, nil
+		if err = SaveState(a.statePath, *state); err != nil {
+			return err
+		}
+		return fmt.Errorf("failed. rewards %s claimed are too few to deposit and are left in the account", round.Claimed)
+	}
+
+	state.Phase = PhaseDepositing
+	return SaveState(a.statePath, *state)
+}
+
+// finish closes the round of the state
+func finish(state *State) {
+	state.Rounds++
+	state.TotalCompounded = state.TotalCompounded.Add(state.Round.DepositAmount)
+	state.LastRoundHeight = state.Round.StartHeight
+	state.Phase, state.Round = PhaseIdle, nil
+}
+
+// retryLater resets the failed tx of the round to be sent with a new sequence in the next run
+func (a *Agent) retryLater(state *State, record **TxRecord, err error) error {
+	if (*record).Status == StatusFailed {
+		*record = nil
+		if saveErr := SaveState(a.statePath, *state); saveErr != nil {
+			return saveErr
+		}
+	}
+
+	return err
+}
+
+// exec sends the tx of the record, or resolves it if it's in flight, and returns the events of the tx once it's
+// confirmed. The tx is sent again with the same sequence if it isn't committed, and is never sent again once it's
+// confirmed
+// Note: onConfirmed moves the state on, and is applied in the same save as the confirmation of the tx
+func (a *Agent) exec(state *State, record *TxRecord, eventType string,
+	send func(accNum, seqNum uint64) (sdk.TxResponse, error), onConfirmed func()) (events sdk.StringEvents,
+	err error) {
+	if record.Status == StatusConfirmed {
+		pResultTx, err := a.cli.Tendermint().QueryTxResult(record.TxHash, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed. query confirmed tx %s in phase %s error: %s", record.TxHash, state.Phase,
+				err)
+		}
+		return a.finish(state, record, pResultTx, onConfirmed)
+	}
+
+	account, err := a.cli.Auth().QueryAccount(state.DelegatorAddress)
+	if err != nil {
+		return
+	}
+
+	txTracker := a.newTracker()
+	if record.InFlight() {
+		pResultTx, err := txTracker.Resolve(record, account.GetSequence(), func(pResultTx *tmtypes.ResultTx) (bool,
+			error) {
+			return len(utils.ParseEventAttributes(utils.GetEventsFromResultTx(pResultTx), eventType)) != 0, nil
+		})
+		if err == tracker.ErrTxNotFound {
+			return nil, fmt.Errorf("failed. sequence %d has been consumed but no tx with event %s is found after "+
+				"height %d. Check the txs of %s before fixing the state %s by hand", record.Sequence, eventType,
+				record.BroadcastHeight, state.DelegatorAddress, a.statePath)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pResultTx != nil {
+			return a.finish(state, record, pResultTx, onConfirmed)
+		}
+	}
+
+	if err = txTracker.Bind(record, account.GetSequence()); err != nil {
+		return
+	}
+	if err = SaveState(a.statePath, *state); err != nil {
+		return
+	}
+
+	resp, sendErr := send(state.AccountNumber, record.Sequence)
+	sendErr = record.ApplyResponse(resp, sendErr)
+	if record.Status == StatusConfirmed {
+		events = utils.GetEventsFromTxResponse(resp)
+		if onConfirmed != nil {
+			onConfirmed()
+		}
+	}
+
+	if err = SaveState(a.statePath, *state); err != nil {
+		return
+	}
+
+	switch {
+	case sendErr != nil:
+		return nil, fmt.Errorf("failed. send tx in phase %s with sequence %d error: %s", state.Phase,
+			record.Sequence, sendErr)
+	case record.Status == StatusBroadcast:
+		pResultTx := txTracker.WaitConfirm(record)
+		if pResultTx == nil {
+			return nil, fmt.Errorf("failed. tx %s in phase %s is unconfirmed, run again to resume", record.TxHash,
+				state.Phase)
+		}
+		return a.finish(state, record, pResultTx, onConfirmed)
+	case record.Status == StatusFailed:
+		return nil, fmt.Errorf("failed. tx %s in phase %s failed: %s", record.TxHash, state.Phase, record.Log)
+	}
+
+	return
+}
+
+// newTracker creates the tracker of the txs sent by the agent
+func (a *Agent) newTracker() *tracker.Tracker {
+	return tracker.NewTracker(a.cli, a.fromInfo.GetAddress().String(), a.opts.ConfirmTimeout, a.opts.PollInterval)
+}
+
+// finish moves the state on by the result of the tx committed, which is applied to the record already, and saves it
+func (a *Agent) finish(state *State, record *TxRecord, pResultTx *tmtypes.ResultTx, onConfirmed func()) (
+	sdk.StringEvents, error) {
+	if record.Status == StatusConfirmed && onConfirmed != nil {
+		onConfirmed()
+	}
+
+	if err := SaveState(a.statePath, *state); err != nil {
+		return nil, err
+	}
+
+	if record.Status == StatusFailed {
+		return nil, fmt.Errorf("failed. tx %s in phase %s failed: %s", record.TxHash, state.Phase, record.Log)
+	}
+
+	return utils.GetEventsFromResultTx(pResultTx), nil
+}
+
+// estimateFeePerTx estimates the fee of a tx in the native token with the client config
+func estimateFeePerTx(config gosdktypes.ClientConfig) sdk.Dec {
+	if fee := config.Fees.AmountOf(common.NativeToken); fee.IsPositive() {
+		return fee
+	}
+
+	return config.GasPrices.AmountOf(common.NativeToken).MulInt64(int64(config.Gas))
+}
+
+func checkValAddrs(valAddrsStr []string) error {
+	if len(valAddrsStr) == 0 {
+		return errors.New("failed. no validator to add shares to")
+	}
+
+	valAddrs, err := utils.ParseValAddresses(valAddrsStr)
+	if err != nil {
+		return fmt.Errorf("failed. validator address parsed error: %s", err.Error())
+	}
+
+	set := make(map[string]bool, len(valAddrs))
+	for _, valAddr := range valAddrs {
+		if set[valAddr.String()] {
+			return fmt.Errorf("failed. duplicated validator %s", valAddr)
+		}
+		set[valAddr.String()] = true
+	}
+
+	return nil
+}
+
+func isSameSet(valAddrs []sdk.ValAddress, valAddrsStr []string) bool {
+	if len(valAddrs) != len(valAddrsStr) {
+		return false
+	}
+
+	set := make(map[string]bool, len(valAddrs))
+	for _, valAddr := range valAddrs {
+		set[valAddr.String()] = true
+	}
+
+	for _, valAddrStr := range valAddrsStr {
+		if !set[valAddrStr] {
+			return false
+		}
+	}
+
+	return true
+}
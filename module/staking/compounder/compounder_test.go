@@ -0,0 +1,378 @@
+package compounder
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/mocks"
+	authtypes "github.com/okex/exchain-go-sdk/module/auth/types"
+	"github.com/okex/exchain-go-sdk/module/distribution"
+	distrtypes "github.com/okex/exchain-go-sdk/module/distribution/types"
+	stakingtypes "github.com/okex/exchain-go-sdk/module/staking/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	okbdistrtypes "github.com/okx/okbchain/x/distribution/types"
+	okbstakingtypes "github.com/okx/okbchain/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	name     = "alice"
+	passWd   = "12345678"
+	mnemonic = "giggle sibling fun arrow elevator spoon blood grocery laugh tortoise culture tool"
+	memo     = "my memo"
+	valAddr1 = "exvaloper1qwuag8gx408m9ej038vzx50ntt0x4yrq8qwdtq"
+)
+
+var valAddr2 = sdk.ValAddress(sdk.MustAccAddressFromBech32("ex1qj5c07sm6jetjz8f509qtrxgh4psxkv3ddyq7u")).String()
+
+// fakeChain simulates the rewards and the withdraw address of the delegator on top of the shared fake chain
+type fakeChain struct {
+	*mocks.FakeChain
+	withdrawAddr sdk.AccAddress
+	rewards      sdk.Dec
+	deposits     []string
+	// failQuery makes the query fail once after the tx with the sequence failQuerySeq is committed
+	failQuery    string
+	failQuerySeq uint64
+}
+
+func newFakeChain(delAddr sdk.AccAddress) *fakeChain {
+	fc := &fakeChain{
+		FakeChain:    mocks.NewFakeChain(100, 8),
+		withdrawAddr: delAddr,
+		rewards:      sdk.NewDec(10),
+	}
+	fc.Delegator.DelegatorAddress = delAddr
+	fc.StakingParams = okbstakingtypes.DefaultParams()
+	return fc
+}
+
+func (fc *fakeChain) queryErr(query string) error {
+	if fc.failQuery == query && fc.Sequence > fc.failQuerySeq {
+		fc.failQuery = ""
+		return errors.New("connection reset")
+	}
+
+	return nil
+}
+
+func (fc *fakeChain) GetConfig() gosdktypes.ClientConfig {
+	return gosdktypes.ClientConfig{
+		Gas:  200000,
+		Fees: sdk.NewDecCoinsFromDec(sdk.DefaultBondDenom, sdk.NewDecWithPrec(5, 1)),
+	}
+}
+
+func (fc *fakeChain) Auth() exposed.Auth { return fakeAuth{Auth: fc.FakeChain.Auth(), chain: fc} }
+func (fc *fakeChain) Staking() exposed.Staking {
+	return fakeStaking{Staking: fc.FakeChain.Staking(), chain: fc}
+}
+func (fc *fakeChain) Distribution() exposed.Distribution {
+	return fakeDistribution{Distribution: distribution.NewDistrClient(nil), chain: fc}
+}
+func (fc *fakeChain) Tendermint() exposed.Tendermint {
+	return fakeTendermint{Tendermint: fc.FakeChain.Tendermint(), chain: fc}
+}
+
+type fakeAuth struct {
+	exposed.Auth
+	chain *fakeChain
+}
+
+func (fa fakeAuth) QueryAccount(accAddrStr string) (authtypes.Account, error) {
+	if err := fa.chain.queryErr("QueryAccount"); err != nil {
+		return nil, err
+	}
+
+	return fa.Auth.QueryAccount(accAddrStr)
+}
+
+type fakeStaking struct {
+	exposed.Staking
+	chain *fakeChain
+}
+
+func (fs fakeStaking) QueryParams(height int64) (stakingtypes.Params, error) {
+	if err := fs.chain.queryErr("QueryParams"); err != nil {
+		return stakingtypes.Params{}, err
+	}
+
+	return fs.Staking.QueryParams(height)
+}
+
+func (fs fakeStaking) Deposit(fromInfo keys.Info, passWd, coinsStr, memo string, accNum, seqNum uint64) (
+	sdk.TxResponse, error) {
+	resp, err := fs.Staking.Deposit(fromInfo, passWd, coinsStr, memo, accNum, seqNum)
+	if tx := fs.chain.LastTx(); tx != nil && tx.Hash.String() == resp.TxHash && tx.TxResult.IsOK() {
+		fs.chain.deposits = append(fs.chain.deposits, coinsStr)
+	}
+	return resp, err
+}
+
+type fakeDistribution struct {
+	exposed.Distribution
+	chain *fakeChain
+}
+
+func (fd fakeDistribution) QueryWithdrawAddress(string) (sdk.AccAddress, error) {
+	return fd.chain.withdrawAddr, nil
+}
+
+func (fd fakeDistribution) QueryDelegatorTotalRewards(string) (distrtypes.DelegatorTotalRewards, error) {
+	return distrtypes.DelegatorTotalRewards{
+		Total: sdk.NewDecCoinsFromDec(sdk.DefaultBondDenom, fd.chain.rewards),
+	}, nil
+}
+
+func (fd fakeDistribution) QueryParams() (distrtypes.Params, error) {
+	return okbdistrtypes.DefaultParams(), nil
+}
+
+func (fd fakeDistribution) WithdrawDelegatorAllRewards(_ keys.Info, _, _ string, _, seqNum uint64) (sdk.TxResponse,
+	error) {
+	event := abci.Event{
+		Type: okbdistrtypes.EventTypeWithdrawRewards,
+		Attributes: []kv.Pair{
+			{Key: []byte(sdk.AttributeKeyAmount), Value: []byte(fd.chain.rewards.String() + sdk.DefaultBondDenom)},
+			{Key: []byte(okbdistrtypes.AttributeKeyValidator), Value: []byte(valAddr1)},
+		},
+	}
+	if !fd.chain.Fail {
+		fd.chain.rewards = sdk.ZeroDec()
+	}
+	return fd.chain.Commit(seqNum, event)
+}
+
+type fakeTendermint struct {
+	exposed.Tendermint
+	chain *fakeChain
+}
+
+func (ft fakeTendermint) QueryStatus() (*ctypes.ResultStatus, error) {
+	if err := ft.chain.queryErr("QueryStatus"); err != nil {
+		return nil, err
+	}
+
+	return ft.Tendermint.QueryStatus()
+}
+
+func newTestAgent(t *testing.T, chain Client, statePath string) *Agent {
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	return NewAgent(chain, fromInfo, passWd, memo, statePath, []string{valAddr1, valAddr2}, Options{
+		MinProfit:      sdk.NewDec(5),
+		ConfirmTimeout: time.Millisecond,
+		PollInterval:   time.Millisecond,
+	})
+}
+
+func TestAgent_RunOnce(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	chain := newFakeChain(fromInfo.GetAddress())
+	agent := newTestAgent(t, chain, statePath)
+
+	// dry run sends and saves nothing
+	report, err := agent.RunOnce(true)
+	require.NoError(t, err)
+	require.True(t, report.Claim)
+	require.True(t, report.NeedAddShares)
+	require.Equal(t, sdk.NewDecWithPrec(15, 1), report.EstimatedFees)
+	require.Equal(t, sdk.NewDecWithPrec(85, 1), report.NetRewards)
+	require.Empty(t, chain.Txs)
+	_, exists, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	report, err = agent.RunOnce(false)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(85, 1), report.Round.DepositAmount)
+	require.Equal(t, uint64(8), report.Round.Withdraw.Sequence)
+	require.Equal(t, uint64(9), report.Round.Deposit.Sequence)
+	require.Equal(t, uint64(10), report.Round.AddShares.Sequence)
+	require.Equal(t, []string{"8.500000000000000000okb"}, chain.deposits)
+	require.Equal(t, 2, len(chain.Delegator.ValidatorAddresses))
+
+	state, exists, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, PhaseIdle, state.Phase)
+	require.Nil(t, state.Round)
+	require.Equal(t, 1, state.Rounds)
+	require.Equal(t, sdk.NewDecWithPrec(85, 1), state.TotalCompounded)
+
+	// no shares adding any more and the net rewards haven't reached the threshold
+	chain.rewards = sdk.NewDecWithPrec(55, 1)
+	report, err = agent.RunOnce(false)
+	require.NoError(t, err)
+	require.False(t, report.NeedAddShares)
+	require.False(t, report.Claim)
+	require.NotEmpty(t, report.Reason)
+
+	chain.rewards = sdk.NewDec(7)
+	report, err = agent.RunOnce(false)
+	require.NoError(t, err)
+	require.True(t, report.Claim)
+	require.Nil(t, report.Round.AddShares)
+	require.Equal(t, []string{"8.500000000000000000okb", "6.000000000000000000okb"}, chain.deposits)
+	require.Equal(t, uint64(13), chain.Sequence)
+
+	// the failed withdrawal drops the round
+	chain.rewards, chain.Fail = sdk.NewDec(10), true
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+	state, _, err = LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseIdle, state.Phase)
+	require.Equal(t, 2, state.Rounds)
+
+	// the rewards are withdrawn to another address
+	chain.withdrawAddr = sdk.AccAddress(valAddr1)
+	_, err = agent.RunOnce(true)
+	require.Error(t, err)
+	chain.withdrawAddr = chain.Delegator.DelegatorAddress
+
+	// no shares adding during binding to a proxy
+	chain.Delegator.ValidatorAddresses, chain.Delegator.ProxyAddress = nil, sdk.AccAddress(valAddr1)
+	_, err = agent.RunOnce(true)
+	require.Error(t, err)
+	chain.Delegator.ProxyAddress = nil
+
+	agent.valAddrsStr = []string{valAddr1, valAddr1}
+	_, err = agent.RunOnce(true)
+	require.Error(t, err)
+}
+
+func TestAgent_RunOnceResume(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	chain := newFakeChain(fromInfo.GetAddress())
+	agent := newTestAgent(t, chain, statePath)
+
+	// crash after the withdrawal is committed
+	chain.Crash = true
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+	state, _, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseWithdrawing, state.Phase)
+	require.Equal(t, StatusSending, state.Round.Withdraw.Status)
+
+	// dry run reports the interrupted round only
+	report, err := agent.RunOnce(true)
+	require.NoError(t, err)
+	require.True(t, report.Resumed)
+	require.Equal(t, 1, len(chain.Txs))
+
+	// the deposit fails and is sent again with a new sequence in the next run
+	chain.Fail = true
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+	state, _, err = LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseDepositing, state.Phase)
+	require.Equal(t, sdk.NewDec(10), state.Round.Claimed)
+	require.Nil(t, state.Round.Deposit)
+
+	// more rewards come but only the claimed ones are deposited, and nothing is withdrawn twice
+	chain.rewards = sdk.NewDec(100)
+	report, err = agent.RunOnce(false)
+	require.NoError(t, err)
+	require.True(t, report.Resumed)
+	require.Equal(t, uint64(10), report.Round.Deposit.Sequence)
+	require.Equal(t, []string{"8.500000000000000000okb"}, chain.deposits)
+	require.Equal(t, sdk.NewDec(100), chain.rewards)
+
+	state, _, err = LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseIdle, state.Phase)
+	require.Equal(t, 1, state.Rounds)
+}
+
+func TestAgent_RunOnceUnresolved(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	chain := newFakeChain(fromInfo.GetAddress())
+	agent := newTestAgent(t, chain, statePath)
+
+	chain.Crash = true
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+
+	// the sequence is consumed by another tx, so the withdrawal can't be resolved automatically
+	chain.Txs[0].TxResult.Events = nil
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+	require.Equal(t, 1, len(chain.Txs))
+
+	state, _, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseWithdrawing, state.Phase)
+	require.Equal(t, StatusSending, state.Round.Withdraw.Status)
+}
+
+func TestAgent_RunOnceResumeConfirmed(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	chain := newFakeChain(fromInfo.GetAddress())
+	agent := newTestAgent(t, chain, statePath)
+
+	// the claim fails after the withdrawal is confirmed
+	chain.failQuery, chain.failQuerySeq = "QueryParams", 8
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+	state, _, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseWithdrawing, state.Phase)
+	require.Equal(t, StatusConfirmed, state.Round.Withdraw.Status)
+
+	// the confirmed withdrawal is claimed instead of withdrawing again, and the next step fails after the deposit
+	chain.rewards = sdk.NewDec(100)
+	chain.failQuery, chain.failQuerySeq = "QueryAccount", 9
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+	require.Equal(t, 2, len(chain.Txs))
+	require.Equal(t, sdk.NewDec(100), chain.rewards)
+	state, _, err = LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseAddingShares, state.Phase)
+	require.Equal(t, sdk.NewDec(10), state.Round.Claimed)
+	require.Equal(t, StatusConfirmed, state.Round.Deposit.Status)
+
+	// a confirmed deposit left in phase depositing is never sent again
+	state.Phase = PhaseDepositing
+	require.NoError(t, SaveState(statePath, state))
+	chain.failQuery, chain.failQuerySeq = "QueryStatus", 9
+	_, err = agent.RunOnce(false)
+	require.Error(t, err)
+	require.Equal(t, 2, len(chain.Txs))
+	state, _, err = LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseAddingShares, state.Phase)
+
+	report, err := agent.RunOnce(false)
+	require.NoError(t, err)
+	require.True(t, report.Resumed)
+	require.Equal(t, 3, len(chain.Txs))
+	require.Equal(t, []string{"8.500000000000000000okb"}, chain.deposits)
+
+	state, _, err = LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, PhaseIdle, state.Phase)
+	require.Equal(t, 1, state.Rounds)
+	require.Equal(t, sdk.NewDecWithPrec(85, 1), state.TotalCompounded)
+}
@@ -0,0 +1,115 @@
+package compounder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/okex/exchain-go-sdk/module/tendermint/tracker"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// phases of a compounding round
+const (
+	// PhaseIdle means that there's no round in progress
+	PhaseIdle = "idle"
+	// PhaseWithdrawing means that the rewards are being withdrawn
+	PhaseWithdrawing = "withdrawing"
+	// PhaseDepositing means that the rewards have been withdrawn to the delegator and are being deposited
+	PhaseDepositing = "depositing"
+	// PhaseAddingShares means that the rewards have been deposited and the shares are being added to the validators
+	PhaseAddingShares = "adding_shares"
+)
+
+// status of a tx of a round
+const (
+	// StatusSending means that the tx is bound to a sequence and being broadcast, its tx hash is unknown yet
+	StatusSending = tracker.StatusSending
+	// StatusBroadcast means that the tx is accepted by the node but not confirmed yet
+	StatusBroadcast = tracker.StatusBroadcast
+	// StatusConfirmed means that the tx is committed in a block successfully
+	StatusConfirmed = tracker.StatusConfirmed
+	// StatusFailed means that the tx is committed in a block with a non-zero code
+	StatusFailed = tracker.StatusFailed
+)
+
+// TxRecord - structure of a tx sent in a round
+type TxRecord = tracker.Record
+
+// Round - structure of a compounding round
+type Round struct {
+	StartHeight int64 `json:"start_height"`
+	// PendingRewards is the amount of the rewards to claim when the round starts
+	PendingRewards sdk.Dec `json:"pending_rewards"`
+	// Fees is the estimated fees of the txs in the round, which are paid out of the rewards claimed
+	Fees          sdk.Dec `json:"fees"`
+	NeedAddShares bool    `json:"need_add_shares"`
+	// Claimed is the amount of the rewards withdrawn to the delegator actually, which is known once the withdrawal
+	// is confirmed
+	Claimed       sdk.Dec   `json:"claimed"`
+	DepositAmount sdk.Dec   `json:"deposit_amount"`
+	Withdraw      *TxRecord `json:"withdraw,omitempty"`
+	Deposit       *TxRecord `json:"deposit,omitempty"`
+	AddShares     *TxRecord `json:"add_shares,omitempty"`
+}
+
+// State - structure of the progress of the agent, which is persisted before and after each broadcast
+type State struct {
+	DelegatorAddress string  `json:"delegator_address"`
+	AccountNumber    uint64  `json:"account_number"`
+	Phase            string  `json:"phase"`
+	Round            *Round  `json:"round,omitempty"`
+	Rounds           int     `json:"rounds"`
+	TotalCompounded  sdk.Dec `json:"total_compounded"`
+	LastRoundHeight  int64   `json:"last_round_height,omitempty"`
+}
+
+// LoadState loads the state from the file. The existence is false if the file doesn't exist
+func LoadState(filePath string) (state State, exists bool, err error) {
+	bytes, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return state, false, nil
+	}
+	if err != nil {
+		return state, false, fmt.Errorf("failed. read state error: %s", err)
+	}
+
+	if err = json.Unmarshal(bytes, &state); err != nil {
+		return state, true, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return state, true, nil
+}
+
+// SaveState saves the state to the file atomically, so that a crash never leaves a broken state behind
+func SaveState(filePath string, state State) error {
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return utils.ErrMarshalJSON(err.Error())
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed. create temp state error: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.Write(bytes); err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed. write state error: %s", err)
+	}
+
+	if err = os.Rename(tmpFile.Name(), filePath); err != nil {
+		return fmt.Errorf("failed. replace state error: %s", err)
+	}
+
+	return nil
+}
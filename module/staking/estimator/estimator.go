@@ -0,0 +1,358 @@
+package estimator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	stakingtypes "github.com/okex/exchain-go-sdk/module/staking/types"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+)
+
+// const
+const (
+	// DefaultMaxWindow is the default max number of blocks sampled in a window
+	DefaultMaxWindow = 10000
+
+	// Year is the duration that the yield is annualized to
+	Year = 365 * 24 * time.Hour
+)
+
+// Client shows the expected behavior of the client that the estimator works with
+type Client interface {
+	Staking() exposed.Staking
+	Tendermint() exposed.Tendermint
+}
+
+// ValidatorYield - structure of the rewards allocated to a validator in the sample window and the annualized yield
+type ValidatorYield struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address"`
+	Moniker          string         `json:"moniker"`
+	Jailed           bool           `json:"jailed"`
+	CommissionRate   sdk.Dec        `json:"commission_rate"`
+	DelegatorShares  sdk.Dec        `json:"delegator_shares"`
+	// Rewards is the total amount allocated to the validator, Commission of which is kept by the validator and
+	// DelegatorRewards of which is shared by the delegators
+	Rewards          sdk.SysCoins `json:"rewards"`
+	Commission       sdk.SysCoins `json:"commission"`
+	DelegatorRewards sdk.SysCoins `json:"delegator_rewards"`
+	// APR is the annualized delegator rewards in the bond denom per share
+	APR sdk.Dec `json:"apr"`
+}
+
+// Sample - structure of the rewards distribution sampled in the block window (FromHeight, ToHeight]
+type Sample struct {
+	FromHeight int64            `json:"from_height"`
+	ToHeight   int64            `json:"to_height"`
+	FromTime   time.Time        `json:"from_time"`
+	ToTime     time.Time        `json:"to_time"`
+	Denom      string           `json:"denom"`
+	Validators []ValidatorYield `json:"validators"`
+}
+
+// AnnualFactor gets the ratio of a year to the duration of the sample window
+func (s Sample) AnnualFactor() sdk.Dec {
+	duration := s.ToTime.Sub(s.FromTime)
+	if duration <= 0 {
+		return sdk.ZeroDec()
+	}
+
+	return sdk.NewDec(int64(Year)).QuoInt64(int64(duration))
+}
+
+// GetValidator gets the yield of a validator in the sample
+func (s Sample) GetValidator(valAddr sdk.ValAddress) (ValidatorYield, bool) {
+	for _, val := range s.Validators {
+		if val.ValidatorAddress.Equals(valAddr) {
+			return val, true
+		}
+	}
+
+	return ValidatorYield{}, false
+}
+
+// annualRewards estimates the annual rewards in the bond denom of the shares added to a validator, where the shares of
+// the validator are supposed to be extraShares more than those in the sample
+func (s Sample) annualRewards(val ValidatorYield, shares, extraShares sdk.Dec) sdk.Dec {
+	totalShares := val.DelegatorShares.Add(extraShares)
+	if !totalShares.IsPositive() || !shares.IsPositive() {
+		return sdk.ZeroDec()
+	}
+
+	return val.DelegatorRewards.AmountOf(s.Denom).Mul(shares).Quo(totalShares).Mul(s.AnnualFactor())
+}
+
+// ShareYield - structure of the estimated annual rewards from a validator
+type ShareYield struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address"`
+	Shares           sdk.Dec        `json:"shares"`
+	AnnualRewards    sdk.Dec        `json:"annual_rewards"`
+}
+
+// DelegatorYield - structure of the estimated annual rewards and yield of a delegator
+type DelegatorYield struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address"`
+	Tokens           sdk.Dec        `json:"tokens"`
+	Validators       []ShareYield   `json:"validators"`
+	AnnualRewards    sdk.Dec        `json:"annual_rewards"`
+	APR              sdk.Dec        `json:"apr"`
+}
+
+// Estimator - structure of the staking reward estimator
+type Estimator struct {
+	cli       Client
+	denom     string
+	maxWindow int64
+}
+
+// NewEstimator creates a new instance of Estimator
+// Note: denom is the bond denom that the yield is computed in, and sdk.DefaultBondDenom is used by empty input
+func NewEstimator(cli Client, denom string) *Estimator {
+	if len(denom) == 0 {
+		denom = sdk.DefaultBondDenom
+	}
+
+	return &Estimator{
+		cli:       cli,
+		denom:     denom,
+		maxWindow: DefaultMaxWindow,
+	}
+}
+
+// SetMaxWindow sets the max number of blocks sampled in a window
+func (e *Estimator) SetMaxWindow(maxWindow int64) {
+	e.maxWindow = maxWindow
+}
+
+// Sample samples the rewards distributed in the BeginBlock of the blocks (fromHeight, toHeight] and the validators at
+// toHeight
+// Note: toHeight 0 stands for the latest height. The rewards of each block are the fees of the previous block, so the
+// sample of a short window is sensitive to the bursts of txs
+func (e *Estimator) Sample(fromHeight, toHeight int64) (sample Sample, err error) {
+	toBlock, err := e.cli.Tendermint().QueryBlock(toHeight)
+	if err != nil {
+		return sample, utils.ErrClientQuery(err.Error())
+	}
+	toHeight = toBlock.Height
+
+	if fromHeight <= 0 || fromHeight >= toHeight {
+		return sample, fmt.Errorf("failed. invalid block window (%d, %d]", fromHeight, toHeight)
+	}
+
+	if e.maxWindow > 0 && toHeight-fromHeight > e.maxWindow {
+		return sample, fmt.Errorf("failed. block window (%d, %d] exceeds the max window %d", fromHeight, toHeight,
+			e.maxWindow)
+	}
+
+	fromBlock, err := e.cli.Tendermint().QueryBlock(fromHeight)
+	if err != nil {
+		return sample, utils.ErrClientQuery(err.Error())
+	}
+
+	valsResult, err := e.cli.Staking().QueryValidatorsByStatus(stakingtypes.ValidatorStatusAll, toHeight, 1,
+		math.MaxInt32)
+	if err != nil {
+		return
+	}
+
+	yields := make(map[string]*ValidatorYield, len(valsResult.Validators))
+	for _, val := range valsResult.Validators {
+		yields[val.OperatorAddress.String()] = &ValidatorYield{
+			ValidatorAddress: val.OperatorAddress,
+			Moniker:          val.Description.Moniker,
+			Jailed:           val.Jailed,
+			CommissionRate:   val.Commission.Rate,
+			DelegatorShares:  val.DelegatorShares,
+		}
+	}
+
+	for height := fromHeight + 1; height <= toHeight; height++ {
+		blockResults, err := e.cli.Tendermint().QueryBlockResults(height)
+		if err != nil {
+			return sample, utils.ErrClientQuery(err.Error())
+		}
+
+		if err = collectRewards(blockResults, yields); err != nil {
+			return sample, fmt.Errorf("failed. block %d: %s", height, err)
+		}
+	}
+
+	sample = Sample{
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		FromTime:   fromBlock.Time,
+		ToTime:     toBlock.Time,
+		Denom:      e.denom,
+		Validators: make([]ValidatorYield, 0, len(yields)),
+	}
+	if !sample.ToTime.After(sample.FromTime) {
+		return sample, fmt.Errorf("failed. invalid block time from %s to %s", sample.FromTime, sample.ToTime)
+	}
+
+	factor := sample.AnnualFactor()
+	for _, yield := range yields {
+		yield.DelegatorRewards = yield.Rewards.Sub(yield.Commission)
+		yield.APR = sdk.ZeroDec()
+		if yield.DelegatorShares.IsPositive() {
+			yield.APR = yield.DelegatorRewards.AmountOf(e.denom).Quo(yield.DelegatorShares).Mul(factor)
+		}
+		sample.Validators = append(sample.Validators, *yield)
+	}
+
+	sort.SliceStable(sample.Validators, func(i, j int) bool {
+		if !sample.Validators[i].APR.Equal(sample.Validators[j].APR) {
+			return sample.Validators[i].APR.GT(sample.Validators[j].APR)
+		}
+		return sample.Validators[i].ValidatorAddress.String() < sample.Validators[j].ValidatorAddress.String()
+	})
+
+	return
+}
+
+// EstimateDelegator estimates the annual rewards and yield of a delegator with the sample
+// Note: the delegator address is accepted in both bech32 and hex format. All the shares of a delegator are added to
+// each validator it votes, so the yield is the sum of the yields from those validators
+func (e *Estimator) EstimateDelegator(sample Sample, delAddrStr string) (yield DelegatorYield, err error) {
+	accAddr, err := utils.ToCosmosAddress(delAddrStr)
+	if err != nil {
+		return
+	}
+
+	delResp, err := e.cli.Staking().QueryDelegator(accAddr.String())
+	if err != nil {
+		return
+	}
+
+	yield = DelegatorYield{
+		DelegatorAddress: accAddr,
+		Tokens:           delResp.Tokens,
+		AnnualRewards:    sdk.ZeroDec(),
+		APR:              sdk.ZeroDec(),
+	}
+	if delResp.IsProxy {
+		// the shares of a proxy include the tokens delegated by the delegators bound to it
+		yield.Tokens = delResp.Tokens.Add(delResp.TotalDelegatedTokens)
+	}
+
+	for _, valAddr := range delResp.ValidatorAddresses {
+		shareYield := ShareYield{
+			ValidatorAddress: valAddr,
+			Shares:           delResp.Shares,
+			AnnualRewards:    sdk.ZeroDec(),
+		}
+		if val, ok := sample.GetValidator(valAddr); ok {
+			shareYield.AnnualRewards = sample.annualRewards(val, delResp.Shares, sdk.ZeroDec())
+		}
+
+		yield.Validators = append(yield.Validators, shareYield)
+		yield.AnnualRewards = yield.AnnualRewards.Add(shareYield.AnnualRewards)
+	}
+
+	if yield.Tokens.IsPositive() {
+		yield.APR = yield.AnnualRewards.Quo(yield.Tokens)
+	}
+
+	return
+}
+
+// ProjectAddShares projects the annual rewards and yield of the tokens deposited and added to the validators
+// Note: the shares are regarded as equal to the tokens, and they dilute the rewards of the validators in the sample.
+// The extra rewards that the validators get for the extra shares are not taken into account, so the projection is a
+// bit conservative
+func (e *Estimator) ProjectAddShares(sample Sample, tokens sdk.Dec, valAddrsStr []string) (yield DelegatorYield,
+	err error) {
+	if tokens.IsNil() || !tokens.IsPositive() {
+		return yield, errors.New("failed. tokens to deposit must be positive")
+	}
+
+	valAddrs, err := utils.ParseValAddresses(valAddrsStr)
+	if err != nil {
+		return
+	}
+
+	yield = DelegatorYield{
+		Tokens:        tokens,
+		AnnualRewards: sdk.ZeroDec(),
+		APR:           sdk.ZeroDec(),
+	}
+	added := make(map[string]bool, len(valAddrs))
+	for _, valAddr := range valAddrs {
+		if added[valAddr.String()] {
+			return yield, fmt.Errorf("failed. duplicated validator %s", valAddr)
+		}
+		added[valAddr.String()] = true
+
+		val, ok := sample.GetValidator(valAddr)
+		if !ok {
+			return yield, fmt.Errorf("failed. validator %s isn't in the sample", valAddr)
+		}
+
+		shareYield := ShareYield{
+			ValidatorAddress: valAddr,
+			Shares:           tokens,
+			AnnualRewards:    sdk.ZeroDec(),
+		}
+		if !val.Jailed {
+			shareYield.AnnualRewards = sample.annualRewards(val, tokens, tokens)
+		}
+
+		yield.Validators = append(yield.Validators, shareYield)
+		yield.AnnualRewards = yield.AnnualRewards.Add(shareYield.AnnualRewards)
+	}
+
+	yield.APR = yield.AnnualRewards.Quo(tokens)
+	return
+}
+
+func collectRewards(blockResults *tmtypes.ResultBlockResults, yields map[string]*ValidatorYield) error {
+	for _, event := range blockResults.BeginBlockEvents {
+		if event.Type != distrtypes.EventTypeRewards && event.Type != distrtypes.EventTypeCommission {
+			continue
+		}
+
+		valAddrStr, amount, err := parseAllocationEvent(event)
+		if err != nil {
+			return err
+		}
+
+		yield, ok := yields[valAddrStr]
+		if !ok {
+			// the validator has been removed since then
+			continue
+		}
+
+		if event.Type == distrtypes.EventTypeRewards {
+			yield.Rewards = yield.Rewards.Add(amount...)
+		} else {
+			yield.Commission = yield.Commission.Add(amount...)
+		}
+	}
+
+	return nil
+}
+
+func parseAllocationEvent(event abci.Event) (valAddrStr string, amount sdk.SysCoins, err error) {
+	for _, attr := range event.Attributes {
+		switch string(attr.Key) {
+		case distrtypes.AttributeKeyValidator:
+			valAddrStr = string(attr.Value)
+		case sdk.AttributeKeyAmount:
+			if amount, err = sdk.ParseDecCoins(string(attr.Value)); err != nil {
+				return valAddrStr, amount, fmt.Errorf("parse %s event amount error: %s", event.Type, err)
+			}
+		}
+	}
+
+	if len(valAddrStr) == 0 {
+		return valAddrStr, amount, fmt.Errorf("no validator in %s event", event.Type)
+	}
+
+	return
+}
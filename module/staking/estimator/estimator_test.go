@@ -0,0 +1,197 @@
+package estimator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/mocks"
+	stakingtypes "github.com/okex/exchain-go-sdk/module/staking/types"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	distrtypes "github.com/okx/okbchain/x/distribution/types"
+	okbstakingtypes "github.com/okx/okbchain/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	addr     = "ex1qj5c07sm6jetjz8f509qtrxgh4psxkv3ddyq7u"
+	valAddr1 = "exvaloper1qwuag8gx408m9ej038vzx50ntt0x4yrq8qwdtq"
+)
+
+var (
+	startTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	valAddr2  = sdk.ValAddress(sdk.MustAccAddressFromBech32(addr)).String()
+)
+
+// fakeChain allocates the same rewards to the validators in each block, and a year passes in 1000 blocks
+type fakeChain struct {
+	*mocks.FakeChain
+	rewards map[string][2]string
+}
+
+func newFakeChain(t *testing.T) *fakeChain {
+	newValidator := func(valAddrStr string, shares, commissionRate string) stakingtypes.Validator {
+		valAddr, err := sdk.ValAddressFromBech32(valAddrStr)
+		require.NoError(t, err)
+		return stakingtypes.Validator{
+			OperatorAddress: valAddr,
+			DelegatorShares: sdk.MustNewDecFromStr(shares),
+			Description:     okbstakingtypes.Description{Moniker: valAddrStr[len(valAddrStr)-4:]},
+			Commission: okbstakingtypes.NewCommission(sdk.MustNewDecFromStr(commissionRate), sdk.OneDec(),
+				sdk.OneDec()),
+		}
+	}
+
+	val1, val2 := newValidator(valAddr1, "100", "0.1"), newValidator(valAddr2, "300", "0.5")
+	chain := &fakeChain{
+		FakeChain: mocks.NewFakeChain(12, 0),
+		rewards: map[string][2]string{
+			valAddr1: {"1okb", "0.1okb"},
+			valAddr2: {"3okb", "1.5okb"},
+		},
+	}
+	chain.Validators = []stakingtypes.Validator{val2, val1}
+	chain.Delegator = stakingtypes.DelegatorResponse{
+		DelegatorAddress:   sdk.MustAccAddressFromBech32(addr),
+		ValidatorAddresses: []sdk.ValAddress{val1.OperatorAddress, val2.OperatorAddress},
+		Shares:             sdk.NewDec(50),
+		Tokens:             sdk.NewDec(50),
+	}
+	return chain
+}
+
+func (fc *fakeChain) Tendermint() exposed.Tendermint {
+	return fakeTendermint{Tendermint: fc.FakeChain.Tendermint(), chain: fc}
+}
+
+type fakeTendermint struct {
+	exposed.Tendermint
+	chain *fakeChain
+}
+
+func (ft fakeTendermint) QueryBlock(height int64) (*tmtypes.Block, error) {
+	if height == 0 {
+		height = ft.chain.Height
+	}
+	if height > ft.chain.Height {
+		return nil, errors.New("height is too high")
+	}
+
+	var block tmtypes.Block
+	block.Height = height
+	block.Time = startTime.Add(time.Duration(height) * Year / 1000)
+	return &block, nil
+}
+
+func (ft fakeTendermint) QueryBlockResults(height int64) (*tmtypes.ResultBlockResults, error) {
+	if height > ft.chain.Height {
+		return nil, errors.New("height is too high")
+	}
+
+	blockResults := &tmtypes.ResultBlockResults{Height: height}
+	for valAddrStr, rewards := range ft.chain.rewards {
+		for i, eventType := range []string{distrtypes.EventTypeRewards, distrtypes.EventTypeCommission} {
+			blockResults.BeginBlockEvents = append(blockResults.BeginBlockEvents, abci.Event{
+				Type: eventType,
+				Attributes: []kv.Pair{
+					{Key: []byte(sdk.AttributeKeyAmount), Value: []byte(rewards[i])},
+					{Key: []byte(distrtypes.AttributeKeyValidator), Value: []byte(valAddrStr)},
+				},
+			})
+		}
+	}
+
+	return blockResults, nil
+}
+
+func TestEstimator_Sample(t *testing.T) {
+	estimator := NewEstimator(newFakeChain(t), "")
+	sample, err := estimator.Sample(10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), sample.FromHeight)
+	require.Equal(t, int64(12), sample.ToHeight)
+	require.Equal(t, sdk.DefaultBondDenom, sample.Denom)
+	require.Equal(t, sdk.NewDec(500), sample.AnnualFactor())
+	require.Equal(t, 2, len(sample.Validators))
+
+	// sorted by APR
+	val1, val2 := sample.Validators[0], sample.Validators[1]
+	require.Equal(t, valAddr1, val1.ValidatorAddress.String())
+	require.Equal(t, "2.000000000000000000okb", val1.Rewards.String())
+	require.Equal(t, "0.200000000000000000okb", val1.Commission.String())
+	require.Equal(t, "1.800000000000000000okb", val1.DelegatorRewards.String())
+	require.Equal(t, sdk.NewDec(9), val1.APR)
+	require.Equal(t, valAddr2, val2.ValidatorAddress.String())
+	require.Equal(t, sdk.NewDec(5), val2.APR)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), val2.CommissionRate)
+
+	_, err = estimator.Sample(12, 12)
+	require.Error(t, err)
+
+	_, err = estimator.Sample(0, 12)
+	require.Error(t, err)
+
+	_, err = estimator.Sample(10, 13)
+	require.Error(t, err)
+
+	estimator.SetMaxWindow(1)
+	_, err = estimator.Sample(10, 12)
+	require.Error(t, err)
+}
+
+func TestEstimator_EstimateDelegator(t *testing.T) {
+	chain := newFakeChain(t)
+	estimator := NewEstimator(chain, "")
+	sample, err := estimator.Sample(10, 12)
+	require.NoError(t, err)
+
+	// hex address is accepted
+	yield, err := estimator.EstimateDelegator(sample, "0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991")
+	require.NoError(t, err)
+	require.Equal(t, addr, yield.DelegatorAddress.String())
+	require.Equal(t, 2, len(yield.Validators))
+	require.Equal(t, sdk.NewDec(450), yield.Validators[0].AnnualRewards)
+	require.Equal(t, sdk.NewDec(250), yield.Validators[1].AnnualRewards)
+	require.Equal(t, sdk.NewDec(700), yield.AnnualRewards)
+	require.Equal(t, sdk.NewDec(14), yield.APR)
+
+	// the tokens delegated to a proxy are counted in
+	chain.Delegator.IsProxy, chain.Delegator.TotalDelegatedTokens = true, sdk.NewDec(50)
+	yield, err = estimator.EstimateDelegator(sample, addr)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(7), yield.APR)
+
+	_, err = estimator.EstimateDelegator(sample, addr[1:])
+	require.Error(t, err)
+
+	_, err = estimator.EstimateDelegator(sample, valAddr2)
+	require.Error(t, err)
+}
+
+func TestEstimator_ProjectAddShares(t *testing.T) {
+	chain := newFakeChain(t)
+	estimator := NewEstimator(chain, "")
+	sample, err := estimator.Sample(10, 12)
+	require.NoError(t, err)
+
+	yield, err := estimator.ProjectAddShares(sample, sdk.NewDec(100), []string{valAddr1, valAddr2})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(yield.Validators))
+	require.Equal(t, sdk.NewDec(450), yield.Validators[0].AnnualRewards)
+	require.Equal(t, sdk.NewDec(375), yield.Validators[1].AnnualRewards)
+	require.Equal(t, sdk.MustNewDecFromStr("8.25"), yield.APR)
+
+	_, err = estimator.ProjectAddShares(sample, sdk.ZeroDec(), []string{valAddr1})
+	require.Error(t, err)
+
+	_, err = estimator.ProjectAddShares(sample, sdk.NewDec(100), []string{valAddr1, valAddr1})
+	require.Error(t, err)
+
+	_, err = estimator.ProjectAddShares(sample, sdk.NewDec(100), []string{
+		sdk.ValAddress(sdk.MustAccAddressFromBech32("ex1qwuag8gx408m9ej038vzx50ntt0x4yrq38yf06")[:19]).String()})
+	require.Error(t, err)
+}
@@ -0,0 +1,344 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	stakingtypes "github.com/okex/exchain-go-sdk/module/staking/types"
+	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/x/common"
+	stakingkeeper "github.com/okx/okbchain/x/staking/keeper"
+)
+
+// actions of the steps in a plan
+const (
+	ActionDeposit   = "deposit"
+	ActionWithdraw  = "withdraw"
+	ActionAddShares = "add_shares"
+)
+
+// reasons of the flagged validators
+const (
+	// FlagJailed means that the validator is jailed and gets no rewards
+	FlagJailed = "jailed"
+	// FlagDestroyed means that the validator has been destroyed by its owner and can't be added shares to any more
+	FlagDestroyed = "destroyed"
+	// FlagNotFound means that the validator doesn't exist on chain any more
+	FlagNotFound = "not_found"
+)
+
+// Client shows the expected behavior of the client that the planner works with
+type Client interface {
+	Auth() exposed.Auth
+	Staking() exposed.Staking
+}
+
+// Policy - structure of the target policy of the delegation
+type Policy struct {
+	// TopN is the number of the validators with the lowest commission rates to add shares to, which is capped by the
+	// staking param max_validators_to_add_shares
+	TopN int
+	// ExcludeJailed excludes the jailed validators from the candidates. The destroyed validators are always excluded
+	ExcludeJailed bool
+	// MaxSharesPerValidator excludes the validators whose total delegator shares would exceed it after the shares of
+	// the delegator are added. No limit by nil or zero
+	// Note: it's in shares rather than tokens, and the target tokens are weighted into shares as the chain does
+	MaxSharesPerValidator sdk.Dec
+	// TargetTokens is the amount of tokens that the delegator is supposed to deposit in the end. The current deposit
+	// is kept by nil
+	TargetTokens sdk.Dec
+}
+
+// Step - structure of a tx to send in the plan
+type Step struct {
+	Action             string   `json:"action"`
+	Amount             string   `json:"amount,omitempty"`
+	ValidatorAddresses []string `json:"validator_addresses,omitempty"`
+}
+
+// Flag - structure of a validator that the delegator has added shares to but is in an abnormal state
+type Flag struct {
+	ValidatorAddress string `json:"validator_address"`
+	Reason           string `json:"reason"`
+}
+
+// Plan - structure of the steps to rebalance the delegation of a delegator
+type Plan struct {
+	DelegatorAddress  string   `json:"delegator_address"`
+	CurrentTokens     sdk.Dec  `json:"current_tokens"`
+	TargetTokens      sdk.Dec  `json:"target_tokens"`
+	CurrentValidators []string `json:"current_validators"`
+	TargetValidators  []string `json:"target_validators"`
+	Steps             []Step   `json:"steps"`
+	Flags             []Flag   `json:"flags"`
+}
+
+// IsEmpty shows whether the delegation is balanced already
+func (p Plan) IsEmpty() bool {
+	return len(p.Steps) == 0
+}
+
+// Planner - structure of the delegation rebalancing planner
+type Planner struct {
+	cli Client
+}
+
+// NewPlanner creates a new instance of Planner
+func NewPlanner(cli Client) *Planner {
+	return &Planner{cli}
+}
+
+// Plan makes the plan to rebalance the delegation of a delegator to the policy with the current state on chain
+// Note: the steps are in the order of Deposit, AddShares and Withdraw, where at most one of Deposit and Withdraw is
+// needed. Deposit and Withdraw update the shares on the validators added already, so AddShares is planned only if the
+// target validators are different from the current ones
+func (p *Planner) Plan(delAddrStr string, policy Policy) (plan Plan, err error) {
+	if policy.TopN <= 0 {
+		return plan, errors.New("failed. TopN of the policy must be positive")
+	}
+
+	if !policy.TargetTokens.IsNil() && policy.TargetTokens.IsNegative() {
+		return plan, errors.New("failed. negative target tokens")
+	}
+
+	accAddr, err := utils.ToCosmosAddress(delAddrStr)
+	if err != nil {
+		return
+	}
+
+	delResp, err := p.cli.Staking().QueryDelegator(accAddr.String())
+	if err != nil {
+		return
+	}
+
+	vals, err := p.cli.Staking().QueryValidators()
+	if err != nil {
+		return
+	}
+
+	stakingParams, err := p.cli.Staking().QueryParams(0)
+	if err != nil {
+		return
+	}
+
+	plan = Plan{
+		DelegatorAddress:  accAddr.String(),
+		CurrentTokens:     delResp.Tokens,
+		TargetTokens:      delResp.Tokens,
+		CurrentValidators: make([]string, len(delResp.ValidatorAddresses)),
+	}
+	if !policy.TargetTokens.IsNil() {
+		plan.TargetTokens = policy.TargetTokens
+	}
+	for i, valAddr := range delResp.ValidatorAddresses {
+		plan.CurrentValidators[i] = valAddr.String()
+	}
+	plan.Flags = flagValidators(delResp.ValidatorAddresses, vals, stakingParams.MinSelfDelegation)
+
+	// deposit or withdraw
+	var depositStep, withdrawStep *Step
+	if delta := plan.TargetTokens.Sub(plan.CurrentTokens); !delta.IsZero() {
+		step := Step{Action: ActionDeposit}
+		if delta.IsNegative() {
+			step.Action, delta = ActionWithdraw, delta.Neg()
+			if delResp.IsProxy && plan.TargetTokens.IsZero() {
+				return plan, errors.New("failed. a proxy has to be unregistered before withdrawing all the tokens")
+			}
+		}
+		if delta.LT(stakingParams.MinDelegation) {
+			return plan, fmt.Errorf("failed. the amount %s to %s is less than the min delegation %s", delta,
+				step.Action, stakingParams.MinDelegation)
+		}
+		step.Amount = sdk.NewDecCoinFromDec(common.NativeToken, delta).String()
+		if step.Action == ActionDeposit {
+			depositStep = &step
+		} else {
+			withdrawStep = &step
+		}
+	}
+
+	if depositStep != nil {
+		plan.Steps = append(plan.Steps, *depositStep)
+	}
+
+	// nothing to add shares with after withdrawing all the tokens
+	if plan.TargetTokens.IsPositive() {
+		targetTokens := plan.TargetTokens
+		if delResp.IsProxy {
+			targetTokens = targetTokens.Add(delResp.TotalDelegatedTokens)
+		}
+
+		plan.TargetValidators = selectValidators(vals, delResp, policy, stakingParams, targetTokens)
+		if len(plan.TargetValidators) == 0 {
+			return plan, errors.New("failed. no validator satisfies the policy")
+		}
+
+		if !isSameSet(plan.CurrentValidators, plan.TargetValidators) {
+			if !delResp.ProxyAddress.Empty() {
+				return plan, fmt.Errorf("failed. delegator %s is bound to proxy %s and has to unbind before adding "+
+					"shares", accAddr, delResp.ProxyAddress)
+			}
+
+			plan.Steps = append(plan.Steps, Step{
+				Action:             ActionAddShares,
+				ValidatorAddresses: plan.TargetValidators,
+			})
+		}
+	}
+
+	if withdrawStep != nil {
+		plan.Steps = append(plan.Steps, *withdrawStep)
+	}
+
+	return
+}
+
+// Execute sends the txs of the plan in order with the successive sequences of the delegator, and stops at the first
+// failed one
+// Note: the responses of the txs sent are returned along with the error
+func (p *Planner) Execute(plan Plan, fromInfo keys.Info, passWd, memo string) (resps []sdk.TxResponse, err error) {
+	if err = params.CheckKeyParams(fromInfo, passWd); err != nil {
+		return
+	}
+
+	if fromInfo.GetAddress().String() != plan.DelegatorAddress {
+		return resps, fmt.Errorf("failed. plan of %s can't be executed by %s", plan.DelegatorAddress,
+			fromInfo.GetAddress())
+	}
+
+	if plan.IsEmpty() {
+		return
+	}
+
+	acc, err := p.cli.Auth().QueryAccount(plan.DelegatorAddress)
+	if err != nil {
+		return
+	}
+
+	accNum, seqNum := acc.GetAccountNumber(), acc.GetSequence()
+	for i, step := range plan.Steps {
+		var resp sdk.TxResponse
+		switch step.Action {
+		case ActionDeposit:
+			resp, err = p.cli.Staking().Deposit(fromInfo, passWd, step.Amount, memo, accNum, seqNum)
+		case ActionWithdraw:
+			resp, err = p.cli.Staking().Withdraw(fromInfo, passWd, step.Amount, memo, accNum, seqNum)
+		case ActionAddShares:
+			resp, err = p.cli.Staking().AddShares(fromInfo, passWd, step.ValidatorAddresses, memo, accNum, seqNum)
+		default:
+			return resps, fmt.Errorf("failed. unknown action %s of step %d", step.Action, i)
+		}
+		if err != nil {
+			return resps, fmt.Errorf("failed. step %d %s: %s", i, step.Action, err)
+		}
+
+		resps = append(resps, resp)
+		if resp.Code != 0 {
+			return resps, fmt.Errorf("failed. step %d %s: tx %s failed with code %d: %s", i, step.Action, resp.TxHash,
+				resp.Code, resp.RawLog)
+		}
+		seqNum++
+	}
+
+	return
+}
+
+func flagValidators(valAddrs []sdk.ValAddress, vals []stakingtypes.Validator, minSelfDelegation sdk.Dec) (
+	flags []Flag) {
+	valsMap := make(map[string]stakingtypes.Validator, len(vals))
+	for _, val := range vals {
+		valsMap[val.OperatorAddress.String()] = val
+	}
+
+	for _, valAddr := range valAddrs {
+		val, ok := valsMap[valAddr.String()]
+		switch {
+		case !ok:
+			flags = append(flags, Flag{valAddr.String(), FlagNotFound})
+		case isDestroyed(val, minSelfDelegation):
+			flags = append(flags, Flag{valAddr.String(), FlagDestroyed})
+		case val.Jailed:
+			flags = append(flags, Flag{valAddr.String(), FlagJailed})
+		}
+	}
+
+	return
+}
+
+func selectValidators(vals []stakingtypes.Validator, delResp stakingtypes.DelegatorResponse, policy Policy,
+	stakingParams stakingtypes.Params, targetTokens sdk.Dec) (valAddrsStr []string) {
+	// the shares are weighted from the tokens by the chain, so the limit is compared with the weighted ones
+	targetShares := stakingkeeper.SimulateWeight(targetTokens)
+	currentVals := make(map[string]bool, len(delResp.ValidatorAddresses))
+	for _, valAddr := range delResp.ValidatorAddresses {
+		currentVals[valAddr.String()] = true
+	}
+
+	candidates := make([]stakingtypes.Validator, 0, len(vals))
+	for _, val := range vals {
+		if isDestroyed(val, stakingParams.MinSelfDelegation) || policy.ExcludeJailed && val.Jailed {
+			continue
+		}
+
+		if !policy.MaxSharesPerValidator.IsNil() && policy.MaxSharesPerValidator.IsPositive() {
+			shares := val.DelegatorShares
+			if currentVals[val.OperatorAddress.String()] {
+				shares = shares.Sub(delResp.Shares)
+			}
+			if shares.Add(targetShares).GT(policy.MaxSharesPerValidator) {
+				continue
+			}
+		}
+
+		candidates = append(candidates, val)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		rateI, rateJ := candidates[i].Commission.Rate, candidates[j].Commission.Rate
+		if !rateI.Equal(rateJ) {
+			return rateI.LT(rateJ)
+		}
+		return candidates[i].OperatorAddress.String() < candidates[j].OperatorAddress.String()
+	})
+
+	topN := policy.TopN
+	if maxVals := int(stakingParams.MaxValsToAddShares); maxVals > 0 && topN > maxVals {
+		topN = maxVals
+	}
+
+	for i := 0; i < len(candidates) && i < topN; i++ {
+		valAddrsStr = append(valAddrsStr, candidates[i].OperatorAddress.String())
+	}
+
+	return
+}
+
+// isDestroyed shows whether the validator has been destroyed, whose min self delegation is withdrawn
+// Note: it's the same rule as the chain refuses to add shares to a dismissed validator
+func isDestroyed(val stakingtypes.Validator, minSelfDelegation sdk.Dec) bool {
+	return val.MinSelfDelegation.IsNil() || val.MinSelfDelegation.LT(minSelfDelegation)
+}
+
+func isSameSet(strs1, strs2 []string) bool {
+	if len(strs1) != len(strs2) {
+		return false
+	}
+
+	set := make(map[string]bool, len(strs1))
+	for _, str := range strs1 {
+		set[str] = true
+	}
+
+	for _, str := range strs2 {
+		if !set[str] {
+			return false
+		}
+	}
+
+	return true
+}
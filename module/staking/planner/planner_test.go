@@ -0,0 +1,171 @@
+package planner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okex/exchain-go-sdk/mocks"
+	stakingtypes "github.com/okex/exchain-go-sdk/module/staking/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	okbstakingtypes "github.com/okx/okbchain/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	addr     = "ex1qj5c07sm6jetjz8f509qtrxgh4psxkv3ddyq7u"
+	name     = "alice"
+	passWd   = "12345678"
+	mnemonic = "giggle sibling fun arrow elevator spoon blood grocery laugh tortoise culture tool"
+	memo     = "my memo"
+)
+
+var (
+	valA, valB, valC, valD, valX = newValAddr(1), newValAddr(2), newValAddr(3), newValAddr(4), newValAddr(5)
+)
+
+func newValAddr(b byte) sdk.ValAddress {
+	return bytes.Repeat([]byte{b}, sdk.AddrLen)
+}
+
+// newFakeChain holds the validators of A(0.05), B(0.1, jailed), C(0.02, destroyed) and D(0.2), and the delegator added
+// shares to B, C and X which doesn't exist any more
+func newFakeChain() *mocks.FakeChain {
+	newValidator := func(valAddr sdk.ValAddress, shares int64, commissionRate string, jailed bool,
+		minSelfDelegation int64) stakingtypes.Validator {
+		return stakingtypes.Validator{
+			OperatorAddress:   valAddr,
+			Jailed:            jailed,
+			DelegatorShares:   sdk.NewDec(shares),
+			MinSelfDelegation: sdk.NewDec(minSelfDelegation),
+			Commission: okbstakingtypes.NewCommission(sdk.MustNewDecFromStr(commissionRate), sdk.OneDec(),
+				sdk.OneDec()),
+		}
+	}
+
+	chain := mocks.NewFakeChain(100, 8)
+	chain.Validators = []stakingtypes.Validator{
+		newValidator(valD, 100, "0.2", false, 10000),
+		newValidator(valC, 100, "0.02", false, 0),
+		newValidator(valB, 100, "0.1", true, 10000),
+		newValidator(valA, 1000, "0.05", false, 10000),
+	}
+	chain.Delegator = stakingtypes.DelegatorResponse{
+		DelegatorAddress:   sdk.MustAccAddressFromBech32(addr),
+		ValidatorAddresses: []sdk.ValAddress{valB, valC, valX},
+		Shares:             sdk.NewDec(100),
+		Tokens:             sdk.NewDec(100),
+	}
+	chain.StakingParams = okbstakingtypes.DefaultParams()
+	chain.StakingParams.MinSelfDelegation = sdk.NewDec(10000)
+	return chain
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	chain := newFakeChain()
+	planner := NewPlanner(chain)
+
+	plan, err := planner.Plan(addr, Policy{TopN: 2, ExcludeJailed: true, TargetTokens: sdk.NewDec(150)})
+	require.NoError(t, err)
+	require.Equal(t, []Flag{
+		{valB.String(), FlagJailed},
+		{valC.String(), FlagDestroyed},
+		{valX.String(), FlagNotFound},
+	}, plan.Flags)
+	require.Equal(t, []string{valA.String(), valD.String()}, plan.TargetValidators)
+	require.Equal(t, []Step{
+		{Action: ActionDeposit, Amount: "50.000000000000000000okb"},
+		{Action: ActionAddShares, ValidatorAddresses: []string{valA.String(), valD.String()}},
+	}, plan.Steps)
+
+	// jailed validators are allowed and the deposit is kept
+	plan, err = planner.Plan(addr, Policy{TopN: 2})
+	require.NoError(t, err)
+	require.Equal(t, []Step{{Action: ActionAddShares, ValidatorAddresses: []string{valA.String(), valB.String()}}},
+		plan.Steps)
+
+	// A is excluded because its shares would be 1000 plus the 150 ones weighted from the target tokens > 1100
+	plan, err = planner.Plan(addr, Policy{TopN: 2, ExcludeJailed: true, TargetTokens: sdk.NewDec(150),
+		MaxSharesPerValidator: sdk.NewDec(1100)})
+	require.NoError(t, err)
+	require.Equal(t, []string{valD.String()}, plan.TargetValidators)
+
+	// TopN is capped by the param
+	chain.StakingParams.MaxValsToAddShares = 1
+	plan, err = planner.Plan(addr, Policy{TopN: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{valA.String()}, plan.TargetValidators)
+	chain.StakingParams.MaxValsToAddShares = 30
+
+	// withdraw all
+	plan, err = planner.Plan(addr, Policy{TopN: 2, TargetTokens: sdk.ZeroDec()})
+	require.NoError(t, err)
+	require.Equal(t, []Step{{Action: ActionWithdraw, Amount: "100.000000000000000000okb"}}, plan.Steps)
+
+	// balanced already
+	chain.Delegator.ValidatorAddresses = []sdk.ValAddress{valD, valA}
+	plan, err = planner.Plan(addr, Policy{TopN: 2, ExcludeJailed: true})
+	require.NoError(t, err)
+	require.True(t, plan.IsEmpty())
+	require.Empty(t, plan.Flags)
+
+	// the shares on the current validators are updated by the withdrawal only
+	plan, err = planner.Plan(addr, Policy{TopN: 2, ExcludeJailed: true, TargetTokens: sdk.NewDec(60)})
+	require.NoError(t, err)
+	require.Equal(t, []Step{{Action: ActionWithdraw, Amount: "40.000000000000000000okb"}}, plan.Steps)
+
+	// less than the min delegation
+	_, err = planner.Plan(addr, Policy{TopN: 2, TargetTokens: sdk.MustNewDecFromStr("100.00001")})
+	require.Error(t, err)
+
+	// no shares adding during binding to a proxy
+	chain.Delegator.ProxyAddress = sdk.MustAccAddressFromBech32("ex1qwuag8gx408m9ej038vzx50ntt0x4yrq38yf06")
+	_, err = planner.Plan(addr, Policy{TopN: 1})
+	require.Error(t, err)
+
+	_, err = planner.Plan(addr, Policy{TopN: 0})
+	require.Error(t, err)
+
+	_, err = planner.Plan(addr, Policy{TopN: 1, TargetTokens: sdk.NewDec(-1)})
+	require.Error(t, err)
+
+	_, err = planner.Plan(addr, Policy{TopN: 1, MaxSharesPerValidator: sdk.OneDec()})
+	require.Error(t, err)
+
+	_, err = planner.Plan(addr[1:], Policy{TopN: 1})
+	require.Error(t, err)
+}
+
+func TestPlanner_Execute(t *testing.T) {
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	chain := newFakeChain()
+	planner := NewPlanner(chain)
+	plan, err := planner.Plan(addr, Policy{TopN: 2, ExcludeJailed: true, TargetTokens: sdk.NewDec(150)})
+	require.NoError(t, err)
+
+	resps, err := planner.Execute(plan, fromInfo, passWd, memo)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resps))
+	require.Equal(t, uint64(10), chain.Sequence)
+	require.Equal(t, 2, len(chain.Txs))
+	deposit := chain.Txs[0].TxResult.Events[0]
+	require.Equal(t, okbstakingtypes.EventTypeDelegate, deposit.Type)
+	require.Equal(t, "50.000000000000000000okb", string(deposit.Attributes[0].Value))
+	require.Equal(t, []sdk.ValAddress{valA, valD}, chain.Delegator.ValidatorAddresses)
+
+	// stop at the failed tx
+	chain.BroadcastBlock, chain.Fail = true, true
+	resps, err = planner.Execute(plan, fromInfo, passWd, memo)
+	require.Error(t, err)
+	require.Equal(t, 1, len(resps))
+	require.Equal(t, 3, len(chain.Txs))
+
+	_, err = planner.Execute(plan, fromInfo, "", memo)
+	require.Error(t, err)
+
+	plan.DelegatorAddress = "ex1qwuag8gx408m9ej038vzx50ntt0x4yrq38yf06"
+	_, err = planner.Execute(plan, fromInfo, passWd, memo)
+	require.Error(t, err)
+}
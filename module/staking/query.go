@@ -2,8 +2,12 @@ package staking
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/okex/exchain-go-sdk/module/staking/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/types/params"
 	"github.com/okex/exchain-go-sdk/utils"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	stakingtypes "github.com/okx/okbchain/x/staking/types"
@@ -67,7 +71,9 @@ func (sc stakingClient) QueryDelegator(delAddrStr string) (delResp types.Delegat
 		return delResp, utils.ErrClientQuery(err.Error())
 	}
 	if len(resp) != 0 {
-		sc.GetCodec().MustUnmarshalBinaryLengthPrefixed(resp, &delegator)
+		if err = sc.GetCodec().UnmarshalBinaryLengthPrefixed(resp, &delegator); err != nil {
+			return delResp, fmt.Errorf("failed. unmarshal delegator error: %s", err)
+		}
 	}
 
 	// query for the undelegation info
@@ -87,3 +93,192 @@ func (sc stakingClient) QueryDelegator(delAddrStr string) (delResp types.Delegat
 
 	return utils.ConvertToDelegatorResponse(delegator, undelegation), nil
 }
+
+// QueryValidatorsByStatus gets a page of the validators with a specific bond status at a specific height
+// Note: the status is one of "all", "bonded", "unbonding" and "unbonded" case-insensitively. Height 0 stands for the
+// latest height
+func (sc stakingClient) QueryValidatorsByStatus(status string, height int64, page, limit int) (
+	result types.ValidatorsResult, err error) {
+	if err = params.CheckQueryValidatorsParams(status, height, page, limit); err != nil {
+		return
+	}
+
+	// the validators of status "all" are returned without paging by the chain, which makes the total count available
+	jsonBytes, err := sc.GetCodec().MarshalJSON(stakingtypes.NewQueryValidatorsParams(1, 0, types.ValidatorStatusAll))
+	if err != nil {
+		return result, utils.ErrMarshalJSON(err.Error())
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryValidators)
+	res, resHeight, err := sc.QueryWithHeight(path, jsonBytes, height)
+	if err != nil {
+		return result, utils.ErrClientQuery(err.Error())
+	}
+
+	var vals []types.Validator
+	if err = sc.GetCodec().UnmarshalJSON(res, &vals); err != nil {
+		return result, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	status = strings.ToLower(status)
+	if status != types.ValidatorStatusAll {
+		filteredVals := make([]types.Validator, 0, len(vals))
+		for _, val := range vals {
+			if strings.EqualFold(val.GetStatus().String(), status) {
+				filteredVals = append(filteredVals, val)
+			}
+		}
+		vals = filteredVals
+	}
+
+	result.PageInfo = gosdktypes.NewPageInfo(resHeight, len(vals), page, limit)
+	result.Status = status
+	start, end := result.Bounds()
+	result.Validators = vals[start:end]
+	return
+}
+
+// QueryValidatorAllShares gets the shares added to a validator by each delegator at a specific height
+// Note: the shares are sorted in descending order. Height 0 stands for the latest height
+func (sc stakingClient) QueryValidatorAllShares(valAddrStr string, height int64) (
+	result types.ValidatorSharesResult, err error) {
+	valAddr, err := sdk.ValAddressFromBech32(valAddrStr)
+	if err != nil {
+		return
+	}
+
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return
+	}
+
+	jsonBytes, err := sc.GetCodec().MarshalJSON(stakingtypes.NewQueryValidatorParams(valAddr))
+	if err != nil {
+		return result, utils.ErrMarshalJSON(err.Error())
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryValidatorAllShares)
+	res, _, err := sc.QueryWithHeight(path, jsonBytes, height)
+	if err != nil {
+		return result, utils.ErrClientQuery(err.Error())
+	}
+
+	var shares []types.SharesResponse
+	if err = sc.GetCodec().UnmarshalJSON(res, &shares); err != nil {
+		return result, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].Shares.GT(shares[j].Shares)
+	})
+
+	result.ValidatorAddress, result.TotalShares, result.Shares = valAddr, sdk.ZeroDec(), shares
+	for _, share := range shares {
+		result.TotalShares = result.TotalShares.Add(share.Shares)
+	}
+
+	return
+}
+
+// QueryProxyDelegators gets the delegators bound to a proxy at a specific height
+// Note: the proxy address is accepted in both bech32 and hex format. Height 0 stands for the latest height
+func (sc stakingClient) QueryProxyDelegators(proxyAddrStr string, height int64) (
+	result types.ProxyDelegatorsResult, err error) {
+	proxyAddr, err := utils.ToCosmosAddress(proxyAddrStr)
+	if err != nil {
+		return
+	}
+
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return
+	}
+
+	jsonBytes, err := sc.GetCodec().MarshalJSON(stakingtypes.NewQueryDelegatorParams(proxyAddr))
+	if err != nil {
+		return result, utils.ErrMarshalJSON(err.Error())
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryProxy)
+	res, _, err := sc.QueryWithHeight(path, jsonBytes, height)
+	if err != nil {
+		return result, utils.ErrClientQuery(err.Error())
+	}
+
+	result.ProxyAddress = proxyAddr
+	if err = sc.GetCodec().UnmarshalJSON(res, &result.Delegators); err != nil {
+		return result, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryParams gets the current staking params at a specific height
+// Note: height 0 stands for the latest height
+func (sc stakingClient) QueryParams(height int64) (stakingParams types.Params, err error) {
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryParameters)
+	res, _, err := sc.QueryWithHeight(path, nil, height)
+	if err != nil {
+		return stakingParams, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = sc.GetCodec().UnmarshalJSON(res, &stakingParams); err != nil {
+		return stakingParams, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryPool gets the total amount of the bonded and not bonded tokens at a specific height
+// Note: height 0 stands for the latest height
+func (sc stakingClient) QueryPool(height int64) (pool types.Pool, err error) {
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryPool)
+	res, _, err := sc.QueryWithHeight(path, nil, height)
+	if err != nil {
+		return pool, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = sc.GetCodec().UnmarshalJSON(res, &pool); err != nil {
+		return pool, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryUnbonding gets the unbonding entry of an address at a specific height
+// Note: the address is accepted in both bech32 and hex format. An error is returned if there's no unbonding entry of
+// the address. Height 0 stands for the latest height
+func (sc stakingClient) QueryUnbonding(delAddrStr string, height int64) (undelegation types.UndelegationInfo,
+	err error) {
+	delAddr, err := utils.ToCosmosAddress(delAddrStr)
+	if err != nil {
+		return
+	}
+
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return
+	}
+
+	jsonBytes, err := sc.GetCodec().MarshalJSON(stakingtypes.NewQueryDelegatorParams(delAddr))
+	if err != nil {
+		return undelegation, utils.ErrMarshalJSON(err.Error())
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryUnbondingDelegation)
+	res, _, err := sc.QueryWithHeight(path, jsonBytes, height)
+	if err != nil {
+		return undelegation, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = sc.GetCodec().UnmarshalJSON(res, &undelegation); err != nil {
+		return undelegation, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
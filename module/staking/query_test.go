@@ -206,3 +206,285 @@ func TestStakingClient_QueryDelegator(t *testing.T) {
 	_, err = mockCli.Staking().QueryDelegator(addr)
 	require.Error(t, err)
 }
+
+func TestStakingClient_QueryValidatorsByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewStakingClient(mockCli.MockBaseClient))
+
+	consPK, err := stakingtypes.GetConsPubKeyBech32(valConsPK)
+	require.NoError(t, err)
+	valOperAddr, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+	statuses := []sdk.BondStatus{sdk.Bonded, sdk.Unbonded, sdk.Bonded, sdk.Bonded}
+	vals := make([]stakingtypes.Validator, len(statuses))
+	for i, status := range statuses {
+		vals[i] = stakingtypes.Validator{
+			OperatorAddress: valOperAddr,
+			ConsPubKey:      consPK,
+			Status:          status,
+			DelegatorShares: sdk.NewDec(int64(i)),
+			Description:     stakingtypes.Description{Moniker: fmt.Sprintf("val%d", i)},
+		}
+	}
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	expectedRet := expectedCdc.MustMarshalJSON(vals)
+	expectedPath := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryValidators)
+	expectedParams, err := expectedCdc.MarshalJSON(stakingtypes.NewQueryValidatorsParams(1, 0, "all"))
+	require.NoError(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(1024)).
+		Return(expectedRet, int64(1024), nil)
+	result, err := mockCli.Staking().QueryValidatorsByStatus("Bonded", 1024, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), result.Height)
+	require.Equal(t, 3, result.TotalCount)
+	require.Equal(t, "bonded", result.Status)
+	require.False(t, result.HasNext())
+	require.Equal(t, 1, len(result.Validators))
+	require.Equal(t, "val3", result.Validators[0].Description.Moniker)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(expectedRet, int64(2048), nil)
+	result, err = mockCli.Staking().QueryValidatorsByStatus("all", 0, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, 4, result.TotalCount)
+	require.True(t, result.HasNext())
+	require.Equal(t, 3, len(result.Validators))
+	require.Equal(t, sdk.Unbonded, result.Validators[1].Status)
+
+	_, err = mockCli.Staking().QueryValidatorsByStatus("jailed", 0, 1, 3)
+	require.Error(t, err)
+
+	_, err = mockCli.Staking().QueryValidatorsByStatus("all", -1, 1, 3)
+	require.Error(t, err)
+
+	_, err = mockCli.Staking().QueryValidatorsByStatus("all", 0, 0, 3)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(expectedRet[1:], int64(2048), nil)
+	_, err = mockCli.Staking().QueryValidatorsByStatus("all", 0, 1, 3)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Staking().QueryValidatorsByStatus("all", 0, 1, 3)
+	require.Error(t, err)
+}
+
+func TestStakingClient_QueryValidatorAllShares(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewStakingClient(mockCli.MockBaseClient))
+
+	valOperAddr, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	proxyAddr, err := sdk.AccAddressFromBech32(proxyAddr)
+	require.NoError(t, err)
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	expectedRet := expectedCdc.MustMarshalJSON([]stakingtypes.SharesResponse{
+		stakingtypes.NewSharesResponse(delAddr, sdk.NewDecWithPrec(1024, 2)),
+		stakingtypes.NewSharesResponse(proxyAddr, sdk.NewDecWithPrec(2048, 2)),
+	})
+	expectedPath := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryValidatorAllShares)
+	expectedParams, err := expectedCdc.MarshalJSON(stakingtypes.NewQueryValidatorParams(valOperAddr))
+	require.NoError(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(expectedRet, int64(1024), nil)
+	result, err := mockCli.Staking().QueryValidatorAllShares(valAddr, 0)
+	require.NoError(t, err)
+	require.Equal(t, valOperAddr, result.ValidatorAddress)
+	require.Equal(t, sdk.NewDecWithPrec(3072, 2), result.TotalShares)
+	require.Equal(t, 2, len(result.Shares))
+	require.Equal(t, proxyAddr, result.Shares[0].DelAddr)
+	require.Equal(t, delAddr, result.Shares[1].DelAddr)
+
+	_, err = mockCli.Staking().QueryValidatorAllShares(valAddr[1:], 0)
+	require.Error(t, err)
+
+	_, err = mockCli.Staking().QueryValidatorAllShares(valAddr, -1)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Staking().QueryValidatorAllShares(valAddr, 0)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Staking().QueryValidatorAllShares(valAddr, 0)
+	require.Error(t, err)
+}
+
+func TestStakingClient_QueryProxyDelegators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewStakingClient(mockCli.MockBaseClient))
+
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	proxyAccAddr, err := sdk.AccAddressFromBech32(proxyAddr)
+	require.NoError(t, err)
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	expectedRet := mockCli.BuildAccAddrListBytes(delAddr)
+	expectedPath := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryProxy)
+	expectedParams, err := expectedCdc.MarshalJSON(stakingtypes.NewQueryDelegatorParams(proxyAccAddr))
+	require.NoError(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(1024)).
+		Return(expectedRet, int64(1024), nil)
+	result, err := mockCli.Staking().QueryProxyDelegators(proxyAddr, 1024)
+	require.NoError(t, err)
+	require.Equal(t, proxyAccAddr, result.ProxyAddress)
+	require.Equal(t, []sdk.AccAddress{delAddr}, result.Delegators)
+
+	_, err = mockCli.Staking().QueryProxyDelegators(proxyAddr[1:], 0)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Staking().QueryProxyDelegators(proxyAddr, 0)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Staking().QueryProxyDelegators(proxyAddr, 0)
+	require.Error(t, err)
+}
+
+func TestStakingClient_QueryParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewStakingClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	expectedParams := stakingtypes.DefaultParams()
+	expectedRet := expectedCdc.MustMarshalJSON(expectedParams)
+	expectedPath := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryParameters)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(expectedRet, int64(1024), nil)
+	stakingParams, err := mockCli.Staking().QueryParams(0)
+	require.NoError(t, err)
+	require.Equal(t, expectedParams.UnbondingTime, stakingParams.UnbondingTime)
+	require.Equal(t, expectedParams.MaxValidators, stakingParams.MaxValidators)
+	require.Equal(t, expectedParams.MinDelegation, stakingParams.MinDelegation)
+
+	_, err = mockCli.Staking().QueryParams(-1)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Staking().QueryParams(0)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Staking().QueryParams(0)
+	require.Error(t, err)
+}
+
+func TestStakingClient_QueryPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewStakingClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	expectedRet := expectedCdc.MustMarshalJSON(stakingtypes.NewPool(sdk.NewDecWithPrec(1024, 2),
+		sdk.NewDecWithPrec(2048, 2)))
+	expectedPath := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryPool)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(1024)).Return(expectedRet, int64(1024), nil)
+	pool, err := mockCli.Staking().QueryPool(1024)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(1024, 2), pool.NotBondedTokens)
+	require.Equal(t, sdk.NewDecWithPrec(2048, 2), pool.BondedTokens)
+
+	_, err = mockCli.Staking().QueryPool(-1)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Staking().QueryPool(0)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Staking().QueryPool(0)
+	require.Error(t, err)
+}
+
+func TestStakingClient_QueryUnbonding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewStakingClient(mockCli.MockBaseClient))
+
+	delAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	quantity, err := sdk.NewDecFromStr("40.96")
+	require.NoError(t, err)
+	completionTime := time.Now()
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	expectedRet := mockCli.BuildUndelegationBytes(delAddr, quantity, completionTime)
+	expectedPath := fmt.Sprintf("custom/%s/%s", stakingtypes.QuerierRoute, stakingtypes.QueryUnbondingDelegation)
+	expectedParams, err := expectedCdc.MarshalJSON(stakingtypes.NewQueryDelegatorParams(delAddr))
+	require.NoError(t, err)
+
+	// hex address is accepted
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(expectedRet, int64(1024), nil)
+	undelegation, err := mockCli.Staking().QueryUnbonding("0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991", 0)
+	require.NoError(t, err)
+	require.Equal(t, delAddr, undelegation.DelegatorAddress)
+	require.Equal(t, quantity, undelegation.Quantity)
+	require.True(t, completionTime.Equal(undelegation.CompletionTime))
+
+	_, err = mockCli.Staking().QueryUnbonding(addr[1:], 0)
+	require.Error(t, err)
+
+	_, err = mockCli.Staking().QueryUnbonding(addr, -1)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(expectedRet[1:], int64(1024), nil)
+	_, err = mockCli.Staking().QueryUnbonding(addr, 0)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, tmbytes.HexBytes(expectedParams), int64(0)).
+		Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Staking().QueryUnbonding(addr, 0)
+	require.Error(t, err)
+}
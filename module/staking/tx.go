@@ -18,7 +18,7 @@ func (sc stakingClient) Deposit(fromInfo keys.Info, passWd, coinsStr, memo strin
 		return
 	}
 
-	coin, err := sdk.ParseDecCoin(coinsStr)
+	coin, err := utils.ParseDecCoin(coinsStr)
 	if err != nil {
 		return resp, fmt.Errorf("failed : parse Coins [%s] error: %s", coinsStr, err)
 	}
@@ -34,7 +34,7 @@ func (sc stakingClient) Withdraw(fromInfo keys.Info, passWd, coinsStr, memo stri
 		return
 	}
 
-	coin, err := sdk.ParseDecCoin(coinsStr)
+	coin, err := utils.ParseDecCoin(coinsStr)
 	if err != nil {
 		return resp, fmt.Errorf("failed : parse Coins [%s] error: %s", coinsStr, err)
 	}
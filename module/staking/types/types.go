@@ -1,6 +1,8 @@
 package types
 
 import (
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	stakingcli "github.com/okx/okbchain/x/staking/client/cli"
 	stakingtypes "github.com/okx/okbchain/x/staking/types"
 )
@@ -8,9 +10,36 @@ import (
 // const
 const (
 	ModuleName = stakingtypes.ModuleName
+
+	// ValidatorStatusAll is the status to query the validators regardless of the bond status
+	ValidatorStatusAll = "all"
 )
 
 type (
 	Validator         = stakingtypes.Validator
 	DelegatorResponse = stakingcli.DelegatorResponse
+	SharesResponse    = stakingtypes.SharesResponse
+	Params            = stakingtypes.Params
+	Pool              = stakingtypes.Pool
+	UndelegationInfo  = stakingtypes.UndelegationInfo
 )
+
+// ValidatorsResult - structure of a page of the validators with a specific bond status
+type ValidatorsResult struct {
+	gosdktypes.PageInfo
+	Status     string      `json:"status"`
+	Validators []Validator `json:"validators"`
+}
+
+// ValidatorSharesResult - structure of the shares added to a validator by each delegator
+type ValidatorSharesResult struct {
+	ValidatorAddress sdk.ValAddress   `json:"validator_address"`
+	TotalShares      sdk.Dec          `json:"total_shares"`
+	Shares           []SharesResponse `json:"shares"`
+}
+
+// ProxyDelegatorsResult - structure of the delegators bound to a proxy
+type ProxyDelegatorsResult struct {
+	ProxyAddress sdk.AccAddress   `json:"proxy_address"`
+	Delegators   []sdk.AccAddress `json:"delegators"`
+}
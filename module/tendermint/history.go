@@ -0,0 +1,308 @@
+package tendermint
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain-go-sdk/module/tendermint/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/bank"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/supply"
+	ibctypes "github.com/okx/okbchain/libs/ibc-go/modules/apps/transfer/types"
+	tmtypes "github.com/okx/okbchain/libs/tendermint/types"
+	evmtypes "github.com/okx/okbchain/x/evm/types"
+)
+
+const (
+	historySearchPerPage = 100
+	historyOrderBy       = "desc"
+)
+
+// QueryHistory gets the txs sent or received by an address between two heights, newest first
+// Note: addrStr is accepted in both bech32 and hex format, and toHeight 0 stands for the latest height.
+// The txs are searched by message.sender, transfer.recipient, ethereum.recipient and fungible_token_packet.receiver,
+// so that the cosmos, evm and ibc transfers are merged and de-duplicated by tx position. Only the txs up to the end of
+// the page are downloaded, newest first
+func (tc tendermintClient) QueryHistory(addrStr string, fromHeight, toHeight int64, page, limit int) (
+	history types.TxHistory, err error) {
+	accAddr, err := utils.ToCosmosAddress(addrStr)
+	if err != nil {
+		return history, fmt.Errorf("failed. parse Address [%s] error: %s", addrStr, err)
+	}
+
+	if err = checkHistoryParams(fromHeight, toHeight, page, limit); err != nil {
+		return
+	}
+
+	bech32Addr, ethAddr := accAddr.String(), ethcmn.BytesToAddress(accAddr).Hex()
+	heightCond := fmt.Sprintf("%s>=%d", tmtypes.TxHeightKey, fromHeight)
+	if toHeight > 0 {
+		heightCond = fmt.Sprintf("%s AND %s<=%d", heightCond, tmtypes.TxHeightKey, toHeight)
+	}
+
+	queries := []string{
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeySender, bech32Addr),
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeySender, ethAddr),
+		fmt.Sprintf("%s.%s='%s'", bank.EventTypeTransfer, bank.AttributeKeyRecipient, bech32Addr),
+		fmt.Sprintf("%s.%s='%s'", evmtypes.EventTypeEthereumTx, evmtypes.AttributeKeyRecipient, ethAddr),
+		fmt.Sprintf("%s.%s='%s'", ibctypes.EventTypePacket, ibctypes.AttributeKeyReceiver, bech32Addr),
+	}
+
+	// one more tx than the page tells whether there's a next page
+	end := page * limit
+	perPage := end + 1
+	if perPage > historySearchPerPage {
+		perPage = historySearchPerPage
+	}
+	searches := make([]*historySearch, len(queries))
+	for i, query := range queries {
+		searches[i] = &historySearch{query: fmt.Sprintf("%s AND %s", query, heightCond), perPage: perPage}
+	}
+
+	txs, err := tc.mergeHistorySearches(searches, end+1)
+	if err != nil {
+		return
+	}
+
+	history = types.TxHistory{
+		Address:    bech32Addr,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		HasMore:    len(txs) > end,
+		Page:       page,
+		Limit:      limit,
+	}
+
+	start := (page - 1) * limit
+	if start >= len(txs) {
+		return
+	}
+	if end > len(txs) {
+		end = len(txs)
+	}
+
+	blockTimes := make(map[int64]time.Time)
+	for _, tx := range txs[start:end] {
+		blockTime, ok := blockTimes[tx.Height]
+		if !ok {
+			height := tx.Height
+			pResultBlock, err := tc.Block(&height)
+			if err != nil {
+				return history, fmt.Errorf("failed. query block %d error: %s", height, err)
+			}
+			blockTime = pResultBlock.Block.Time
+			blockTimes[tx.Height] = blockTime
+		}
+
+		entry, err := newHistoryEntry(tx, accAddr)
+		if err != nil {
+			return history, err
+		}
+		entry.Timestamp = blockTime
+		history.Entries = append(history.Entries, entry)
+	}
+
+	return
+}
+
+// historySearch pages through the txs matching a query, newest first
+type historySearch struct {
+	query   string
+	perPage int
+	page    int
+	total   int
+	txs     []*types.ResultTx
+}
+
+// mergeHistorySearches merges the txs of the searches newest first until there are max txs
+// Note: a tx found by several searches is taken once, and so is a tx shifted into the next page by the new blocks
+func (tc tendermintClient) mergeHistorySearches(searches []*historySearch, max int) (txs []*types.ResultTx, err error) {
+	for len(txs) < max {
+		var last, newest *types.ResultTx
+		if len(txs) != 0 {
+			last = txs[len(txs)-1]
+		}
+		for _, search := range searches {
+			tx, err := tc.peekHistorySearch(search, last)
+			if err != nil {
+				return nil, err
+			}
+			if tx != nil && (newest == nil || isNewerTx(tx, newest)) {
+				newest = tx
+			}
+		}
+		if newest == nil {
+			return
+		}
+
+		txs = append(txs, newest)
+	}
+
+	return
+}
+
+// peekHistorySearch gets the newest tx left in the search older than the last one merged, which is nil if the search
+// is done
+func (tc tendermintClient) peekHistorySearch(search *historySearch, last *types.ResultTx) (*types.ResultTx, error) {
+	for {
+		if len(search.txs) == 0 {
+			if search.page != 0 && search.page*search.perPage >= search.total {
+				return nil, nil
+			}
+
+			search.page++
+			pResultTxSearch, err := tc.TxSearch(search.query, false, search.page, search.perPage, historyOrderBy)
+			if err != nil {
+				return nil, fmt.Errorf("failed. search txs by [%s] error: %s", search.query, err)
+			}
+			if len(pResultTxSearch.Txs) == 0 {
+				return nil, nil
+			}
+			search.txs, search.total = pResultTxSearch.Txs, pResultTxSearch.TotalCount
+		}
+
+		if last == nil || isNewerTx(last, search.txs[0]) {
+			return search.txs[0], nil
+		}
+		search.txs = search.txs[1:]
+	}
+}
+
+func isNewerTx(tx, other *types.ResultTx) bool {
+	if tx.Height != other.Height {
+		return tx.Height > other.Height
+	}
+
+	return tx.Index > other.Index
+}
+
+func newHistoryEntry(tx *types.ResultTx, accAddr sdk.AccAddress) (entry types.HistoryEntry, err error) {
+	entry = types.HistoryEntry{
+		TxHash:   tx.Hash.String(),
+		Height:   tx.Height,
+		Index:    tx.Index,
+		Code:     tx.TxResult.Code,
+		Kind:     types.TxKindCosmos,
+		Sent:     sdk.SysCoins{},
+		Received: sdk.SysCoins{},
+	}
+
+	events := utils.GetEventsFromResultTx(tx)
+	var isSender bool
+	for _, attrs := range utils.ParseEventAttributes(events, sdk.EventTypeMessage) {
+		sender := attrs[sdk.AttributeKeySender]
+		if sender == accAddr.String() || strings.EqualFold(sender, ethcmn.BytesToAddress(accAddr).Hex()) {
+			isSender = true
+		}
+	}
+
+	switch {
+	case len(utils.ParseEventAttributes(events, ibctypes.EventTypePacket)) != 0,
+		len(utils.ParseEventAttributes(events, ibctypes.EventTypeTransfer)) != 0:
+		entry.Kind = types.TxKindIbc
+		err = fillTransferAmounts(&entry, events, accAddr)
+	case len(utils.ParseEventAttributes(events, evmtypes.EventTypeEthereumTx)) != 0:
+		entry.Kind = types.TxKindEvm
+		err = fillEthereumTxAmounts(&entry, events, accAddr, isSender)
+	default:
+		err = fillTransferAmounts(&entry, events, accAddr)
+	}
+	if err != nil {
+		return
+	}
+
+	switch {
+	case !entry.Sent.IsZero() && !entry.Received.IsZero():
+		entry.Direction = types.DirectionSelf
+	case !entry.Received.IsZero():
+		entry.Direction = types.DirectionReceive
+	case !entry.Sent.IsZero() || isSender:
+		entry.Direction = types.DirectionSend
+	default:
+		entry.Direction = types.DirectionNone
+	}
+
+	return
+}
+
+func fillTransferAmounts(entry *types.HistoryEntry, events sdk.StringEvents, accAddr sdk.AccAddress) error {
+	feeCollector := supply.NewModuleAddress(auth.FeeCollectorName).String()
+	for _, attrs := range utils.ParseEventAttributes(events, bank.EventTypeTransfer) {
+		recipient := attrs[bank.AttributeKeyRecipient]
+		if recipient == feeCollector {
+			continue
+		}
+
+		amount, err := utils.ParseEventCoins(attrs[sdk.AttributeKeyAmount])
+		if err != nil {
+			return err
+		}
+
+		if attrs[bank.AttributeKeySender] == accAddr.String() {
+			entry.Sent = entry.Sent.Add(amount...)
+		}
+		if recipient == accAddr.String() {
+			entry.Received = entry.Received.Add(amount...)
+		}
+	}
+
+	return nil
+}
+
+func fillEthereumTxAmounts(entry *types.HistoryEntry, events sdk.StringEvents, accAddr sdk.AccAddress,
+	isSender bool) error {
+	ethAddr := ethcmn.BytesToAddress(accAddr).Hex()
+	for _, attrs := range utils.ParseEventAttributes(events, evmtypes.EventTypeEthereumTx) {
+		amountStr, ok := attrs[sdk.AttributeKeyAmount]
+		if !ok {
+			continue
+		}
+
+		amountWei, ok := new(big.Int).SetString(amountStr, 10)
+		if !ok {
+			return errors.New("failed. parse amount of ethereum event")
+		}
+		if amountWei.Sign() == 0 {
+			continue
+		}
+
+		amount, err := utils.BaseToDecCoin(amountWei, utils.DenomWei)
+		if err != nil {
+			return err
+		}
+		if isSender {
+			entry.Sent = entry.Sent.Add(amount)
+		}
+		if strings.EqualFold(attrs[evmtypes.AttributeKeyRecipient], ethAddr) {
+			entry.Received = entry.Received.Add(amount)
+		}
+	}
+
+	return nil
+}
+
+func checkHistoryParams(fromHeight, toHeight int64, page, limit int) error {
+	if fromHeight < 0 || toHeight < 0 {
+		return errors.New("failed. negative height")
+	}
+
+	if toHeight > 0 && fromHeight > toHeight {
+		return fmt.Errorf("failed. fromHeight %d is greater than toHeight %d", fromHeight, toHeight)
+	}
+
+	if page <= 0 {
+		return errors.New("page must greater than 0")
+	}
+
+	if limit <= 0 {
+		return errors.New("limit must greater than 0")
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package tendermint
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/tendermint/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/auth"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/supply"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	tmbytes "github.com/okx/okbchain/libs/tendermint/libs/bytes"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	"github.com/okx/okbchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	recAddr    = "ex1qwuag8gx408m9ej038vzx50ntt0x4yrq38yf06"
+	addrHex    = "0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991"
+	recAddrHex = "0x03B9D41d06abCFb2e64F89D82351F35aDE6a9060"
+)
+
+func newTestEvent(eventType string, kvs ...string) abci.Event {
+	event := abci.Event{Type: eventType}
+	for i := 0; i < len(kvs); i += 2 {
+		event.Attributes = append(event.Attributes, kv.Pair{Key: []byte(kvs[i]), Value: []byte(kvs[i+1])})
+	}
+	return event
+}
+
+func newTestResultTx(hash string, height int64, index uint32, events ...abci.Event) *ctypes.ResultTx {
+	return &ctypes.ResultTx{
+		Hash:     tmbytes.HexBytes(hash),
+		Height:   height,
+		Index:    index,
+		TxResult: abci.ResponseDeliverTx{Events: events},
+	}
+}
+
+func TestTendermintClient_QueryHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTendermintClient(mockCli.MockBaseClient))
+
+	feeCollector := supply.NewModuleAddress(auth.FeeCollectorName).String()
+	sendTx := newTestResultTx("send", 10, 0,
+		newTestEvent("transfer", "recipient", feeCollector, "sender", addr, "amount", "0.1okt"),
+		newTestEvent("message", "sender", addr),
+		newTestEvent("transfer", "recipient", recAddr, "sender", addr, "amount", "1okt"),
+	)
+	receiveTx := newTestResultTx("receive", 20, 1,
+		newTestEvent("message", "sender", recAddr),
+		newTestEvent("transfer", "recipient", addr, "sender", recAddr, "amount", "2okt"),
+	)
+	evmTx := newTestResultTx("evm", 20, 0,
+		newTestEvent("message", "sender", recAddrHex),
+		newTestEvent("ethereum", "amount", "3000000000000000000", "recipient", addrHex),
+	)
+
+	// only the txs up to the end of the page and one more are searched, newest first
+	searchTxs := func(query string, prove bool, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+		require.True(t, strings.HasSuffix(query, "AND tx.height>=1 AND tx.height<=100"))
+		switch {
+		case strings.HasPrefix(query, "message.sender='"+addr):
+			return &ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{sendTx}, TotalCount: 1}, nil
+		case strings.HasPrefix(query, "transfer.recipient"):
+			// the send tx pays the fee collector so it's found again by recipient
+			return &ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{receiveTx, sendTx}, TotalCount: 2}, nil
+		case strings.HasPrefix(query, "ethereum.recipient='"+addrHex):
+			return &ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{evmTx}, TotalCount: 1}, nil
+		default:
+			return &ctypes.ResultTxSearch{}, nil
+		}
+	}
+	mockCli.EXPECT().TxSearch(gomock.Any(), false, 1, 3, historyOrderBy).DoAndReturn(searchTxs).Times(5)
+
+	blockTime := time.Now()
+	height := int64(20)
+	mockCli.EXPECT().Block(gomock.AssignableToTypeOf(&height)).
+		Return(mockCli.GetRawResultBlockPointer("default chainID", height, blockTime, nil, nil), nil)
+
+	history, err := mockCli.Tendermint().QueryHistory(addrHex, 1, 100, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, addr, history.Address)
+	require.True(t, history.HasMore)
+	require.Equal(t, 2, len(history.Entries))
+
+	require.Equal(t, receiveTx.Hash.String(), history.Entries[0].TxHash)
+	require.Equal(t, types.TxKindCosmos, history.Entries[0].Kind)
+	require.Equal(t, types.DirectionReceive, history.Entries[0].Direction)
+	require.Equal(t, "2.000000000000000000okt", history.Entries[0].Received.String())
+	require.True(t, history.Entries[0].Sent.IsZero())
+	require.True(t, blockTime.Equal(history.Entries[0].Timestamp))
+
+	require.Equal(t, evmTx.Hash.String(), history.Entries[1].TxHash)
+	require.Equal(t, types.TxKindEvm, history.Entries[1].Kind)
+	require.Equal(t, types.DirectionReceive, history.Entries[1].Direction)
+	require.Equal(t, "3.000000000000000000"+common.NativeToken, history.Entries[1].Received.String())
+
+	// the last page
+	mockCli.EXPECT().TxSearch(gomock.Any(), false, 1, 5, historyOrderBy).DoAndReturn(searchTxs).Times(5)
+	mockCli.EXPECT().Block(gomock.AssignableToTypeOf(&height)).
+		Return(mockCli.GetRawResultBlockPointer("default chainID", 10, blockTime, nil, nil), nil)
+	history, err = mockCli.Tendermint().QueryHistory(addr, 1, 100, 2, 2)
+	require.NoError(t, err)
+	require.False(t, history.HasMore)
+	require.Equal(t, 1, len(history.Entries))
+	require.Equal(t, sendTx.Hash.String(), history.Entries[0].TxHash)
+
+	// the fee isn't counted into the sent coins
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	entry, err := newHistoryEntry(sendTx, accAddr)
+	require.NoError(t, err)
+	require.Equal(t, types.DirectionSend, entry.Direction)
+	require.Equal(t, "1.000000000000000000okt", entry.Sent.String())
+
+	mockCli.EXPECT().TxSearch(gomock.Any(), false, 1, 11, historyOrderBy).
+		Return(nil, errors.New("default error"))
+	_, err = mockCli.Tendermint().QueryHistory(addr, 0, 0, 1, 10)
+	require.Error(t, err)
+
+	_, err = mockCli.Tendermint().QueryHistory("invalid address", 0, 0, 1, 10)
+	require.Error(t, err)
+
+	_, err = mockCli.Tendermint().QueryHistory(addr, 100, 1, 1, 10)
+	require.Error(t, err)
+
+	_, err = mockCli.Tendermint().QueryHistory(addr, 0, 0, 0, 10)
+	require.Error(t, err)
+
+	_, err = mockCli.Tendermint().QueryHistory(addr, 0, 0, 1, 0)
+	require.Error(t, err)
+}
+
+func TestTendermintClient_mergeHistorySearches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	tc := NewTendermintClient(mockCli.MockBaseClient).(tendermintClient)
+
+	txA, txB, txC := newTestResultTx("a", 30, 0), newTestResultTx("b", 20, 0), newTestResultTx("c", 10, 0)
+	// txA committed after the first page shifts txB into the second page of query a
+	mockCli.EXPECT().TxSearch("a", false, 1, 1, historyOrderBy).
+		Return(&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{txB}, TotalCount: 2}, nil)
+	mockCli.EXPECT().TxSearch("a", false, 2, 1, historyOrderBy).
+		Return(&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{txB}, TotalCount: 3}, nil)
+	mockCli.EXPECT().TxSearch("a", false, 3, 1, historyOrderBy).
+		Return(&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{txC}, TotalCount: 3}, nil)
+	mockCli.EXPECT().TxSearch("b", false, 1, 1, historyOrderBy).
+		Return(&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{txA}, TotalCount: 2}, nil)
+	mockCli.EXPECT().TxSearch("b", false, 2, 1, historyOrderBy).
+		Return(&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{txC}, TotalCount: 2}, nil)
+
+	searches := []*historySearch{{query: "a", perPage: 1}, {query: "b", perPage: 1}}
+	txs, err := tc.mergeHistorySearches(searches, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*ctypes.ResultTx{txA, txB, txC}, txs)
+}
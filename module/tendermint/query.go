@@ -77,6 +77,26 @@ func (tc tendermintClient) QueryValidatorsResult(height int64) (pValsResult *typ
 	return tc.Validators(pHeight, 1, 0)
 }
 
+// QueryValidatorsResultByPage gets a page of the validators info on a specific height
+// query the latest block with height 0 input
+// Note: the node caps the limit at 100, and the total of the validator set is carried in the result
+func (tc tendermintClient) QueryValidatorsResultByPage(height int64, page, limit int) (
+	pValsResult *types.ResultValidators, err error) {
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return pValsResult, err
+	}
+	if page <= 0 || limit <= 0 {
+		return pValsResult, errors.New("failed. page and limit must be positive")
+	}
+
+	var pHeight *int64
+	if height > 0 {
+		pHeight = &height
+	}
+
+	return tc.Validators(pHeight, page, limit)
+}
+
 // QueryTxResult gets the detail info of a tx with its tx hash
 func (tc tendermintClient) QueryTxResult(hashHexStr string, prove bool) (pResultTx *types.ResultTx, err error) {
 	hash, err := hex.DecodeString(hashHexStr)
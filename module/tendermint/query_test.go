@@ -164,6 +164,42 @@ func TestTendermintClient_QueryValidatorsResult(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTendermintClient_QueryValidatorsResultByPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "",
+		200000, 1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTendermintClient(mockCli.MockBaseClient))
+
+	height, votingPower, proposerPriority := int64(1024), int64(2048), int64(-1024)
+	exchain.SetBech32Prefixes(sdk.GetConfig())
+	consPubkey, err := stakingtypes.GetConsPubKeyBech32(valConsPK)
+	require.NoError(t, err)
+
+	expectedRet := mockCli.GetRawValidatorsResultPointer(height, votingPower, proposerPriority, consPubkey)
+	mockCli.EXPECT().Validators(gomock.AssignableToTypeOf(&height), 2, 100).Return(expectedRet, nil)
+
+	valsResult, err := mockCli.Tendermint().QueryValidatorsResultByPage(height, 2, 100)
+	require.NoError(t, err)
+	require.Equal(t, height, valsResult.BlockHeight)
+	require.Equal(t, consPubkey, valsResult.Validators[0].PubKey)
+
+	mockCli.EXPECT().Validators(gomock.AssignableToTypeOf(&height), 1, 100).Return(nil, errors.New("default error"))
+	_, err = mockCli.Tendermint().QueryValidatorsResultByPage(height, 1, 100)
+	require.Error(t, err)
+
+	_, err = mockCli.Tendermint().QueryValidatorsResultByPage(-1, 1, 100)
+	require.Error(t, err)
+
+	_, err = mockCli.Tendermint().QueryValidatorsResultByPage(height, 0, 100)
+	require.Error(t, err)
+
+	_, err = mockCli.Tendermint().QueryValidatorsResultByPage(height, 1, 0)
+	require.Error(t, err)
+}
+
 func TestTendermintClient_QueryTxResult(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,197 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// status of a tx tracked
+const (
+	// StatusSending means that the tx is bound to a sequence and being broadcast, its tx hash is unknown yet
+	StatusSending = "sending"
+	// StatusBroadcast means that the tx is accepted by the node but not confirmed yet
+	StatusBroadcast = "broadcast"
+	// StatusConfirmed means that the tx is committed in a block successfully
+	StatusConfirmed = "confirmed"
+	// StatusFailed means that the tx is committed in a block with a non-zero code
+	StatusFailed = "failed"
+)
+
+const historyPageLimit = 100
+
+// ErrTxNotFound means that the sequence of a record has been consumed but none of the txs sent after its broadcast
+// height matches it
+var ErrTxNotFound = errors.New("failed. sequence consumed but no tx matched is found")
+
+// Client shows the expected behavior of the client that the tracker works with
+type Client interface {
+	Tendermint() exposed.Tendermint
+}
+
+// Record - structure of a tx bound to a sequence, which is persisted by the routines to resume after a crash
+type Record struct {
+	Sequence        uint64 `json:"sequence"`
+	Status          string `json:"status"`
+	TxHash          string `json:"tx_hash,omitempty"`
+	Height          int64  `json:"height,omitempty"`
+	BroadcastHeight int64  `json:"broadcast_height,omitempty"`
+	Log             string `json:"log,omitempty"`
+}
+
+// InFlight shows whether the tx is bound to a sequence while its result is unknown
+func (r *Record) InFlight() bool {
+	return r != nil && (r.Status == StatusSending || r.Status == StatusBroadcast)
+}
+
+// ApplyResponse applies the response of the broadcast to the record, and returns the error of the broadcast if the tx
+// isn't committed
+// Note: the tx is committed already in the block broadcast mode, so its result is applied at once
+func (r *Record) ApplyResponse(resp sdk.TxResponse, sendErr error) error {
+	if len(resp.TxHash) != 0 {
+		r.TxHash, r.Status = resp.TxHash, StatusBroadcast
+	}
+
+	if resp.Height > 0 {
+		r.Height = resp.Height
+		if resp.Code == 0 {
+			r.Status, r.Log = StatusConfirmed, ""
+		} else {
+			r.Status, r.Log = StatusFailed, resp.RawLog
+		}
+		return nil
+	}
+
+	if sendErr == nil && resp.Code != 0 {
+		sendErr = errors.New(resp.RawLog)
+	}
+
+	return sendErr
+}
+
+// ApplyResult applies the result of the tx committed to the record
+func (r *Record) ApplyResult(pResultTx *tmtypes.ResultTx) {
+	r.TxHash, r.Height = pResultTx.Hash.String(), pResultTx.Height
+	if pResultTx.TxResult.Code == 0 {
+		r.Status, r.Log = StatusConfirmed, ""
+	} else {
+		r.Status, r.Log = StatusFailed, pResultTx.TxResult.Log
+	}
+}
+
+// Matcher shows whether a successful tx sent by the sender is the one of a record
+type Matcher func(pResultTx *tmtypes.ResultTx) (bool, error)
+
+// Tracker - structure of the helper that sends, resolves and confirms the txs of a sender by their sequences
+// Note: the sender account is supposed to be dedicated to the routine, because a tx is resolved by the account
+// sequence after a crash
+type Tracker struct {
+	cli            Client
+	addrStr        string
+	confirmTimeout time.Duration
+	pollInterval   time.Duration
+}
+
+// NewTracker creates a new instance of Tracker for the txs of the sender
+func NewTracker(cli Client, addrStr string, confirmTimeout, pollInterval time.Duration) *Tracker {
+	return &Tracker{
+		cli:            cli,
+		addrStr:        addrStr,
+		confirmTimeout: confirmTimeout,
+		pollInterval:   pollInterval,
+	}
+}
+
+// Bind binds the record to the sequence with the latest height as its broadcast height, which is supposed to be
+// persisted before the broadcast
+func (t *Tracker) Bind(record *Record, seq uint64) error {
+	status, err := t.cli.Tendermint().QueryStatus()
+	if err != nil {
+		return fmt.Errorf("failed. query status error: %s", err)
+	}
+
+	record.Sequence, record.Status, record.TxHash, record.Height, record.Log = seq, StatusSending, "", 0, ""
+	record.BroadcastHeight = status.SyncInfo.LatestBlockHeight
+	return nil
+}
+
+// Resolve finds out the result of the record in flight with the current sequence of the sender. It returns nil
+// without any error if the tx isn't committed, so that it's safe to be sent again with the same sequence
+// Note: once the sequence has been consumed, the tx is looked up by the matcher in the history after the broadcast
+// height, and ErrTxNotFound is returned if none matches
+func (t *Tracker) Resolve(record *Record, seq uint64, match Matcher) (*tmtypes.ResultTx, error) {
+	if len(record.TxHash) != 0 {
+		if pResultTx, err := t.cli.Tendermint().QueryTxResult(record.TxHash, false); err == nil && pResultTx != nil {
+			record.ApplyResult(pResultTx)
+			return pResultTx, nil
+		}
+	}
+
+	if seq <= record.Sequence {
+		return nil, nil
+	}
+
+	pResultTx, err := t.findTx(record, match)
+	if err != nil {
+		return nil, err
+	}
+
+	record.ApplyResult(pResultTx)
+	return pResultTx, nil
+}
+
+// WaitConfirm polls the tx of the record until it's committed, and returns nil once timeout
+func (t *Tracker) WaitConfirm(record *Record) *tmtypes.ResultTx {
+	deadline := time.Now().Add(t.confirmTimeout)
+	for {
+		pResultTx, err := t.cli.Tendermint().QueryTxResult(record.TxHash, false)
+		if err == nil && pResultTx != nil {
+			record.ApplyResult(pResultTx)
+			return pResultTx
+		}
+
+		if time.Now().After(deadline) {
+			return nil
+		}
+		time.Sleep(t.pollInterval)
+	}
+}
+
+// findTx looks for the successful tx matched in the txs sent after the broadcast height of the record
+// Note: the tx is never committed in the block of the broadcast height, which is the latest one before the broadcast
+func (t *Tracker) findTx(record *Record, match Matcher) (*tmtypes.ResultTx, error) {
+	for page := 1; ; page++ {
+		history, err := t.cli.Tendermint().QueryHistory(t.addrStr, record.BroadcastHeight+1, 0, page,
+			historyPageLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range history.Entries {
+			if entry.Code != 0 || entry.Kind != tmtypes.TxKindCosmos || entry.Direction == tmtypes.DirectionReceive {
+				continue
+			}
+
+			pResultTx, err := t.cli.Tendermint().QueryTxResult(entry.TxHash, false)
+			if err != nil {
+				return nil, err
+			}
+
+			matched, err := match(pResultTx)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				return pResultTx, nil
+			}
+		}
+
+		if !history.HasMore {
+			return nil, ErrTxNotFound
+		}
+	}
+}
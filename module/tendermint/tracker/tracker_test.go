@@ -0,0 +1,136 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/mocks"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	addr       = "ex1qj5c07sm6jetjz8f509qtrxgh4psxkv3ddyq7u"
+	eventMatch = "match"
+)
+
+func matchEvent(pResultTx *tmtypes.ResultTx) (bool, error) {
+	for _, event := range pResultTx.TxResult.Events {
+		if event.Type == eventMatch {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func newTestTracker() (*mocks.FakeChain, *Tracker) {
+	chain := mocks.NewFakeChain(100, 8)
+	return chain, NewTracker(chain, addr, time.Millisecond, time.Millisecond)
+}
+
+func TestRecord_ApplyResponse(t *testing.T) {
+	var record Record
+	require.Error(t, record.ApplyResponse(sdk.TxResponse{}, errors.New("mempool is full")))
+	require.Empty(t, record.Status)
+
+	require.Error(t, record.ApplyResponse(sdk.TxResponse{TxHash: "tx", Code: 1, RawLog: "out of gas"}, nil))
+	require.Equal(t, StatusBroadcast, record.Status)
+	require.True(t, record.InFlight())
+
+	require.NoError(t, record.ApplyResponse(sdk.TxResponse{TxHash: "tx"}, nil))
+	require.Equal(t, StatusBroadcast, record.Status)
+
+	// block broadcast mode
+	require.NoError(t, record.ApplyResponse(sdk.TxResponse{TxHash: "tx", Height: 10, Code: 1, RawLog: "out of gas"},
+		nil))
+	require.Equal(t, StatusFailed, record.Status)
+	require.Equal(t, "out of gas", record.Log)
+	require.False(t, record.InFlight())
+
+	require.NoError(t, record.ApplyResponse(sdk.TxResponse{TxHash: "tx", Height: 11}, nil))
+	require.Equal(t, StatusConfirmed, record.Status)
+	require.Equal(t, int64(11), record.Height)
+	require.Empty(t, record.Log)
+
+	var nilRecord *Record
+	require.False(t, nilRecord.InFlight())
+}
+
+func TestTracker_WaitConfirm(t *testing.T) {
+	chain, tracker := newTestTracker()
+	var record Record
+	require.NoError(t, tracker.Bind(&record, chain.Sequence))
+	require.Equal(t, StatusSending, record.Status)
+	require.Equal(t, int64(100), record.BroadcastHeight)
+
+	// unconfirmed
+	chain.Pend = true
+	require.NoError(t, record.ApplyResponse(chain.Commit(record.Sequence)))
+	require.Nil(t, tracker.WaitConfirm(&record))
+	require.Equal(t, StatusBroadcast, record.Status)
+
+	// failed
+	chain.Fail = true
+	require.NoError(t, record.ApplyResponse(chain.Commit(record.Sequence)))
+	require.NotNil(t, tracker.WaitConfirm(&record))
+	require.Equal(t, StatusFailed, record.Status)
+	require.Equal(t, int64(101), record.Height)
+
+	require.NoError(t, tracker.Bind(&record, chain.Sequence))
+	require.Empty(t, record.TxHash)
+	require.Equal(t, int64(101), record.BroadcastHeight)
+	require.NoError(t, record.ApplyResponse(chain.Commit(record.Sequence)))
+	require.NotNil(t, tracker.WaitConfirm(&record))
+	require.Equal(t, StatusConfirmed, record.Status)
+	require.Equal(t, uint64(9), record.Sequence)
+}
+
+func TestTracker_Resolve(t *testing.T) {
+	chain, tracker := newTestTracker()
+
+	// crash before the broadcast, so it's safe to send again with the same sequence
+	var record Record
+	require.NoError(t, tracker.Bind(&record, chain.Sequence))
+	pResultTx, err := tracker.Resolve(&record, chain.Sequence, matchEvent)
+	require.NoError(t, err)
+	require.Nil(t, pResultTx)
+	require.Equal(t, StatusSending, record.Status)
+
+	// crash after the broadcast, resolved by the history
+	chain.Crash = true
+	require.Error(t, record.ApplyResponse(chain.Commit(record.Sequence, abci.Event{Type: eventMatch})))
+	require.Empty(t, record.TxHash)
+	pResultTx, err = tracker.Resolve(&record, chain.Sequence, matchEvent)
+	require.NoError(t, err)
+	require.Equal(t, chain.LastTx(), pResultTx)
+	require.Equal(t, StatusConfirmed, record.Status)
+	require.Equal(t, chain.LastTx().Hash.String(), record.TxHash)
+
+	// resolved by the tx hash
+	require.NoError(t, tracker.Bind(&record, chain.Sequence))
+	chain.Fail = true
+	require.NoError(t, record.ApplyResponse(chain.Commit(record.Sequence)))
+	pResultTx, err = tracker.Resolve(&record, chain.Sequence, matchEvent)
+	require.NoError(t, err)
+	require.NotNil(t, pResultTx)
+	require.Equal(t, StatusFailed, record.Status)
+
+	// the sequence is consumed by another tx
+	require.NoError(t, tracker.Bind(&record, chain.Sequence))
+	_, err = chain.Commit(record.Sequence)
+	require.NoError(t, err)
+	_, err = tracker.Resolve(&record, chain.Sequence, matchEvent)
+	require.Equal(t, ErrTxNotFound, err)
+	require.Equal(t, StatusSending, record.Status)
+
+	// the matcher fails
+	_, err = tracker.Resolve(&record, chain.Sequence, func(*tmtypes.ResultTx) (bool, error) {
+		return false, errors.New("default error")
+	})
+	require.Error(t, err)
+	require.NotEqual(t, ErrTxNotFound, err)
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// const
+const (
+	// kinds of the tx in history
+	TxKindCosmos = "cosmos"
+	TxKindEvm    = "evm"
+	TxKindIbc    = "ibc"
+
+	// directions of the tx in history from the view of the queried address
+	DirectionSend    = "send"
+	DirectionReceive = "receive"
+	DirectionSelf    = "self"
+	DirectionNone    = "none"
+)
+
+// HistoryEntry - structure of a tx sent or received by an address
+type HistoryEntry struct {
+	TxHash    string    `json:"tx_hash"`
+	Height    int64     `json:"height"`
+	Index     uint32    `json:"index"`
+	Timestamp time.Time `json:"timestamp"`
+	Code      uint32    `json:"code"`
+	Kind      string    `json:"kind"`
+	Direction string    `json:"direction"`
+	// Sent and Received are the coins moved out of and into the address in the tx, excluding the fee
+	Sent     sdk.SysCoins `json:"sent"`
+	Received sdk.SysCoins `json:"received"`
+}
+
+// TxHistory - structure of a page of the tx history of an address
+type TxHistory struct {
+	Address    string `json:"address"`
+	FromHeight int64  `json:"from_height"`
+	ToHeight   int64  `json:"to_height"`
+	// HasMore tells whether there are txs after the page
+	HasMore bool           `json:"has_more"`
+	Page    int            `json:"page"`
+	Limit   int            `json:"limit"`
+	Entries []HistoryEntry `json:"entries"`
+}
@@ -0,0 +1,52 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain-go-sdk/module/token/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/bank"
+)
+
+// ParseTransferEvents decodes all the transfer events
+func (tokenClient) ParseTransferEvents(events sdk.StringEvents) (transfers []types.TransferEvent, err error) {
+	for _, attrs := range utils.ParseEventAttributes(events, bank.EventTypeTransfer) {
+		var transfer types.TransferEvent
+		if senderStr, ok := attrs[bank.AttributeKeySender]; ok {
+			transfer.Sender, err = sdk.AccAddressFromBech32(senderStr)
+			if err != nil {
+				return nil, fmt.Errorf("failed. parse sender [%s] error: %s", senderStr, err)
+			}
+		}
+
+		recipientStr := attrs[bank.AttributeKeyRecipient]
+		transfer.Recipient, err = sdk.AccAddressFromBech32(recipientStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed. parse recipient [%s] error: %s", recipientStr, err)
+		}
+
+		transfer.Amount, err = utils.ParseEventCoins(attrs[sdk.AttributeKeyAmount])
+		if err != nil {
+			return
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	return
+}
+
+// GetTransfers gets the coins transferred in the tx by Send or MultiSend
+func (tc tokenClient) GetTransfers(resp sdk.TxResponse) ([]types.TransferEvent, error) {
+	transfers, err := tc.ParseTransferEvents(utils.GetEventsFromTxResponse(resp))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transfers) == 0 {
+		return nil, utils.ErrEventAttributeNotFound(bank.EventTypeTransfer, bank.AttributeKeyRecipient)
+	}
+
+	return transfers, nil
+}
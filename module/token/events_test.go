@@ -0,0 +1,60 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/exchain-go-sdk/mocks"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenClient_GetTransfers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+
+	resp := mocks.DefaultMockSuccessTxResponse()
+	resp.Logs = sdk.ABCIMessageLogs{{
+		Events: sdk.StringEvents{{
+			Type: "transfer",
+			Attributes: []sdk.Attribute{
+				{Key: "recipient", Value: recAddr},
+				{Key: "sender", Value: addr},
+				{Key: "amount", Value: "1.024000000000000000okt"},
+				{Key: "recipient", Value: addr},
+				{Key: "amount", Value: "2.048000000000000000okt"},
+			},
+		}},
+	}}
+
+	transfers, err := mockCli.Token().GetTransfers(resp)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transfers))
+	require.Equal(t, addr, transfers[0].Sender.String())
+	require.Equal(t, recAddr, transfers[0].Recipient.String())
+	require.Equal(t, "1.024000000000000000okt", transfers[0].Amount.String())
+	require.True(t, transfers[1].Sender.Empty())
+	require.Equal(t, addr, transfers[1].Recipient.String())
+	require.Equal(t, "2.048000000000000000okt", transfers[1].Amount.String())
+
+	// bad recipient
+	resp.Logs[0].Events[0].Attributes[0].Value = recAddr[1:]
+	_, err = mockCli.Token().GetTransfers(resp)
+	require.Error(t, err)
+
+	// bad amount
+	resp.Logs[0].Events[0].Attributes[0].Value = recAddr
+	resp.Logs[0].Events[0].Attributes[2].Value = "bad amount"
+	_, err = mockCli.Token().GetTransfers(resp)
+	require.Error(t, err)
+
+	// no transfer event
+	_, err = mockCli.Token().GetTransfers(mocks.DefaultMockSuccessTxResponse())
+	require.Error(t, err)
+}
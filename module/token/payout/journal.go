@@ -0,0 +1,129 @@
+package payout
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/okex/exchain-go-sdk/module/tendermint/tracker"
+	"github.com/okex/exchain-go-sdk/module/token/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// status of a chunk in the journal
+const (
+	// StatusPending means that the chunk has never been sent, or its tx failed and it's to be sent again
+	StatusPending = "pending"
+	// StatusSending means that the chunk is bound to a sequence and being broadcast, its tx hash is unknown yet
+	StatusSending = tracker.StatusSending
+	// StatusBroadcast means that the tx of the chunk is accepted by the node but not confirmed yet
+	StatusBroadcast = tracker.StatusBroadcast
+	// StatusConfirmed means that the tx of the chunk is committed in a block successfully
+	StatusConfirmed = tracker.StatusConfirmed
+	// StatusFailed means that the tx of the chunk is committed in a block with a non-zero code, so nothing is paid
+	StatusFailed = tracker.StatusFailed
+)
+
+// Chunk - structure of the transfers sent in one MultiSend tx
+type Chunk struct {
+	Index int    `json:"index"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Gas   uint64 `json:"gas"`
+	// Record of the MultiSend tx is bound to a sequence once the chunk is sent, and the sequence is never changed
+	// until the chunk is confirmed or failed
+	tracker.Record
+}
+
+// Journal - structure of the progress of a payout, which is persisted before and after each broadcast
+type Journal struct {
+	PayoutID      string  `json:"payout_id"`
+	From          string  `json:"from"`
+	AccountNumber uint64  `json:"account_number"`
+	Chunks        []Chunk `json:"chunks"`
+}
+
+// IsDone shows whether all the chunks of the payout are confirmed
+func (j Journal) IsDone() bool {
+	for _, chunk := range j.Chunks {
+		if chunk.Status != StatusConfirmed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CountByStatus counts the chunks with a specific status
+func (j Journal) CountByStatus(status string) (count int) {
+	for _, chunk := range j.Chunks {
+		if chunk.Status == status {
+			count++
+		}
+	}
+
+	return
+}
+
+// LoadJournal loads the journal from the file. The existence is false if the file doesn't exist
+func LoadJournal(filePath string) (journal Journal, exists bool, err error) {
+	bytes, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return journal, false, nil
+	}
+	if err != nil {
+		return journal, false, fmt.Errorf("failed. read journal error: %s", err)
+	}
+
+	if err = json.Unmarshal(bytes, &journal); err != nil {
+		return journal, true, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return journal, true, nil
+}
+
+// SaveJournal saves the journal to the file atomically, so that a crash never leaves a broken journal behind
+func SaveJournal(filePath string, journal Journal) error {
+	bytes, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return utils.ErrMarshalJSON(err.Error())
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed. create temp journal error: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.Write(bytes); err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed. write journal error: %s", err)
+	}
+
+	if err = os.Rename(tmpFile.Name(), filePath); err != nil {
+		return fmt.Errorf("failed. replace journal error: %s", err)
+	}
+
+	return nil
+}
+
+// GetPayoutID gets the identity of a payout by the sender and the transfers in order
+func GetPayoutID(from sdk.AccAddress, transfers []types.TransferUnit) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(from.String()))
+	for _, transfer := range transfers {
+		hasher.Write([]byte(fmt.Sprintf("\n%s %s", transfer.To, transfer.Coins)))
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
@@ -0,0 +1,295 @@
+package payout
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/module/tendermint/tracker"
+	tmtypes "github.com/okex/exchain-go-sdk/module/tendermint/types"
+	"github.com/okex/exchain-go-sdk/module/token/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+)
+
+// const
+const (
+	// DefaultBaseGas is the estimated gas of a MultiSend tx without any transfer
+	DefaultBaseGas = 60000
+	// DefaultGasPerCoin is the estimated gas of each coin transferred to a recipient
+	DefaultGasPerCoin = 30000
+	// DefaultConfirmTimeout is the default duration to wait for the confirmation of a chunk
+	DefaultConfirmTimeout = time.Minute
+	// DefaultPollInterval is the default interval to poll the tx of a chunk
+	DefaultPollInterval = 2 * time.Second
+)
+
+// Client shows the expected behavior of the client that the payout engine works with
+type Client interface {
+	GetConfig() gosdktypes.ClientConfig
+	Auth() exposed.Auth
+	Token() exposed.Token
+	Tendermint() exposed.Tendermint
+}
+
+// Options - structure of the options of the payout engine
+type Options struct {
+	// MaxGasPerTx bounds the estimated gas of each chunk. Defaults to the gas of the client config by 0
+	MaxGasPerTx uint64
+	// BaseGas and GasPerCoin estimate the gas of a chunk. Default to DefaultBaseGas and DefaultGasPerCoin by 0
+	BaseGas    uint64
+	GasPerCoin uint64
+	// MaxTransfersPerTx bounds the number of transfers in each chunk. No bound by 0
+	MaxTransfersPerTx int
+	ConfirmTimeout    time.Duration
+	PollInterval      time.Duration
+}
+
+// Engine - structure of the payout engine which splits a mass payout into MultiSend txs with a resumable journal
+// Note: the sender account is supposed to be dedicated to the payout, because the engine detects the tx of a chunk by
+// the account sequence after a crash
+type Engine struct {
+	cli         Client
+	fromInfo    keys.Info
+	passWd      string
+	memo        string
+	journalPath string
+	opts        Options
+}
+
+// NewEngine creates a new instance of Engine
+func NewEngine(cli Client, fromInfo keys.Info, passWd, memo, journalPath string, opts Options) *Engine {
+	if opts.MaxGasPerTx == 0 {
+		opts.MaxGasPerTx = cli.GetConfig().Gas
+	}
+	if opts.BaseGas == 0 {
+		opts.BaseGas = DefaultBaseGas
+	}
+	if opts.GasPerCoin == 0 {
+		opts.GasPerCoin = DefaultGasPerCoin
+	}
+	if opts.ConfirmTimeout <= 0 {
+		opts.ConfirmTimeout = DefaultConfirmTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	return &Engine{
+		cli:         cli,
+		fromInfo:    fromInfo,
+		passWd:      passWd,
+		memo:        memo,
+		journalPath: journalPath,
+		opts:        opts,
+	}
+}
+
+// Split splits the transfers into chunks in order, where the estimated gas of each chunk is bounded by the options
+func (e *Engine) Split(transfers []types.TransferUnit) (chunks []Chunk, err error) {
+	for start, end := 0, 0; start < len(transfers); start = end {
+		gas := e.opts.BaseGas
+		for end = start; end < len(transfers); end++ {
+			transferGas := e.opts.GasPerCoin * uint64(len(transfers[end].Coins))
+			if end > start && (gas+transferGas > e.opts.MaxGasPerTx ||
+				e.opts.MaxTransfersPerTx > 0 && end-start >= e.opts.MaxTransfersPerTx) {
+				break
+			}
+			gas += transferGas
+		}
+
+		if gas > e.opts.MaxGasPerTx {
+			return nil, fmt.Errorf("failed. estimated gas %d of the transfer to %s exceeds the max gas %d per tx",
+				gas, transfers[start].To, e.opts.MaxGasPerTx)
+		}
+
+		chunks = append(chunks, Chunk{
+			Index:  len(chunks),
+			Start:  start,
+			End:    end,
+			Gas:    gas,
+			Record: tracker.Record{Status: StatusPending},
+		})
+	}
+
+	return
+}
+
+// Run runs the payout to the end or until an error occurs, and it's safe to run again with the same transfers and
+// journal path to resume the payout
+// Note: the chunks in flight are resolved first and sent again with the same sequences if they haven't been committed,
+// so that a chunk is never paid twice. The failed chunks are sent again with new sequences in the next run
+func (e *Engine) Run(transfers []types.TransferUnit) (journal Journal, err error) {
+	if err = params.CheckTransferUnitsParams(e.fromInfo, e.passWd, transfers); err != nil {
+		return
+	}
+
+	if err = CheckTransfers(transfers); err != nil {
+		return
+	}
+
+	from := e.fromInfo.GetAddress()
+	payoutID := GetPayoutID(from, transfers)
+	journal, exists, err := LoadJournal(e.journalPath)
+	if err != nil {
+		return
+	}
+
+	if exists && journal.PayoutID != payoutID {
+		return journal, fmt.Errorf("failed. journal %s belongs to another payout %s", e.journalPath, journal.PayoutID)
+	}
+
+	if !exists {
+		journal = Journal{
+			PayoutID: payoutID,
+			From:     from.String(),
+		}
+		if journal.Chunks, err = e.Split(transfers); err != nil {
+			return
+		}
+	}
+
+	account, err := e.cli.Auth().QueryAccount(from.String())
+	if err != nil {
+		return
+	}
+
+	journal.AccountNumber = account.GetAccountNumber()
+	if err = SaveJournal(e.journalPath, journal); err != nil {
+		return
+	}
+
+	// resolve the chunks in flight by the last run
+	var inFlight []int
+	for i := range journal.Chunks {
+		if !journal.Chunks[i].InFlight() {
+			continue
+		}
+
+		if err = e.resolve(&journal.Chunks[i], transfers, account.GetSequence()); err != nil {
+			return
+		}
+		if err = SaveJournal(e.journalPath, journal); err != nil {
+			return
+		}
+
+		if journal.Chunks[i].InFlight() {
+			inFlight = append(inFlight, i)
+		}
+	}
+
+	// send the chunks in flight again with their own sequences, and then the rest with the following sequences
+	sort.Slice(inFlight, func(i, j int) bool {
+		return journal.Chunks[inFlight[i]].Sequence < journal.Chunks[inFlight[j]].Sequence
+	})
+
+	nextSeq := account.GetSequence()
+	for _, i := range inFlight {
+		if journal.Chunks[i].Sequence != nextSeq {
+			return journal, fmt.Errorf("failed. chunk %d is bound to sequence %d while the next sequence is %d",
+				i, journal.Chunks[i].Sequence, nextSeq)
+		}
+
+		if err = e.send(&journal, i, nextSeq, transfers); err != nil {
+			return
+		}
+		nextSeq++
+	}
+
+	for i := range journal.Chunks {
+		if status := journal.Chunks[i].Status; status != StatusPending && status != StatusFailed {
+			continue
+		}
+
+		if err = e.send(&journal, i, nextSeq, transfers); err != nil {
+			return
+		}
+		nextSeq++
+	}
+
+	// track the confirmations
+	for i := range journal.Chunks {
+		if journal.Chunks[i].Status != StatusBroadcast {
+			continue
+		}
+
+		e.newTracker().WaitConfirm(&journal.Chunks[i].Record)
+		if err = SaveJournal(e.journalPath, journal); err != nil {
+			return
+		}
+	}
+
+	if !journal.IsDone() {
+		return journal, fmt.Errorf("failed. %d chunks failed and %d chunks unconfirmed, run again to resume",
+			journal.CountByStatus(StatusFailed), journal.CountByStatus(StatusBroadcast))
+	}
+
+	return
+}
+
+// send binds the chunk to the sequence in the journal before broadcasting its MultiSend tx
+func (e *Engine) send(journal *Journal, index int, seq uint64, transfers []types.TransferUnit) error {
+	chunk := &journal.Chunks[index]
+	if err := e.newTracker().Bind(&chunk.Record, seq); err != nil {
+		return err
+	}
+	if err := SaveJournal(e.journalPath, *journal); err != nil {
+		return err
+	}
+
+	resp, sendErr := e.cli.Token().MultiSend(e.fromInfo, e.passWd, transfers[chunk.Start:chunk.End], e.memo,
+		journal.AccountNumber, seq)
+	sendErr = chunk.ApplyResponse(resp, sendErr)
+	if err := SaveJournal(e.journalPath, *journal); err != nil {
+		return err
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("failed. send chunk %d with sequence %d error: %s", index, seq, sendErr)
+	}
+
+	return nil
+}
+
+// resolve finds out the result of the chunk in flight by the last run
+func (e *Engine) resolve(chunk *Chunk, transfers []types.TransferUnit, seq uint64) error {
+	_, err := e.newTracker().Resolve(&chunk.Record, seq, func(pResultTx *tmtypes.ResultTx) (bool, error) {
+		transferEvents, err := e.cli.Token().ParseTransferEvents(utils.GetEventsFromResultTx(pResultTx))
+		if err != nil {
+			return false, err
+		}
+
+		return isChunkPaid(transferEvents, transfers[chunk.Start:chunk.End]), nil
+	})
+	if err == tracker.ErrTxNotFound {
+		return fmt.Errorf("failed. sequence %d of chunk %d has been consumed but no tx paying it is found after "+
+			"height %d. Check the txs of %s and set the status of the chunk in %s to %s only if it isn't paid",
+			chunk.Sequence, chunk.Index, chunk.BroadcastHeight, e.fromInfo.GetAddress(), e.journalPath, StatusFailed)
+	}
+
+	return err
+}
+
+// newTracker creates the tracker of the txs sent by the engine
+func (e *Engine) newTracker() *tracker.Tracker {
+	return tracker.NewTracker(e.cli, e.fromInfo.GetAddress().String(), e.opts.ConfirmTimeout, e.opts.PollInterval)
+}
+
+// isChunkPaid checks whether every transfer of the chunk is in the transfer events
+func isChunkPaid(transferEvents []types.TransferEvent, transfers []types.TransferUnit) bool {
+	paid := make(map[string]bool, len(transferEvents))
+	for _, event := range transferEvents {
+		paid[fmt.Sprintf("%s %s", event.Recipient, event.Amount)] = true
+	}
+
+	for _, transfer := range transfers {
+		if !paid[fmt.Sprintf("%s %s", transfer.To, transfer.Coins)] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,204 @@
+package payout
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain-go-sdk/exposed"
+	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/token"
+	"github.com/okex/exchain-go-sdk/module/token/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
+	"github.com/okex/exchain-go-sdk/utils"
+	"github.com/okx/okbchain/libs/cosmos-sdk/crypto/keys"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	name     = "alice"
+	passWd   = "12345678"
+	mnemonic = "giggle sibling fun arrow elevator spoon blood grocery laugh tortoise culture tool"
+	memo     = "my memo"
+)
+
+// fakeChain simulates the MultiSend txs of the payout account on the shared fake chain
+type fakeChain struct {
+	*mocks.FakeChain
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{FakeChain: mocks.NewFakeChain(100, 10)}
+}
+
+func (fc *fakeChain) GetConfig() gosdktypes.ClientConfig {
+	return gosdktypes.ClientConfig{Gas: 200000}
+}
+
+func (fc *fakeChain) Token() exposed.Token {
+	return fakeToken{Token: token.NewTokenClient(nil), chain: fc}
+}
+
+func (fc *fakeChain) paidTimes(to sdk.AccAddress) (times int) {
+	for _, tx := range fc.Txs {
+		for _, event := range tx.TxResult.Events {
+			if string(event.Attributes[0].Value) == to.String() {
+				times++
+			}
+		}
+	}
+
+	return
+}
+
+type fakeToken struct {
+	exposed.Token
+	chain *fakeChain
+}
+
+func (ft fakeToken) MultiSend(_ keys.Info, _ string, transfers []types.TransferUnit, _ string, _, seqNum uint64) (
+	sdk.TxResponse, error) {
+	var events []abci.Event
+	for _, transfer := range transfers {
+		events = append(events, abci.Event{Type: "transfer", Attributes: []kv.Pair{
+			{Key: []byte("recipient"), Value: []byte(transfer.To.String())},
+			{Key: []byte("amount"), Value: []byte(transfer.Coins.String())},
+		}})
+	}
+
+	return ft.chain.Commit(seqNum, events...)
+}
+
+func newTestTransfers(t *testing.T, n int) []types.TransferUnit {
+	transfers := make([]types.TransferUnit, n)
+	for i := range transfers {
+		coins, err := utils.ParseDecCoins(fmt.Sprintf("%d.5okt", i+1))
+		require.NoError(t, err)
+		transfers[i] = types.TransferUnit{To: sdk.AccAddress(fmt.Sprintf("recipient-address-%02d", i)), Coins: coins}
+	}
+
+	return transfers
+}
+
+func newTestEngine(t *testing.T, chain *fakeChain, journalPath string) *Engine {
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	// 2 transfers per chunk at most
+	return NewEngine(chain, fromInfo, passWd, memo, journalPath, Options{
+		BaseGas:        60000,
+		GasPerCoin:     70000,
+		ConfirmTimeout: time.Millisecond,
+		PollInterval:   time.Millisecond,
+	})
+}
+
+func TestEngine_Split(t *testing.T) {
+	engine := newTestEngine(t, newFakeChain(), filepath.Join(t.TempDir(), "journal.json"))
+	chunks, err := engine.Split(newTestTransfers(t, 5))
+	require.NoError(t, err)
+	require.Equal(t, 3, len(chunks))
+	require.Equal(t, 0, chunks[0].Start)
+	require.Equal(t, 2, chunks[0].End)
+	require.Equal(t, uint64(200000), chunks[0].Gas)
+	require.Equal(t, 4, chunks[2].Start)
+	require.Equal(t, 5, chunks[2].End)
+	require.Equal(t, StatusPending, chunks[2].Status)
+
+	engine.opts.MaxTransfersPerTx = 1
+	chunks, err = engine.Split(newTestTransfers(t, 5))
+	require.NoError(t, err)
+	require.Equal(t, 5, len(chunks))
+
+	engine.opts.GasPerCoin = 200000
+	_, err = engine.Split(newTestTransfers(t, 1))
+	require.Error(t, err)
+}
+
+func TestEngine_Run(t *testing.T) {
+	chain := newFakeChain()
+	journalPath := filepath.Join(t.TempDir(), "journal.json")
+	engine := newTestEngine(t, chain, journalPath)
+	transfers := newTestTransfers(t, 5)
+
+	journal, err := engine.Run(transfers)
+	require.NoError(t, err)
+	require.True(t, journal.IsDone())
+	require.Equal(t, uint64(1), journal.AccountNumber)
+	for i, chunk := range journal.Chunks {
+		require.Equal(t, uint64(10+i), chunk.Sequence)
+		require.NotEmpty(t, chunk.TxHash)
+	}
+
+	// nothing is sent again after the payout is done
+	journal, err = engine.Run(transfers)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), chain.Sequence)
+
+	// the journal belongs to another payout
+	_, err = engine.Run(transfers[1:])
+	require.Error(t, err)
+
+	saved, exists, err := LoadJournal(journalPath)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, journal, saved)
+}
+
+func TestEngine_RunResume(t *testing.T) {
+	chain := newFakeChain()
+	journalPath := filepath.Join(t.TempDir(), "journal.json")
+	engine := newTestEngine(t, chain, journalPath)
+	transfers := newTestTransfers(t, 5)
+
+	// crash after the first chunk is committed
+	chain.Crash = true
+	journal, err := engine.Run(transfers)
+	require.Error(t, err)
+	require.Equal(t, StatusSending, journal.Chunks[0].Status)
+	require.Equal(t, StatusPending, journal.Chunks[1].Status)
+
+	// the second chunk is rejected by the mempool
+	chain.Reject = true
+	journal, err = engine.Run(transfers)
+	require.Error(t, err)
+	require.Equal(t, StatusConfirmed, journal.Chunks[0].Status)
+	require.Equal(t, StatusSending, journal.Chunks[1].Status)
+	require.Equal(t, uint64(11), journal.Chunks[1].Sequence)
+
+	journal, err = engine.Run(transfers)
+	require.NoError(t, err)
+	require.True(t, journal.IsDone())
+	require.Equal(t, uint64(11), journal.Chunks[1].Sequence)
+	require.Equal(t, uint64(12), journal.Chunks[2].Sequence)
+
+	// every recipient is paid exactly once
+	for _, transfer := range transfers {
+		require.Equal(t, 1, chain.paidTimes(transfer.To))
+	}
+}
+
+func TestEngine_RunUnresolved(t *testing.T) {
+	chain := newFakeChain()
+	journalPath := filepath.Join(t.TempDir(), "journal.json")
+	engine := newTestEngine(t, chain, journalPath)
+	transfers := newTestTransfers(t, 2)
+
+	chain.Crash = true
+	_, err := engine.Run(transfers)
+	require.Error(t, err)
+
+	// the sequence is consumed by another tx, so the chunk can't be resolved automatically
+	chain.Txs[0].TxResult.Events = nil
+	_, err = engine.Run(transfers)
+	require.Error(t, err)
+	require.Equal(t, 1, len(chain.Txs))
+
+	journal, _, err := LoadJournal(journalPath)
+	require.NoError(t, err)
+	require.Equal(t, StatusSending, journal.Chunks[0].Status)
+}
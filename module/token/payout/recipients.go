@@ -0,0 +1,139 @@
+package payout
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okex/exchain-go-sdk/module/token/types"
+	"github.com/okex/exchain-go-sdk/utils"
+)
+
+// Recipient - structure of a recipient in the JSON formatted payout file
+type Recipient struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+}
+
+// LoadRecipients loads the transfers from a payout file, which is parsed as JSON by the extension .json and as CSV
+// otherwise
+func LoadRecipients(filePath string) ([]types.TransferUnit, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed. open payout file error: %s", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		return ParseRecipientsJSON(file)
+	}
+
+	return ParseRecipientsCSV(file)
+}
+
+// ParseRecipientsCSV parses the transfers from the CSV records like `address,amount`
+// Note: the address is accepted in both bech32 and hex format. The amount of several denoms is supposed to be quoted,
+// like `ex1...,"1okt,2btc-000"`. The optional header `address,amount` and the lines beginning with '#' are skipped
+func ParseRecipientsCSV(reader io.Reader) ([]types.TransferUnit, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.FieldsPerRecord = 2
+	csvReader.TrimLeadingSpace = true
+
+	var transfers []types.TransferUnit
+	for i := 0; ; i++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed. read CSV error: %s", err)
+		}
+
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+
+		transfer, err := parseRecipient(record[0], record[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed. record %d: %s", i, err)
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	if err := CheckTransfers(transfers); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// ParseRecipientsJSON parses the transfers from the JSON array of Recipient
+func ParseRecipientsJSON(reader io.Reader) ([]types.TransferUnit, error) {
+	var recipients []Recipient
+	if err := json.NewDecoder(reader).Decode(&recipients); err != nil {
+		return nil, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	transfers := make([]types.TransferUnit, len(recipients))
+	for i, recipient := range recipients {
+		transfer, err := parseRecipient(recipient.Address, recipient.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed. recipient %d: %s", i, err)
+		}
+
+		transfers[i] = transfer
+	}
+
+	if err := CheckTransfers(transfers); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// CheckTransfers gives a validity check for the transfers of a payout, where neither the empty list, the duplicated
+// recipient nor the zero amount is allowed
+func CheckTransfers(transfers []types.TransferUnit) error {
+	if len(transfers) == 0 {
+		return errors.New("failed. no recipient in the payout")
+	}
+
+	indexes := make(map[string]int, len(transfers))
+	for i, transfer := range transfers {
+		if transfer.To.Empty() {
+			return fmt.Errorf("failed. empty address of recipient %d", i)
+		}
+
+		if transfer.Coins.Empty() || !transfer.Coins.IsAllPositive() {
+			return fmt.Errorf("failed. zero amount to %s", transfer.To)
+		}
+
+		addrStr := transfer.To.String()
+		if j, ok := indexes[addrStr]; ok {
+			return fmt.Errorf("failed. duplicated recipient %s of %d and %d", addrStr, j, i)
+		}
+		indexes[addrStr] = i
+	}
+
+	return nil
+}
+
+func parseRecipient(addrStr, amountStr string) (transfer types.TransferUnit, err error) {
+	addrStr, amountStr = strings.TrimSpace(addrStr), strings.TrimSpace(amountStr)
+	if transfer.To, err = utils.ToCosmosAddress(addrStr); err != nil {
+		return transfer, fmt.Errorf("parse Address [%s] error: %s", addrStr, err)
+	}
+
+	if transfer.Coins, err = utils.ParseDecCoins(amountStr); err != nil {
+		return transfer, fmt.Errorf("parse DecCoins [%s] error: %s", amountStr, err)
+	}
+
+	return
+}
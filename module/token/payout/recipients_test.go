@@ -0,0 +1,84 @@
+package payout
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	addr1    = "ex1qj5c07sm6jetjz8f509qtrxgh4psxkv3ddyq7u"
+	addr1Hex = "0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991"
+	addr2    = "ex1qwuag8gx408m9ej038vzx50ntt0x4yrq38yf06"
+)
+
+func TestParseRecipientsCSV(t *testing.T) {
+	csvStr := `address,amount
+# comment line
+` + addr1 + `,1.024okt
+` + addr2 + `,"2.048okt,1btc-000"
+`
+	transfers, err := ParseRecipientsCSV(strings.NewReader(csvStr))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transfers))
+	require.Equal(t, addr1, transfers[0].To.String())
+	require.Equal(t, "1.024000000000000000okt", transfers[0].Coins.String())
+	require.Equal(t, addr2, transfers[1].To.String())
+	require.Equal(t, 2, len(transfers[1].Coins))
+
+	// duplicated recipient in bech32 and hex
+	_, err = ParseRecipientsCSV(strings.NewReader(addr1 + ",1okt\n" + addr1Hex + ",2okt\n"))
+	require.Error(t, err)
+
+	// zero amount
+	_, err = ParseRecipientsCSV(strings.NewReader(addr1 + ",0okt\n"))
+	require.Error(t, err)
+
+	// amount exceeding the precision
+	_, err = ParseRecipientsCSV(strings.NewReader(addr1 + ",0.5wei\n"))
+	require.Error(t, err)
+
+	_, err = ParseRecipientsCSV(strings.NewReader("invalid address,1okt\n"))
+	require.Error(t, err)
+
+	_, err = ParseRecipientsCSV(strings.NewReader(addr1 + ",1okt,extra\n"))
+	require.Error(t, err)
+
+	_, err = ParseRecipientsCSV(strings.NewReader("address,amount\n"))
+	require.Error(t, err)
+}
+
+func TestParseRecipientsJSON(t *testing.T) {
+	jsonStr := `[{"address":"` + addr1Hex + `","amount":"1.024okt"},{"address":"` + addr2 + `","amount":"2okt"}]`
+	transfers, err := ParseRecipientsJSON(strings.NewReader(jsonStr))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transfers))
+	require.Equal(t, addr1, transfers[0].To.String())
+
+	_, err = ParseRecipientsJSON(strings.NewReader(`[{"address":"` + addr1 + `","amount":""}]`))
+	require.Error(t, err)
+
+	_, err = ParseRecipientsJSON(strings.NewReader(`{}`))
+	require.Error(t, err)
+}
+
+func TestLoadRecipients(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "payout.json")
+	require.NoError(t, ioutil.WriteFile(jsonPath, []byte(`[{"address":"`+addr1+`","amount":"1okt"}]`), 0600))
+	transfers, err := LoadRecipients(jsonPath)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(transfers))
+
+	csvPath := filepath.Join(dir, "payout.csv")
+	require.NoError(t, ioutil.WriteFile(csvPath, []byte(addr1+",1okt\n"+addr2+",2okt\n"), 0600))
+	transfers, err = LoadRecipients(csvPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transfers))
+
+	_, err = LoadRecipients(filepath.Join(dir, "nonexistent.csv"))
+	require.Error(t, err)
+}
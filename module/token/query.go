@@ -1,13 +1,22 @@
 package token
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/okex/exchain-go-sdk/module/token/types"
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	"github.com/okex/exchain-go-sdk/types/params"
+	"github.com/okex/exchain-go-sdk/utils"
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/okx/okbchain/libs/cosmos-sdk/x/supply"
 	"github.com/okx/okbchain/x/token"
+	tokentypes "github.com/okx/okbchain/x/token/types"
 )
 
+const querySupplyOf = "supply_of"
+
 // QueryTokenInfo gets token info with a specific symbol or the owner address
 func (tc tokenClient) QueryTokenInfo(ownerAddr, symbol string) (tokens []types.TokenResp, err error) {
 	if err = params.CheckQueryTokenInfoParams(ownerAddr, symbol); err != nil {
@@ -22,7 +31,10 @@ func (tc tokenClient) QueryTokenInfo(ownerAddr, symbol string) (tokens []types.T
 		}
 
 		var tokenResp types.TokenResp
-		tc.GetCodec().MustUnmarshalJSON(res, &tokenResp)
+		if err = tc.GetCodec().UnmarshalJSON(res, &tokenResp); err != nil {
+			return tokens, utils.ErrUnmarshalJSON(err.Error())
+		}
+
 		tokens = append(tokens, tokenResp)
 		return tokens, err
 	}
@@ -33,6 +45,168 @@ func (tc tokenClient) QueryTokenInfo(ownerAddr, symbol string) (tokens []types.T
 		return tokens, fmt.Errorf("failed. %s doesn't own any tokens: %s", ownerAddr, err.Error())
 	}
 
-	tc.GetCodec().MustUnmarshalJSON(res, &tokens)
+	if err = tc.GetCodec().UnmarshalJSON(res, &tokens); err != nil {
+		return tokens, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryTokens gets a page of all the tokens on chain at a specific height
+// Note: height 0 stands for the latest height
+func (tc tokenClient) QueryTokens(height int64, page, limit int) (result types.TokensResult, err error) {
+	if err = params.CheckQueryPageParams(height, page, limit); err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", token.QuerierRoute, tokentypes.QueryTokens)
+	res, resHeight, err := tc.QueryWithHeight(path, nil, height)
+	if err != nil {
+		return result, utils.ErrClientQuery(err.Error())
+	}
+
+	var tokens []types.TokenResp
+	if err = tc.GetCodec().UnmarshalJSON(res, &tokens); err != nil {
+		return result, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].Symbol < tokens[j].Symbol
+	})
+
+	result.PageInfo = gosdktypes.NewPageInfo(resHeight, len(tokens), page, limit)
+	start, end := result.Bounds()
+	result.Tokens = tokens[start:end]
+	return
+}
+
+// QuerySupply gets a page of the total supply per denom at a specific height
+// Note: height 0 stands for the latest height
+func (tc tokenClient) QuerySupply(height int64, page, limit int) (result types.SupplyResult, err error) {
+	if err = params.CheckQueryPageParams(height, page, limit); err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", token.QuerierRoute, tokentypes.QueryCurrency)
+	res, resHeight, err := tc.QueryWithHeight(path, nil, height)
+	if err != nil {
+		return result, utils.ErrClientQuery(err.Error())
+	}
+
+	var currencies []types.Currency
+	if err = tc.GetCodec().UnmarshalJSON(res, &currencies); err != nil {
+		return result, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	sort.SliceStable(currencies, func(i, j int) bool {
+		return currencies[i].Symbol < currencies[j].Symbol
+	})
+
+	result.PageInfo = gosdktypes.NewPageInfo(resHeight, len(currencies), page, limit)
+	start, end := result.Bounds()
+	result.Supply = currencies[start:end]
+	return
+}
+
+// QuerySupplyOf gets the total supply of a specific denom at a specific height
+// Note: height 0 stands for the latest height
+func (tc tokenClient) QuerySupplyOf(denom string, height int64) (amount sdk.Dec, err error) {
+	if len(denom) == 0 {
+		return amount, errors.New("failed. empty denom")
+	}
+
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return
+	}
+
+	jsonBytes, err := tc.GetCodec().MarshalJSON(types.QuerySupplyOfParams{Denom: denom})
+	if err != nil {
+		return amount, utils.ErrMarshalJSON(err.Error())
+	}
+
+	path := fmt.Sprintf("custom/%s/%s", supply.QuerierRoute, querySupplyOf)
+	res, _, err := tc.QueryWithHeight(path, jsonBytes, height)
+	if err != nil {
+		return amount, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = tc.GetCodec().UnmarshalJSON(res, &amount); err != nil {
+		return amount, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryBalances gets a page of the balances per denom of an account at a specific height
+// Note: addrStr is accepted in both bech32 and hex format, and height 0 stands for the latest height
+func (tc tokenClient) QueryBalances(addrStr string, height int64, page, limit int) (result types.BalancesResult,
+	err error) {
+	if err = params.CheckQueryPageParams(height, page, limit); err != nil {
+		return
+	}
+
+	accResp, resHeight, err := tc.queryAccountCoins(addrStr, "", height)
+	if err != nil {
+		return
+	}
+
+	sort.SliceStable(accResp.Currencies, func(i, j int) bool {
+		return accResp.Currencies[i].Symbol < accResp.Currencies[j].Symbol
+	})
+
+	result.PageInfo = gosdktypes.NewPageInfo(resHeight, len(accResp.Currencies), page, limit)
+	result.Address = accResp.Address
+	start, end := result.Bounds()
+	result.Balances = accResp.Currencies[start:end]
+	return
+}
+
+// QueryBalance gets the balance of a specific denom of an account at a specific height
+// Note: the zero balance is returned if the account doesn't hold the denom
+func (tc tokenClient) QueryBalance(addrStr, denom string, height int64) (balance types.CoinInfo, err error) {
+	if len(denom) == 0 {
+		return balance, errors.New("failed. empty denom")
+	}
+
+	if err = params.CheckQueryHeightParams(height); err != nil {
+		return
+	}
+
+	accResp, _, err := tc.queryAccountCoins(addrStr, denom, height)
+	if err != nil {
+		return
+	}
+
+	for _, coinInfo := range accResp.Currencies {
+		if coinInfo.Symbol == denom {
+			return coinInfo, nil
+		}
+	}
+
+	return *tokentypes.NewCoinInfo(denom, "0", "0"), nil
+}
+
+func (tc tokenClient) queryAccountCoins(addrStr, denom string, height int64) (accResp types.AccountResponse,
+	resHeight int64, err error) {
+	accAddr, err := utils.ToCosmosAddress(addrStr)
+	if err != nil {
+		return accResp, resHeight, fmt.Errorf("failed. parse Address [%s] error: %s", addrStr, err)
+	}
+
+	jsonBytes, err := tc.GetCodec().MarshalJSON(types.AccountParam{Symbol: denom})
+	if err != nil {
+		return accResp, resHeight, utils.ErrMarshalJSON(err.Error())
+	}
+
+	path := fmt.Sprintf("custom/%s/%s/%s", token.QuerierRoute, tokentypes.QueryAccount, accAddr.String())
+	res, resHeight, err := tc.QueryWithHeight(path, jsonBytes, height)
+	if err != nil {
+		return accResp, resHeight, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = tc.GetCodec().UnmarshalJSON(res, &accResp); err != nil {
+		return accResp, resHeight, utils.ErrUnmarshalJSON(err.Error())
+	}
+
 	return
 }
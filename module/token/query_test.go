@@ -7,9 +7,11 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/okex/exchain-go-sdk/mocks"
+	"github.com/okex/exchain-go-sdk/module/token/types"
 	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	"github.com/okx/okbchain/x/token"
+	tokentypes "github.com/okx/okbchain/x/token/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -98,3 +100,163 @@ func TestTokenClient_QueryTokenInfo(t *testing.T) {
 	_, err = mockCli.Token().QueryTokenInfo(addr, "")
 	require.Error(t, err)
 }
+
+func TestTokenClient_QueryTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	ownerAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	tokens := []tokentypes.TokenResp{
+		{Symbol: "usdk-000", Owner: ownerAddr, TotalSupply: sdk.NewDec(1024), OriginalTotalSupply: sdk.NewDec(1024)},
+		{Symbol: tokenSymbol, Owner: ownerAddr, TotalSupply: sdk.NewDec(2048), OriginalTotalSupply: sdk.NewDec(2048)},
+		{Symbol: "okt", Owner: ownerAddr, TotalSupply: sdk.NewDec(4096), OriginalTotalSupply: sdk.NewDec(4096)},
+	}
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(tokens)
+	expectedPath := fmt.Sprintf("custom/%s/%s", token.QuerierRoute, tokentypes.QueryTokens)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(1024)).Return(expectedRet, int64(1024), nil)
+	result, err := mockCli.Token().QueryTokens(1024, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), result.Height)
+	require.Equal(t, 3, result.TotalCount)
+	require.True(t, result.HasNext())
+	require.Equal(t, 2, len(result.Tokens))
+	require.Equal(t, tokenSymbol, result.Tokens[0].Symbol)
+	require.Equal(t, "okt", result.Tokens[1].Symbol)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(expectedRet, int64(2048), nil)
+	result, err = mockCli.Token().QueryTokens(0, 2, 2)
+	require.NoError(t, err)
+	require.False(t, result.HasNext())
+	require.Equal(t, 1, len(result.Tokens))
+	require.Equal(t, "usdk-000", result.Tokens[0].Symbol)
+
+	// out of range
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(expectedRet, int64(2048), nil)
+	result, err = mockCli.Token().QueryTokens(0, 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(result.Tokens))
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return([]byte("malformed"), int64(2048), nil)
+	_, err = mockCli.Token().QueryTokens(0, 1, 2)
+	require.Error(t, err)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Token().QueryTokens(0, 1, 2)
+	require.Error(t, err)
+
+	_, err = mockCli.Token().QueryTokens(-1, 1, 2)
+	require.Error(t, err)
+
+	_, err = mockCli.Token().QueryTokens(0, 0, 2)
+	require.Error(t, err)
+}
+
+func TestTokenClient_QuerySupply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	currencies := []tokentypes.Currency{
+		{Symbol: tokenSymbol, Description: defaultDesc, TotalSupply: sdk.NewDec(2048)},
+		{Symbol: "okt", Description: defaultDesc, TotalSupply: sdk.NewDec(4096)},
+	}
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(currencies)
+	expectedPath := fmt.Sprintf("custom/%s/%s", token.QuerierRoute, tokentypes.QueryCurrency)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(expectedRet, int64(1024), nil)
+	result, err := mockCli.Token().QuerySupply(0, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.TotalCount)
+	require.Equal(t, 2, len(result.Supply))
+	require.Equal(t, tokenSymbol, result.Supply[0].Symbol)
+	require.True(t, sdk.NewDec(2048).Equal(result.Supply[0].TotalSupply))
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, nil, int64(0)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Token().QuerySupply(0, 1, 10)
+	require.Error(t, err)
+
+	expectedPath = fmt.Sprintf("custom/supply/%s", querySupplyOf)
+	expectedParams := mockCli.GetCodec().MustMarshalJSON(types.QuerySupplyOfParams{Denom: "okt"})
+	mockCli.EXPECT().QueryWithHeight(expectedPath, expectedParams, int64(1024)).
+		Return(mockCli.GetCodec().MustMarshalJSON(sdk.NewDec(4096)), int64(1024), nil)
+	amount, err := mockCli.Token().QuerySupplyOf("okt", 1024)
+	require.NoError(t, err)
+	require.True(t, sdk.NewDec(4096).Equal(amount))
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, expectedParams, int64(0)).Return([]byte("malformed"), int64(0), nil)
+	_, err = mockCli.Token().QuerySupplyOf("okt", 0)
+	require.Error(t, err)
+
+	_, err = mockCli.Token().QuerySupplyOf("", 0)
+	require.Error(t, err)
+}
+
+func TestTokenClient_QueryBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := gosdktypes.NewClientConfig("testURL", "testchain-1", gosdktypes.BroadcastBlock, "", 200000,
+		1.1, "0.00000001okt")
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec()).AnyTimes()
+
+	accResp := tokentypes.NewAccountResponse(addr)
+	accResp.Currencies = tokentypes.CoinsInfo{
+		*tokentypes.NewCoinInfo("okt", "10.24", "0"),
+		*tokentypes.NewCoinInfo(tokenSymbol, "20.48", "1"),
+	}
+	expectedRet := mockCli.GetCodec().MustMarshalJSON(accResp)
+	expectedPath := fmt.Sprintf("custom/%s/%s/%s", token.QuerierRoute, tokentypes.QueryAccount, addr)
+	expectedParams := mockCli.GetCodec().MustMarshalJSON(tokentypes.AccountParam{})
+
+	// hex address is accepted
+	mockCli.EXPECT().QueryWithHeight(expectedPath, expectedParams, int64(0)).Return(expectedRet, int64(1024), nil)
+	result, err := mockCli.Token().QueryBalances("0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991", 0, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, addr, result.Address)
+	require.Equal(t, 2, result.TotalCount)
+	require.Equal(t, 1, len(result.Balances))
+	require.Equal(t, tokenSymbol, result.Balances[0].Symbol)
+	require.Equal(t, "1", result.Balances[0].Locked)
+
+	mockCli.EXPECT().QueryWithHeight(expectedPath, expectedParams, int64(0)).Return(nil, int64(0), errors.New("default error"))
+	_, err = mockCli.Token().QueryBalances(addr, 0, 1, 1)
+	require.Error(t, err)
+
+	_, err = mockCli.Token().QueryBalances("invalid address", 0, 1, 1)
+	require.Error(t, err)
+
+	expectedParams = mockCli.GetCodec().MustMarshalJSON(tokentypes.AccountParam{Symbol: "okt"})
+	accResp.Currencies = accResp.Currencies[:1]
+	mockCli.EXPECT().QueryWithHeight(expectedPath, expectedParams, int64(1024)).
+		Return(mockCli.GetCodec().MustMarshalJSON(accResp), int64(1024), nil)
+	balance, err := mockCli.Token().QueryBalance(addr, "okt", 1024)
+	require.NoError(t, err)
+	require.Equal(t, "10.24", balance.Available)
+
+	accResp.Currencies = tokentypes.CoinsInfo{}
+	expectedParams = mockCli.GetCodec().MustMarshalJSON(tokentypes.AccountParam{Symbol: tokenSymbol})
+	mockCli.EXPECT().QueryWithHeight(expectedPath, expectedParams, int64(0)).
+		Return(mockCli.GetCodec().MustMarshalJSON(accResp), int64(1024), nil)
+	balance, err = mockCli.Token().QueryBalance(addr, tokenSymbol, 0)
+	require.NoError(t, err)
+	require.Equal(t, tokenSymbol, balance.Symbol)
+	require.Equal(t, "0", balance.Available)
+
+	_, err = mockCli.Token().QueryBalance(addr, "", 0)
+	require.Error(t, err)
+}
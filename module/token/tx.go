@@ -23,7 +23,7 @@ func (tc tokenClient) Send(fromInfo keys.Info, passWd, toAddrStr, coinsStr, memo
 		return resp, fmt.Errorf("failed. parse Address [%s] error: %s", toAddrStr, err)
 	}
 
-	coins, err := sdk.ParseDecCoins(coinsStr)
+	coins, err := utils.ParseDecCoins(coinsStr)
 	if err != nil {
 		return resp, fmt.Errorf("failed. parse DecCoins [%s] error: %s", coinsStr, err)
 	}
@@ -61,7 +61,7 @@ func (tc tokenClient) Mint(fromInfo keys.Info, passWd, coinsStr, memo string, ac
 		return
 	}
 
-	coin, err := sdk.ParseDecCoin(coinsStr)
+	coin, err := utils.ParseDecCoin(coinsStr)
 	if err != nil {
 		return resp, fmt.Errorf("failed : parse Coins [%s] error: %s", coinsStr, err)
 	}
@@ -77,7 +77,7 @@ func (tc tokenClient) Burn(fromInfo keys.Info, passWd, coinsStr, memo string, ac
 		return
 	}
 
-	coin, err := sdk.ParseDecCoin(coinsStr)
+	coin, err := utils.ParseDecCoin(coinsStr)
 	if err != nil {
 		return resp, fmt.Errorf("failed : parse Coins [%s] error: %s", coinsStr, err)
 	}
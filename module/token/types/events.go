@@ -0,0 +1,13 @@
+package types
+
+import (
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+// TransferEvent - structure of a coin transfer decoded from the transfer event
+// Note: the sender is empty in the transfer events emitted by multi-send
+type TransferEvent struct {
+	Sender    sdk.AccAddress
+	Recipient sdk.AccAddress
+	Amount    sdk.SysCoins
+}
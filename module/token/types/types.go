@@ -1,6 +1,7 @@
 package types
 
 import (
+	gosdktypes "github.com/okex/exchain-go-sdk/types"
 	"github.com/okx/okbchain/x/token"
 	tokentypes "github.com/okx/okbchain/x/token/types"
 )
@@ -11,6 +12,34 @@ const (
 )
 
 type (
-	TransferUnit = tokentypes.TransferUnit
-	TokenResp    = tokentypes.TokenResp
+	TransferUnit    = tokentypes.TransferUnit
+	TokenResp       = tokentypes.TokenResp
+	Currency        = tokentypes.Currency
+	CoinInfo        = tokentypes.CoinInfo
+	AccountParam    = tokentypes.AccountParam
+	AccountResponse = tokentypes.AccountResponse
 )
+
+// TokensResult - structure of a page of the tokens on chain
+type TokensResult struct {
+	gosdktypes.PageInfo
+	Tokens []TokenResp `json:"tokens"`
+}
+
+// SupplyResult - structure of a page of the total supply per denom
+type SupplyResult struct {
+	gosdktypes.PageInfo
+	Supply []Currency `json:"supply"`
+}
+
+// BalancesResult - structure of a page of the balances per denom of an account
+type BalancesResult struct {
+	gosdktypes.PageInfo
+	Address  string     `json:"address"`
+	Balances []CoinInfo `json:"balances"`
+}
+
+// QuerySupplyOfParams - structure of params to query the total supply of a denom from the supply module
+type QuerySupplyOfParams struct {
+	Denom string
+}
@@ -41,6 +41,7 @@ type ClientQuery interface {
 	rpcclient.HistoryClient
 	rpcclient.StatusClient
 	Query(path string, key tmbytes.HexBytes) ([]byte, int64, error)
+	QueryWithHeight(path string, key tmbytes.HexBytes, height int64) ([]byte, int64, error)
 	QueryStore(key tmbytes.HexBytes, storeName, endPath string) ([]byte, int64, error)
 }
 
@@ -273,6 +273,22 @@ func (mr *MockBaseClientMockRecorder) QueryStore(key, storeName, endPath interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryStore", reflect.TypeOf((*MockBaseClient)(nil).QueryStore), key, storeName, endPath)
 }
 
+// QueryWithHeight mocks base method.
+func (m *MockBaseClient) QueryWithHeight(path string, key bytes.HexBytes, height int64) ([]byte, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryWithHeight", path, key, height)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryWithHeight indicates an expected call of QueryWithHeight.
+func (mr *MockBaseClientMockRecorder) QueryWithHeight(path, key, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryWithHeight", reflect.TypeOf((*MockBaseClient)(nil).QueryWithHeight), path, key, height)
+}
+
 // Tx mocks base method.
 func (m *MockBaseClient) Tx(hash []byte, prove bool) (*types1.ResultTx, error) {
 	m.ctrl.T.Helper()
@@ -568,6 +584,22 @@ func (mr *MockClientQueryMockRecorder) QueryStore(key, storeName, endPath interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryStore", reflect.TypeOf((*MockClientQuery)(nil).QueryStore), key, storeName, endPath)
 }
 
+// QueryWithHeight mocks base method.
+func (m *MockClientQuery) QueryWithHeight(path string, key bytes.HexBytes, height int64) ([]byte, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryWithHeight", path, key, height)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryWithHeight indicates an expected call of QueryWithHeight.
+func (mr *MockClientQueryMockRecorder) QueryWithHeight(path, key, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryWithHeight", reflect.TypeOf((*MockClientQuery)(nil).QueryWithHeight), path, key, height)
+}
+
 // Tx mocks base method.
 func (m *MockClientQuery) Tx(hash []byte, prove bool) (*types1.ResultTx, error) {
 	m.ctrl.T.Helper()
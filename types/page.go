@@ -0,0 +1,39 @@
+package types
+
+// PageInfo - structure of the paging info attached to the query result
+type PageInfo struct {
+	Height     int64 `json:"height"`
+	TotalCount int   `json:"total_count"`
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+}
+
+// NewPageInfo creates a new instance of PageInfo
+func NewPageInfo(height int64, totalCount, page, limit int) PageInfo {
+	return PageInfo{
+		Height:     height,
+		TotalCount: totalCount,
+		Page:       page,
+		Limit:      limit,
+	}
+}
+
+// Bounds gets the start and end index of the page in the whole result set
+func (pi PageInfo) Bounds() (start, end int) {
+	start = (pi.Page - 1) * pi.Limit
+	if start > pi.TotalCount {
+		start = pi.TotalCount
+	}
+
+	end = start + pi.Limit
+	if end > pi.TotalCount {
+		end = pi.TotalCount
+	}
+
+	return
+}
+
+// HasNext shows whether there are more results after this page
+func (pi PageInfo) HasNext() bool {
+	return pi.Page*pi.Limit < pi.TotalCount
+}
@@ -341,6 +341,32 @@ func CheckQueryHeightParams(height int64) error {
 	return nil
 }
 
+// CheckQueryPageParams gives a quick validity check for the input params of query with height and paging
+func CheckQueryPageParams(height int64, page, limit int) error {
+	if err := CheckQueryHeightParams(height); err != nil {
+		return err
+	}
+
+	if page <= 0 || limit <= 0 {
+		return errors.New(`failed. "page" and "limit" must be positive`)
+	}
+
+	return nil
+}
+
+// CheckQueryValidatorsParams gives a quick validity check for the input params of query validators by bond status
+func CheckQueryValidatorsParams(status string, height int64, page, limit int) error {
+	switch strings.ToLower(status) {
+	case "all", strings.ToLower(sdk.BondStatusBonded), strings.ToLower(sdk.BondStatusUnbonding),
+		strings.ToLower(sdk.BondStatusUnbonded):
+	default:
+		return fmt.Errorf(`failed. invalid validator status "%s", expected "all", "bonded", "unbonding" or "unbonded"`,
+			status)
+	}
+
+	return CheckQueryPageParams(height, page, limit)
+}
+
 // IsValidAccAddr gives a quick validity check for an address string
 func IsValidAccAddr(addrStr string) error {
 	if len(addrStr) != bech32AddrLen || !strings.HasPrefix(addrStr, sdk.GetConfig().GetBech32AccountAddrPrefix()) {
@@ -0,0 +1,299 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// DenomOKT is the display denom of the native token used by the client side
+	DenomOKT = "okt"
+	// DenomWei is the base denom of the native token on evm and ibc
+	DenomWei = sdk.DefaultIbcWei
+)
+
+var (
+	reCoin = regexp.MustCompile(`^([[:digit:]]*\.?[[:digit:]]+)[[:space:]]*([a-zA-Z][a-zA-Z0-9/-]*)$`)
+
+	denomRegistry = struct {
+		sync.RWMutex
+		units map[string]DenomUnit
+		bases map[string]string
+	}{
+		units: make(map[string]DenomUnit),
+		bases: make(map[string]string),
+	}
+)
+
+func init() {
+	// the first registered display denom of a base denom is the one converted back to
+	for _, display := range []string{sdk.DefaultBondDenom, DenomOKT} {
+		if err := RegisterDenom(display, DenomWei, sdk.Precision); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// DenomUnit - structure of a display denom and its base denom on chain
+// Note: 1 display unit equals 10^Decimals base units
+type DenomUnit struct {
+	Display  string `json:"display"`
+	Base     string `json:"base"`
+	Decimals int64  `json:"decimals"`
+}
+
+// RegisterDenom registers a display denom with its base denom and the decimals between them into the denom registry
+// Note: the decimals can't exceed sdk.Precision because sdk.Dec holds 18 decimal places at most
+func RegisterDenom(display, base string, decimals int64) error {
+	if len(display) == 0 || len(base) == 0 {
+		return errors.New("failed. empty display or base denom")
+	}
+
+	if decimals < 0 || decimals > sdk.Precision {
+		return fmt.Errorf("failed. decimals %d is out of range [0, %d]", decimals, sdk.Precision)
+	}
+
+	display, base = strings.ToLower(display), strings.ToLower(base)
+	denomRegistry.Lock()
+	defer denomRegistry.Unlock()
+	denomRegistry.units[display] = DenomUnit{
+		Display:  display,
+		Base:     base,
+		Decimals: decimals,
+	}
+	if _, ok := denomRegistry.bases[base]; !ok {
+		denomRegistry.bases[base] = display
+	}
+
+	return nil
+}
+
+// GetDenomUnit gets the denom unit of a display denom or a base denom
+// Note: the unregistered denom is regarded as a display denom of itself with sdk.Precision decimals, which is how the
+// chain converts sdk.Dec to the integer amount
+func GetDenomUnit(denom string) (unit DenomUnit, ok bool) {
+	denom = strings.ToLower(denom)
+	denomRegistry.RLock()
+	defer denomRegistry.RUnlock()
+	if unit, ok = denomRegistry.units[denom]; ok {
+		return
+	}
+
+	if display, isBase := denomRegistry.bases[denom]; isBase {
+		return denomRegistry.units[display], true
+	}
+
+	return DenomUnit{
+		Display:  denom,
+		Base:     denom,
+		Decimals: sdk.Precision,
+	}, false
+}
+
+// ParseDecimal parses a non-negative decimal string into the integer amount scaled by 10^decimals
+// Note: an error is returned instead of truncating if there are more fractional digits than decimals
+func ParseDecimal(amountStr string, decimals int64) (*big.Int, error) {
+	amountStr = strings.TrimSpace(amountStr)
+	if len(amountStr) == 0 {
+		return nil, errors.New("failed. empty amount")
+	}
+
+	intPart, fracPart := amountStr, ""
+	if i := strings.IndexByte(amountStr, '.'); i >= 0 {
+		intPart, fracPart = amountStr[:i], amountStr[i+1:]
+	}
+
+	if len(intPart) == 0 && len(fracPart) == 0 || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, fmt.Errorf("failed. invalid decimal amount: %s", amountStr)
+	}
+
+	fracPart = strings.TrimRight(fracPart, "0")
+	if int64(len(fracPart)) > decimals {
+		return nil, fmt.Errorf("failed. amount %s exceeds the precision of %d decimals", amountStr, decimals)
+	}
+
+	amount, _ := new(big.Int).SetString(intPart+fracPart+strings.Repeat("0", int(decimals)-len(fracPart)), 10)
+	return amount, nil
+}
+
+// FormatDecimal formats the integer amount scaled by 10^decimals into a decimal string without trailing zeros
+func FormatDecimal(amount *big.Int, decimals int64) string {
+	if amount == nil {
+		return "0"
+	}
+
+	sign, absStr := "", new(big.Int).Abs(amount).String()
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+
+	if decimals <= 0 {
+		return sign + absStr
+	}
+
+	if pad := int(decimals) + 1 - len(absStr); pad > 0 {
+		absStr = strings.Repeat("0", pad) + absStr
+	}
+
+	point := len(absStr) - int(decimals)
+	intPart, fracPart := absStr[:point], strings.TrimRight(absStr[point:], "0")
+	if len(fracPart) == 0 {
+		return sign + intPart
+	}
+
+	return fmt.Sprintf("%s%s.%s", sign, intPart, fracPart)
+}
+
+// ParseCoinToBase parses a coin string like "0.1024okt" or "1024wei" into the integer amount in base denom
+func ParseCoinToBase(coinStr string) (amount *big.Int, baseDenom string, err error) {
+	matches := reCoin.FindStringSubmatch(strings.TrimSpace(coinStr))
+	if matches == nil {
+		return nil, "", fmt.Errorf("failed. invalid coin expression: %s", coinStr)
+	}
+
+	unit, _ := GetDenomUnit(matches[2])
+	decimals := unit.Decimals
+	if strings.EqualFold(matches[2], unit.Base) && unit.Base != unit.Display {
+		// amount in base denom already
+		decimals = 0
+	}
+
+	if amount, err = ParseDecimal(matches[1], decimals); err != nil {
+		return
+	}
+
+	return amount, unit.Base, nil
+}
+
+// DecToBase converts the amount of sdk.Dec in a denom into the integer amount in base denom
+func DecToBase(dec sdk.Dec, denom string) (amount *big.Int, baseDenom string, err error) {
+	if dec.IsNil() || dec.IsNegative() {
+		return nil, "", fmt.Errorf("failed. invalid amount %s of %s", dec, denom)
+	}
+
+	unit, _ := GetDenomUnit(denom)
+	decimals := unit.Decimals
+	if strings.EqualFold(denom, unit.Base) && unit.Base != unit.Display {
+		decimals = 0
+	}
+
+	// the inner big.Int of sdk.Dec is scaled by 10^sdk.Precision
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision-decimals), nil)
+	amount, remainder := new(big.Int).QuoRem(dec.BigInt(), scale, new(big.Int))
+	if remainder.Sign() != 0 {
+		return nil, "", fmt.Errorf("failed. amount %s%s exceeds the precision of %d decimals", dec, denom, decimals)
+	}
+
+	return amount, unit.Base, nil
+}
+
+// BaseToDec converts the integer amount in base denom into the amount of sdk.Dec in display denom
+func BaseToDec(amount *big.Int, baseDenom string) (dec sdk.Dec, displayDenom string, err error) {
+	if amount == nil || amount.Sign() < 0 {
+		return dec, "", fmt.Errorf("failed. invalid amount %s of %s", amount, baseDenom)
+	}
+
+	unit, _ := GetDenomUnit(baseDenom)
+	if !strings.EqualFold(baseDenom, unit.Base) {
+		return dec, "", fmt.Errorf("failed. %s isn't a base denom", baseDenom)
+	}
+
+	return sdk.NewDecFromBigIntWithPrec(amount, unit.Decimals), unit.Display, nil
+}
+
+// DecCoinToBase converts a DecCoin into the integer amount in base denom
+func DecCoinToBase(coin sdk.DecCoin) (*big.Int, string, error) {
+	return DecToBase(coin.Amount, coin.Denom)
+}
+
+// BaseToDecCoin converts the integer amount in base denom into a DecCoin in display denom
+func BaseToDecCoin(amount *big.Int, baseDenom string) (coin sdk.DecCoin, err error) {
+	dec, displayDenom, err := BaseToDec(amount, baseDenom)
+	if err != nil {
+		return
+	}
+
+	return sdk.NewDecCoinFromDec(displayDenom, dec), nil
+}
+
+// DecCoinToCoinAdapter converts a DecCoin into the CoinAdapter in base denom used by ibc
+func DecCoinToCoinAdapter(coin sdk.DecCoin) (adapter sdk.CoinAdapter, err error) {
+	amount, baseDenom, err := DecCoinToBase(coin)
+	if err != nil {
+		return
+	}
+
+	adapter = sdk.CoinAdapter{
+		Denom:  baseDenom,
+		Amount: sdk.NewIntFromBigInt(amount),
+	}
+	if err = adapter.Validate(); err != nil {
+		return adapter, fmt.Errorf("failed. invalid CoinAdapter %s: %s", adapter, err)
+	}
+
+	return
+}
+
+// CoinAdapterToDecCoin converts a CoinAdapter in base denom into a DecCoin in display denom
+func CoinAdapterToDecCoin(adapter sdk.CoinAdapter) (sdk.DecCoin, error) {
+	if adapter.Amount.IsNil() {
+		return sdk.DecCoin{}, fmt.Errorf("failed. nil amount of %s", adapter.Denom)
+	}
+
+	return BaseToDecCoin(adapter.Amount.BigInt(), adapter.Denom)
+}
+
+// FormatBaseAmount formats the integer amount in base denom into a coin string in display denom, like "0.1024okt"
+func FormatBaseAmount(amount *big.Int, baseDenom string) string {
+	unit, _ := GetDenomUnit(baseDenom)
+	if !strings.EqualFold(baseDenom, unit.Base) {
+		return FormatDecimal(amount, 0) + baseDenom
+	}
+
+	return FormatDecimal(amount, unit.Decimals) + unit.Display
+}
+
+// ParseDecCoin parses a coin string into DecCoin and makes sure that it can be converted to base denom without truncation
+func ParseDecCoin(coinStr string) (coin sdk.DecCoin, err error) {
+	if coin, err = sdk.ParseDecCoin(coinStr); err != nil {
+		return
+	}
+
+	if _, _, err = DecCoinToBase(coin); err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	return
+}
+
+// ParseDecCoins parses a coins string into DecCoins and makes sure that they can be converted to base denom without
+// truncation
+func ParseDecCoins(coinsStr string) (coins sdk.SysCoins, err error) {
+	if coins, err = sdk.ParseDecCoins(coinsStr); err != nil {
+		return
+	}
+
+	for _, coin := range coins {
+		if _, _, err = DecCoinToBase(coin); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+func isDigits(str string) bool {
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
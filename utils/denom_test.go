@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	amount, err := ParseDecimal("0.1024", 18)
+	require.NoError(t, err)
+	require.Equal(t, "102400000000000000", amount.String())
+
+	amount, err = ParseDecimal("1024.500", 1)
+	require.NoError(t, err)
+	require.Equal(t, "10245", amount.String())
+
+	amount, err = ParseDecimal(".5", 6)
+	require.NoError(t, err)
+	require.Equal(t, "500000", amount.String())
+
+	// no truncation
+	_, err = ParseDecimal("0.0000001", 6)
+	require.Error(t, err)
+
+	for _, invalidStr := range []string{"", ".", "-1", "1.2.3", "1e18", "abc"} {
+		_, err = ParseDecimal(invalidStr, 18)
+		require.Error(t, err)
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	require.Equal(t, "0.1024", FormatDecimal(big.NewInt(102400000000000000), 18))
+	require.Equal(t, "1024", FormatDecimal(big.NewInt(1024000000), 6))
+	require.Equal(t, "0.000001", FormatDecimal(big.NewInt(1), 6))
+	require.Equal(t, "-1.5", FormatDecimal(big.NewInt(-15), 1))
+	require.Equal(t, "1024", FormatDecimal(big.NewInt(1024), 0))
+	require.Equal(t, "0", FormatDecimal(nil, 18))
+}
+
+func TestDenomRegistry(t *testing.T) {
+	unit, ok := GetDenomUnit("OKT")
+	require.True(t, ok)
+	require.Equal(t, DenomOKT, unit.Display)
+	require.Equal(t, DenomWei, unit.Base)
+	require.Equal(t, int64(sdk.Precision), unit.Decimals)
+
+	unit, ok = GetDenomUnit(DenomWei)
+	require.True(t, ok)
+	require.Equal(t, sdk.DefaultBondDenom, unit.Display)
+
+	unit, ok = GetDenomUnit("btc-000")
+	require.False(t, ok)
+	require.Equal(t, "btc-000", unit.Base)
+
+	require.NoError(t, RegisterDenom("usdk", "uusdk", 6))
+	unit, ok = GetDenomUnit("uusdk")
+	require.True(t, ok)
+	require.Equal(t, "usdk", unit.Display)
+	require.Equal(t, int64(6), unit.Decimals)
+
+	require.Error(t, RegisterDenom("", "uusdk", 6))
+	require.Error(t, RegisterDenom("usdk", "uusdk", 19))
+}
+
+func TestParseCoinToBase(t *testing.T) {
+	amount, denom, err := ParseCoinToBase("0.1024okt")
+	require.NoError(t, err)
+	require.Equal(t, DenomWei, denom)
+	require.Equal(t, "102400000000000000", amount.String())
+
+	amount, denom, err = ParseCoinToBase("1024wei")
+	require.NoError(t, err)
+	require.Equal(t, DenomWei, denom)
+	require.Equal(t, "1024", amount.String())
+
+	_, _, err = ParseCoinToBase("0.5wei")
+	require.Error(t, err)
+
+	_, _, err = ParseCoinToBase("okt")
+	require.Error(t, err)
+}
+
+func TestDecCoinConversions(t *testing.T) {
+	coin, err := ParseDecCoin("0.000000000000000001okt")
+	require.NoError(t, err)
+
+	adapter, err := DecCoinToCoinAdapter(coin)
+	require.NoError(t, err)
+	require.Equal(t, DenomWei, adapter.Denom)
+	require.Equal(t, "1", adapter.Amount.String())
+
+	decCoin, err := CoinAdapterToDecCoin(sdk.CoinAdapter{Denom: DenomWei, Amount: sdk.NewInt(1024)})
+	require.NoError(t, err)
+	require.Equal(t, sdk.DefaultBondDenom, decCoin.Denom)
+	require.Equal(t, "0.000000000000001024", decCoin.Amount.String())
+
+	amount, denom, err := DecCoinToBase(sdk.NewDecCoinFromDec("btc-000", sdk.MustNewDecFromStr("1.5")))
+	require.NoError(t, err)
+	require.Equal(t, "btc-000", denom)
+	require.Equal(t, "1500000000000000000", amount.String())
+
+	_, err = ParseDecCoin("0.5wei")
+	require.Error(t, err)
+
+	coins, err := ParseDecCoins("1024wei,0.1okt")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(coins))
+
+	_, err = ParseDecCoins("1okt,0.5wei")
+	require.Error(t, err)
+
+	_, err = BaseToDecCoin(big.NewInt(-1), DenomWei)
+	require.Error(t, err)
+
+	_, err = BaseToDecCoin(big.NewInt(1), DenomOKT)
+	require.Error(t, err)
+
+	require.Equal(t, "0.1024"+sdk.DefaultBondDenom, FormatBaseAmount(big.NewInt(102400000000000000), DenomWei))
+}
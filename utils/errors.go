@@ -26,3 +26,8 @@ func ErrFilterDataFromBaseResponse(kind, errMsg string) error {
 func ErrFilterDataFromListResponse(kind, errMsg string) error {
 	return fmt.Errorf("failed. filter %s data from list response error: %s", kind, errMsg)
 }
+
+// ErrEventAttributeNotFound returns an error when the attribute isn't found in the events of a tx
+func ErrEventAttributeNotFound(eventType, key string) error {
+	return fmt.Errorf("failed. attribute %s of event %s not found in tx", key, eventType)
+}
@@ -21,4 +21,6 @@ func TestErrors(t *testing.T) {
 	require.True(t, strings.Contains(errStr, errMsg) && strings.Contains(errStr, kind))
 	errStr = ErrFilterDataFromListResponse(kind, errMsg).Error()
 	require.True(t, strings.Contains(errStr, errMsg) && strings.Contains(errStr, kind))
+	errStr = ErrEventAttributeNotFound(kind, errMsg).Error()
+	require.True(t, strings.Contains(errStr, errMsg) && strings.Contains(errStr, kind))
 }
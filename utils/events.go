@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"fmt"
+
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+)
+
+// NewTxResponseFromResultTx converts the tx result from tendermint into sdk.TxResponse, so that the same event decoders
+// work on both the broadcast response and the queried tx
+func NewTxResponseFromResultTx(pResultTx *ctypes.ResultTx) sdk.TxResponse {
+	resp := sdk.NewResponseResultTx(pResultTx, nil, "")
+	if pResultTx != nil && len(resp.Logs) == 0 && len(pResultTx.TxResult.Events) != 0 {
+		// the raw log of a failed or an old tx isn't JSON formatted, fall back to the events of the tx result
+		resp.Logs = sdk.ABCIMessageLogs{{Log: resp.RawLog, Events: GetEventsFromResultTx(pResultTx)}}
+	}
+
+	return resp
+}
+
+// GetEventsFromResultTx gets all the events of the tx result from tendermint
+// Note: besides the events of msgs, the events emitted by the ante handler like the fee deduction are included
+func GetEventsFromResultTx(pResultTx *ctypes.ResultTx) sdk.StringEvents {
+	if pResultTx == nil {
+		return nil
+	}
+
+	events := make(sdk.Events, len(pResultTx.TxResult.Events))
+	for i, event := range pResultTx.TxResult.Events {
+		events[i] = sdk.Event(event)
+	}
+
+	return sdk.StringifyEvents(events)
+}
+
+// GetEventsFromTxResponse gets the events of all msgs in the tx response
+func GetEventsFromTxResponse(resp sdk.TxResponse) sdk.StringEvents {
+	logs := resp.Logs
+	if len(logs) == 0 && len(resp.RawLog) != 0 {
+		logs, _ = sdk.ParseABCILogs(resp.RawLog)
+	}
+
+	var events sdk.StringEvents
+	for _, log := range logs {
+		events = append(events, log.Events...)
+	}
+
+	return events
+}
+
+// ParseEventAttributes splits the attributes of a specific event type into one key-value map per emitted event
+// Note: the events in the abci logs are flattened by type, so a new event begins whenever an attribute key repeats
+func ParseEventAttributes(events sdk.StringEvents, eventType string) []map[string]string {
+	var attrsList []map[string]string
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+
+		var attrs map[string]string
+		for _, attr := range event.Attributes {
+			if _, ok := attrs[attr.Key]; ok || attrs == nil {
+				attrs = make(map[string]string)
+				attrsList = append(attrsList, attrs)
+			}
+			attrs[attr.Key] = attr.Value
+		}
+	}
+
+	return attrsList
+}
+
+// GetEventAttributeValue gets the value of the attribute key in the first event of a specific type
+func GetEventAttributeValue(events sdk.StringEvents, eventType, key string) (string, error) {
+	for _, attrs := range ParseEventAttributes(events, eventType) {
+		if value, ok := attrs[key]; ok {
+			return value, nil
+		}
+	}
+
+	return "", ErrEventAttributeNotFound(eventType, key)
+}
+
+// ParseEventCoins parses the coins string of an event attribute, the empty string stands for empty coins
+func ParseEventCoins(coinsStr string) (sdk.SysCoins, error) {
+	if len(coinsStr) == 0 {
+		return sdk.SysCoins{}, nil
+	}
+
+	coins, err := sdk.ParseDecCoins(coinsStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed. parse event coins [%s] error: %s", coinsStr, err)
+	}
+
+	return coins, nil
+}
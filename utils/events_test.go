@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+
+	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
+	abci "github.com/okx/okbchain/libs/tendermint/abci/types"
+	"github.com/okx/okbchain/libs/tendermint/libs/kv"
+	ctypes "github.com/okx/okbchain/libs/tendermint/rpc/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventAttributes(t *testing.T) {
+	events := sdk.StringEvents{
+		{
+			Type: "transfer",
+			Attributes: []sdk.Attribute{
+				{Key: "recipient", Value: "addr1"},
+				{Key: "sender", Value: "addr0"},
+				{Key: "amount", Value: "1.000000000000000000okt"},
+				{Key: "recipient", Value: "addr2"},
+				{Key: "amount", Value: "2.000000000000000000okt"},
+			},
+		},
+		{
+			Type:       "message",
+			Attributes: []sdk.Attribute{{Key: "sender", Value: "addr0"}},
+		},
+	}
+
+	attrsList := ParseEventAttributes(events, "transfer")
+	require.Equal(t, 2, len(attrsList))
+	require.Equal(t, map[string]string{"recipient": "addr1", "sender": "addr0", "amount": "1.000000000000000000okt"},
+		attrsList[0])
+	require.Equal(t, map[string]string{"recipient": "addr2", "amount": "2.000000000000000000okt"}, attrsList[1])
+	require.Equal(t, 0, len(ParseEventAttributes(events, "unknown")))
+
+	value, err := GetEventAttributeValue(events, "message", "sender")
+	require.NoError(t, err)
+	require.Equal(t, "addr0", value)
+
+	_, err = GetEventAttributeValue(events, "message", "module")
+	require.Error(t, err)
+}
+
+func TestGetEventsFromTxResponse(t *testing.T) {
+	events := sdk.StringEvents{{Type: "message", Attributes: []sdk.Attribute{{Key: "sender", Value: "addr0"}}}}
+	resp := sdk.TxResponse{Logs: sdk.ABCIMessageLogs{{MsgIndex: 0, Events: events}}}
+	require.Equal(t, events, GetEventsFromTxResponse(resp))
+
+	// parse from raw log
+	resp = sdk.TxResponse{RawLog: `[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"sender","value":"addr0"}]}]}]`}
+	require.Equal(t, events, GetEventsFromTxResponse(resp))
+
+	// bad raw log
+	resp = sdk.TxResponse{RawLog: "default raw log"}
+	require.Equal(t, 0, len(GetEventsFromTxResponse(resp)))
+}
+
+func TestNewTxResponseFromResultTx(t *testing.T) {
+	resultTx := &ctypes.ResultTx{
+		Height: 1024,
+		TxResult: abci.ResponseDeliverTx{
+			Log: "default raw log",
+			Events: []abci.Event{
+				{Type: "message", Attributes: []kv.Pair{{Key: []byte("sender"), Value: []byte("addr0")}}},
+			},
+		},
+	}
+
+	resp := NewTxResponseFromResultTx(resultTx)
+	require.Equal(t, int64(1024), resp.Height)
+	value, err := GetEventAttributeValue(GetEventsFromTxResponse(resp), "message", "sender")
+	require.NoError(t, err)
+	require.Equal(t, "addr0", value)
+
+	require.Equal(t, sdk.TxResponse{}, NewTxResponseFromResultTx(nil))
+}
+
+func TestParseEventCoins(t *testing.T) {
+	coins, err := ParseEventCoins("1.024000000000000000okt")
+	require.NoError(t, err)
+	require.Equal(t, "1.024000000000000000okt", coins.String())
+
+	coins, err = ParseEventCoins("")
+	require.NoError(t, err)
+	require.True(t, coins.IsZero())
+
+	_, err = ParseEventCoins("bad coins")
+	require.Error(t, err)
+}
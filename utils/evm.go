@@ -120,3 +120,9 @@ func (pb *PayloadBuilder) Build(methodName string, args ...interface{}) (payload
 
 	return
 }
+
+// Unpack gets the outputs of the method from the return data of a call in evm module, or the non-indexed values of the
+// event from the data of a log
+func (pb *PayloadBuilder) Unpack(methodName string, data []byte) ([]interface{}, error) {
+	return pb.innerABI.Unpack(methodName, data)
+}
@@ -110,6 +110,23 @@ func TestPayloadBuilder_Build(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPayloadBuilder_Unpack(t *testing.T) {
+	payloadBuilder, err := NewPayloadBuilder(erc20bin, abiJSON)
+	require.NoError(t, err)
+
+	outputs, err := payloadBuilder.Unpack("balanceOf", ethcmn.LeftPadBytes([]byte{0x04, 0x00}, 32))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(outputs))
+	require.Equal(t, big.NewInt(1024), outputs[0])
+
+	// error test
+	_, err = payloadBuilder.Unpack("balanceOf", []byte{0x04})
+	require.Error(t, err)
+
+	_, err = payloadBuilder.Unpack("unknownName", nil)
+	require.Error(t, err)
+}
+
 func TestEthAddresses(t *testing.T) {
 	ethAddrs := EthAddresses([]string{defaultAddrEth})
 	require.Equal(t, 1, len(ethAddrs))
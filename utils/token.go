@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/okex/exchain-go-sdk/module/token/types"
-	sdk "github.com/okx/okbchain/libs/cosmos-sdk/types"
 	tokentypes "github.com/okx/okbchain/x/token/types"
 )
 
@@ -25,12 +24,12 @@ func ParseTransfersStr(str string) ([]types.TransferUnit, error) {
 		}
 		addrStr, coinStr := s[0], s[1]
 
-		to, err := sdk.AccAddressFromBech32(addrStr)
+		to, err := ToCosmosAddress(addrStr)
 		if err != nil {
 			return nil, err
 		}
 
-		coins, err := sdk.ParseDecCoins(coinStr)
+		coins, err := ParseDecCoins(coinStr)
 		if err != nil {
 			return nil, err
 		}
@@ -34,6 +34,11 @@ func TestParseTransfersStr(t *testing.T) {
 	require.Equal(t, addr2, transferUnits[1].To)
 	require.Equal(t, coins2, transferUnits[1].Coins)
 
+	// hex address is accepted
+	transferUnits, err = ParseTransfersStr(fmt.Sprintf("%s %s", "0x04A987fa1Bd4b2B908e9A3Ca058cc8BD43035991", coinsStr1))
+	require.NoError(t, err)
+	require.Equal(t, addr1, transferUnits[0].To)
+
 	badTransfersStr := fmt.Sprintf("%s %s\n%s %s %s", accAddr1, coinsStr1, accAddr2, coinsStr2, "4.096eth")
 	_, err = ParseTransfersStr(badTransfersStr)
 	require.Error(t, err)